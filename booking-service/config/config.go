@@ -3,19 +3,22 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	ServiceName string
-	Port        string
-	DBHost      string
-	DBPort      string
-	DBName      string
-	DBUser      string
-	DBPassword  string
-	DBSSLMode   string
+	ServiceName  string
+	Port         string
+	GRPCPort     int
+	DBHost       string
+	DBPort       string
+	DBName       string
+	DBUser       string
+	DBPassword   string
+	DBSSLMode    string
+	TicketSecret string
 }
 
 func LoadEnv(prefix string) (*Config, error) {
@@ -35,6 +38,10 @@ func LoadEnv(prefix string) (*Config, error) {
 	if err != nil { return nil, err }
 	port, err := getReq("PORT")
 	if err != nil { return nil, err }
+	grpcPortStr, err := getReq("GRPC_PORT")
+	if err != nil { return nil, err }
+	grpcPort, err := strconv.Atoi(grpcPortStr)
+	if err != nil { return nil, fmt.Errorf("invalid GRPC_PORT: %v", err) }
 	host, err := getReq("DB_HOST")
 	if err != nil { return nil, err }
 	dbPort, err := getReq("DB_PORT")
@@ -47,16 +54,20 @@ func LoadEnv(prefix string) (*Config, error) {
 	if err != nil { return nil, err }
 	sslMode, err := getReq("DB_SSLMODE")
 	if err != nil { return nil, err }
+	ticketSecret, err := getReq("TICKET_SECRET")
+	if err != nil { return nil, err }
 
 	return &Config{
-		ServiceName: name,
-		Port:        port,
-		DBHost:      host,
-		DBPort:      dbPort,
-		DBName:      dbName,
-		DBUser:      dbUser,
-		DBPassword:  dbPass,
-		DBSSLMode:   sslMode,
+		ServiceName:  name,
+		Port:         port,
+		GRPCPort:     grpcPort,
+		DBHost:       host,
+		DBPort:       dbPort,
+		DBName:       dbName,
+		DBUser:       dbUser,
+		DBPassword:   dbPass,
+		DBSSLMode:    sslMode,
+		TicketSecret: ticketSecret,
 	}, nil
 }
 
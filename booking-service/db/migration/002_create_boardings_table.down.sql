@@ -0,0 +1 @@
+DROP TABLE IF EXISTS boardings;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS boardings (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT UNIQUE NOT NULL REFERENCES bookings(id),
+    schedule_id BIGINT NOT NULL,
+    gate VARCHAR(50),
+    checked_in_by BIGINT,
+    boarded_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_boardings_schedule_id ON boardings(schedule_id);
\ No newline at end of file
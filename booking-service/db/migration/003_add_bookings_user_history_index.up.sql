@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_bookings_user_id_id ON bookings(user_id, id DESC);
\ No newline at end of file
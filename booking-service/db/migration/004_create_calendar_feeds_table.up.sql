@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user_id BIGINT PRIMARY KEY,
+    token VARCHAR(64) UNIQUE NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    rotated_at TIMESTAMP NULL
+);
\ No newline at end of file
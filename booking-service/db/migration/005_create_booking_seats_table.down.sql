@@ -0,0 +1 @@
+DROP TABLE IF EXISTS booking_seats;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS booking_seats (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL REFERENCES bookings(id),
+    schedule_id BIGINT NOT NULL,
+    coach_number INTEGER NOT NULL,
+    seat_number VARCHAR(4) NOT NULL,
+    class VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (schedule_id, coach_number, seat_number)
+);
+
+CREATE INDEX idx_booking_seats_booking_id ON booking_seats(booking_id);
\ No newline at end of file
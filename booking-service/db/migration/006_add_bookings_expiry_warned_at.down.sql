@@ -0,0 +1 @@
+ALTER TABLE bookings DROP COLUMN IF EXISTS expiry_warned_at;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS expiry_warned_at TIMESTAMP NULL;
\ No newline at end of file
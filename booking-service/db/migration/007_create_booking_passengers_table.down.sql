@@ -0,0 +1 @@
+DROP TABLE IF EXISTS booking_passengers;
\ No newline at end of file
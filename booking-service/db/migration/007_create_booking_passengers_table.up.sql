@@ -0,0 +1,13 @@
+-- Who travels on a booking. traveller_id points at the user's saved
+-- traveller in user-service when the passenger was picked from there; the
+-- name and type are copied so later edits do not change past bookings
+CREATE TABLE IF NOT EXISTS booking_passengers (
+    id BIGSERIAL PRIMARY KEY,
+    booking_id BIGINT NOT NULL REFERENCES bookings(id),
+    traveller_id BIGINT NULL,
+    full_name VARCHAR(100) NOT NULL,
+    passenger_type VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_booking_passengers_booking_id ON booking_passengers(booking_id);
\ No newline at end of file
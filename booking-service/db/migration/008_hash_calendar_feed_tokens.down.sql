@@ -0,0 +1,3 @@
+-- Hashed tokens cannot be turned back into URLs; users get a new one
+DELETE FROM calendar_feeds;
+ALTER TABLE calendar_feeds RENAME COLUMN token_hash TO token;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- Feed tokens are kept only as their SHA-256, so a leaked table does not
+-- leak working feed URLs. Hashing the existing tokens keeps their URLs valid
+ALTER TABLE calendar_feeds RENAME COLUMN token TO token_hash;
+UPDATE calendar_feeds SET token_hash = encode(sha256(convert_to(token_hash, 'UTF8')), 'hex');
\ No newline at end of file
@@ -7,6 +7,8 @@ replace ticket-booking/proto => ../proto
 require (
 	github.com/jackc/pgx/v5 v5.6.0
 	github.com/joho/godotenv v1.5.1
+	github.com/jung-kurt/gofpdf v1.16.2
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	ticket-booking/proto v0.0.0-00010101000000-000000000000
 )
 
@@ -0,0 +1,106 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	calendarProdID = "-//ticket-booking//Trips//EN"
+	icsTimeLayout  = "20060102T150405Z"
+	icsLineOctets  = 75
+)
+
+// CalendarEvent is one trip as it appears in a calendar app. UID must stay
+// the same for a booking so re-imports and feed refreshes update the event
+// in place; Sequence must grow whenever the trip changes.
+type CalendarEvent struct {
+	UID         string
+	Summary     string
+	Location    string
+	Description string
+	Start       time.Time
+	End         time.Time
+	Cancelled   bool
+	Sequence    int
+	Updated     time.Time
+}
+
+// RenderCalendar writes the events as an RFC 5545 VCALENDAR. Times are
+// emitted in UTC so no VTIMEZONE blocks are needed; calendar apps show them
+// in the viewer's zone.
+func RenderCalendar(name string, events []CalendarEvent) []byte {
+	var buf bytes.Buffer
+	line := func(name, value string) {
+		writeFolded(&buf, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", calendarProdID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if name != "" {
+		line("X-WR-CALNAME", escapeText(name))
+	}
+
+	now := time.Now().UTC().Format(icsTimeLayout)
+	for _, e := range events {
+		status := "CONFIRMED"
+		if e.Cancelled {
+			status = "CANCELLED"
+		}
+
+		line("BEGIN", "VEVENT")
+		line("UID", e.UID)
+		line("DTSTAMP", now)
+		line("DTSTART", e.Start.UTC().Format(icsTimeLayout))
+		line("DTEND", e.End.UTC().Format(icsTimeLayout))
+		line("SUMMARY", escapeText(e.Summary))
+		line("LOCATION", escapeText(e.Location))
+		line("DESCRIPTION", escapeText(e.Description))
+		line("STATUS", status)
+		line("SEQUENCE", fmt.Sprintf("%d", e.Sequence))
+		if !e.Updated.IsZero() {
+			line("LAST-MODIFIED", e.Updated.UTC().Format(icsTimeLayout))
+		}
+		line("TRANSP", "OPAQUE")
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return buf.Bytes()
+}
+
+// escapeText applies the TEXT value escaping of RFC 5545 section 3.3.11.
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeFolded ends the content line with CRLF, folding it so no physical line
+// exceeds 75 octets and no UTF-8 sequence is split across a fold.
+func writeFolded(buf *bytes.Buffer, s string) {
+	limit := icsLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		buf.WriteString(s[:cut])
+		buf.WriteString("\r\n ")
+		s = s[cut:]
+		// the leading space of a continuation line counts towards its length
+		limit = icsLineOctets - 1
+	}
+	buf.WriteString(s)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,122 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "ticket-booking/proto/booking"
+
+	"github.com/jung-kurt/gofpdf"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const (
+	pageMargin = 15.0
+	labelWidth = 45.0
+	rowHeight  = 7.0
+	qrSize     = 40.0
+)
+
+// RenderItinerary builds a single-page PDF holding the travel itinerary and
+// the payment receipt for a booking. When the booking carries a signed ticket
+// payload it is rendered as a QR code for gate check-in.
+func RenderItinerary(b *pb.Booking) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetTitle("Itinerary "+b.BookingCode, false)
+	pdf.SetCreator("ticket-booking", false)
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 10, "E-Ticket & Receipt", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, "Booking code: "+b.BookingCode, "", 1, "L", false, 0, "")
+
+	if b.TicketPayload != "" {
+		png, err := qrcode.Encode(b.TicketPayload, qrcode.Medium, 256)
+		if err != nil {
+			return nil, err
+		}
+		pdf.RegisterImageOptionsReader("ticket-qr", gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(png))
+		pdf.ImageOptions("ticket-qr", 210-pageMargin-qrSize, pageMargin, qrSize, qrSize, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+	}
+
+	pdf.SetY(pageMargin + qrSize + 5)
+
+	section(pdf, "Itinerary")
+	row(pdf, "Train", b.TrainName)
+	row(pdf, "Route", b.Origin+" - "+b.Destination)
+	row(pdf, "Departure", formatTime(b.DepartureTime))
+	row(pdf, "Arrival", formatTime(b.ArrivalTime))
+	row(pdf, "Passengers", fmt.Sprintf("%d", b.SeatCount))
+	if len(b.Seats) > 0 {
+		row(pdf, "Seats", FormatSeats(b.Seats))
+	}
+
+	section(pdf, "Receipt")
+	unitPrice := 0.0
+	if b.SeatCount > 0 {
+		unitPrice = b.TotalPrice / float64(b.SeatCount)
+	}
+	row(pdf, "Fare per seat", formatRupiah(unitPrice))
+	row(pdf, "Quantity", fmt.Sprintf("x %d", b.SeatCount))
+	row(pdf, "Total paid", formatRupiah(b.TotalPrice))
+	row(pdf, "Payment status", b.Status)
+	row(pdf, "Payment reference", b.BookingCode)
+	row(pdf, "Booked at", formatTime(b.CreatedAt))
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func section(pdf *gofpdf.Fpdf, title string) {
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.SetFillColor(230, 230, 230)
+	pdf.CellFormat(0, 8, title, "", 1, "L", true, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+}
+
+func row(pdf *gofpdf.Fpdf, label, value string) {
+	pdf.CellFormat(labelWidth, rowHeight, label, "B", 0, "L", false, 0, "")
+	pdf.CellFormat(0, rowHeight, value, "B", 1, "L", false, 0, "")
+}
+
+func formatTime(value string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return t.Format("02 Jan 2006 15:04")
+}
+
+func formatRupiah(amount float64) string {
+	s := fmt.Sprintf("%.0f", amount)
+	var out []byte
+	for i := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			out = append(out, '.')
+		}
+		out = append(out, s[i])
+	}
+	return "Rp " + string(out)
+}
+
+// FormatSeats lists seats as coach-seat pairs with their class, e.g.
+// "3-12A, 3-12B (economy)".
+func FormatSeats(seats []*pb.BookedSeat) string {
+	labels := make([]string, len(seats))
+	classes := make([]string, 0, 1)
+	for i, seat := range seats {
+		labels[i] = fmt.Sprintf("%d-%s", seat.CoachNumber, seat.SeatNumber)
+		if len(classes) == 0 || classes[len(classes)-1] != seat.Class {
+			classes = append(classes, seat.Class)
+		}
+	}
+	return strings.Join(labels, ", ") + " (" + strings.Join(classes, ", ") + ")"
+}
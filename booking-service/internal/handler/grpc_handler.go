@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"ticket-booking/booking-service/internal/repository"
+	"ticket-booking/booking-service/internal/service"
+	"ticket-booking/proto/auth"
+	pb "ticket-booking/proto/booking"
+)
+
+type GrpcServer struct {
+	pb.UnimplementedBookingServiceServer
+	bookingService  service.BookingService
+	calendarService service.CalendarService
+}
+
+func NewGrpcServer(bookingService service.BookingService, calendarService service.CalendarService) *GrpcServer {
+	return &GrpcServer{bookingService: bookingService, calendarService: calendarService}
+}
+
+func (s *GrpcServer) CreateBooking(ctx context.Context, req *pb.CreateBookingRequest) (*pb.CreateBookingResponse, error) {
+	id, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Saved travellers are checked against this user, so it must be the caller
+	req.UserId = id.UserID
+
+	booking, err := s.bookingService.CreateBooking(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CreateBookingResponse{Booking: booking}, nil
+}
+
+func (s *GrpcServer) GetBooking(ctx context.Context, req *pb.GetBookingRequest) (*pb.GetBookingResponse, error) {
+	id, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := s.bookingService.GetBooking(ctx, req.BookingId, id.UserID, id.HasAnyRole(auth.RoleOperator, auth.RoleSupport))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetBookingResponse{Booking: booking}, nil
+}
+
+func (s *GrpcServer) ListUserBookings(ctx context.Context, req *pb.ListUserBookingsRequest) (*pb.ListUserBookingsResponse, error) {
+	id, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserId != 0 && req.UserId != id.UserID {
+		return nil, status.Error(codes.PermissionDenied, "bookings of another user")
+	}
+	req.UserId = id.UserID
+
+	bookings, total, nextCursor, err := s.bookingService.ListUserBookings(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListUserBookingsResponse{
+		Bookings:   bookings,
+		Total:      total,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+func (s *GrpcServer) CancelBooking(ctx context.Context, req *pb.CancelBookingRequest) (*pb.CancelBookingResponse, error) {
+	id, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.bookingService.CancelBooking(ctx, req.BookingId, id.UserID)
+	if err != nil {
+		return &pb.CancelBookingResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.CancelBookingResponse{
+		Success: true,
+		Message: "Booking cancelled",
+	}, nil
+}
+
+func (s *GrpcServer) UpdatePaymentStatus(ctx context.Context, req *pb.UpdatePaymentStatusRequest) (*pb.UpdatePaymentStatusResponse, error) {
+	booking, err := s.bookingService.UpdatePaymentStatus(ctx, req.BookingId, req.Status)
+	if err != nil {
+		return &pb.UpdatePaymentStatusResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.UpdatePaymentStatusResponse{
+		Success: true,
+		Message: "Payment status updated",
+		Booking: booking,
+	}, nil
+}
+
+func (s *GrpcServer) CheckIn(ctx context.Context, req *pb.CheckInRequest) (*pb.CheckInResponse, error) {
+	id, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, boardedAt, err := s.bookingService.CheckIn(ctx, req, id.UserID)
+	if err != nil {
+		return &pb.CheckInResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.CheckInResponse{
+		Success:   true,
+		Message:   "Boarding recorded",
+		Booking:   booking,
+		BoardedAt: boardedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (s *GrpcServer) GetBoardingManifest(ctx context.Context, req *pb.GetBoardingManifestRequest) (*pb.GetBoardingManifestResponse, error) {
+	entries, err := s.bookingService.GetBoardingManifest(ctx, req.ScheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.GetBoardingManifestResponse{
+		ScheduleId: req.ScheduleId,
+		Entries:    entries,
+	}
+	for _, e := range entries {
+		switch e.Status {
+		case "boarded":
+			resp.Boarded++
+		case "no_show":
+			resp.NoShow++
+		default:
+			resp.NotBoarded++
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *GrpcServer) GetBookingDocument(ctx context.Context, req *pb.GetBookingDocumentRequest) (*pb.GetBookingDocumentResponse, error) {
+	id, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.bookingService.GetBookingDocument(ctx, req.BookingId, id.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetBookingDocumentResponse{
+		FileName:    fmt.Sprintf("itinerary-%d.pdf", req.BookingId),
+		ContentType: "application/pdf",
+		Content:     content,
+	}, nil
+}
+
+func (s *GrpcServer) WatchSeatAvailability(req *pb.WatchSeatAvailabilityRequest, stream pb.BookingService_WatchSeatAvailabilityServer) error {
+	return s.bookingService.WatchSeatAvailability(stream.Context(), req.ScheduleIds, stream.Send)
+}
+
+func (s *GrpcServer) GetBookingCalendar(ctx context.Context, req *pb.GetBookingCalendarRequest) (*pb.GetBookingCalendarResponse, error) {
+	id, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.calendarService.GetBookingCalendar(ctx, req.BookingId, id.UserID)
+	if err != nil {
+		return nil, calendarError(err)
+	}
+
+	return &pb.GetBookingCalendarResponse{
+		FileName:    fmt.Sprintf("booking-%d.ics", req.BookingId),
+		ContentType: "text/calendar; charset=utf-8",
+		Content:     content,
+	}, nil
+}
+
+func (s *GrpcServer) GetCalendarFeedToken(ctx context.Context, req *pb.GetCalendarFeedTokenRequest) (*pb.GetCalendarFeedTokenResponse, error) {
+	id, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.calendarService.GetCalendarFeedToken(ctx, id.UserID, req.Rotate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetCalendarFeedTokenResponse{Token: token}, nil
+}
+
+func (s *GrpcServer) GetCalendarFeed(ctx context.Context, req *pb.GetCalendarFeedRequest) (*pb.GetCalendarFeedResponse, error) {
+	content, err := s.calendarService.GetCalendarFeed(ctx, req.Token)
+	if err != nil {
+		return nil, calendarError(err)
+	}
+
+	return &pb.GetCalendarFeedResponse{
+		FileName:    "trips.ics",
+		ContentType: "text/calendar; charset=utf-8",
+		Content:     content,
+	}, nil
+}
+
+// calendarError reports unknown bookings and unknown or revoked feed tokens
+// as NotFound so the gateway can answer 404 rather than 500.
+func calendarError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrBookingNotFound), errors.Is(err, repository.ErrCalendarFeedNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrCalendarNotPaid):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+// caller is the user the gateway authenticated. Bookings are always read
+// and changed as that user, never as a user ID sent in the request.
+func caller(ctx context.Context) (auth.Identity, error) {
+	id, ok := auth.FromIncomingContext(ctx)
+	if !ok {
+		return auth.Identity{}, status.Error(codes.Unauthenticated, "not logged in")
+	}
+	return id, nil
+}
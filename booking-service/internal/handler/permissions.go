@@ -0,0 +1,12 @@
+package handler
+
+import "ticket-booking/proto/auth"
+
+// Permissions lists the RPCs for staff. Boarding is for the operator's crew;
+// payment results come from support, standing in for the payment provider.
+// Everything else acts on the caller's own bookings.
+var Permissions = auth.Rules{
+	"/booking.BookingService/CheckIn":             {auth.RoleOperator},
+	"/booking.BookingService/GetBoardingManifest": {auth.RoleOperator},
+	"/booking.BookingService/UpdatePaymentStatus": {auth.RoleSupport},
+}
@@ -0,0 +1,72 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+type TicketClaims struct {
+	BookingID   int64  `json:"booking_id"`
+	BookingCode string `json:"booking_code"`
+	ScheduleID  int64  `json:"schedule_id"`
+}
+
+type TicketSigner struct {
+	secretKey []byte
+}
+
+func NewTicketSigner(secretKey string) *TicketSigner {
+	return &TicketSigner{
+		secretKey: []byte(secretKey),
+	}
+}
+
+// Sign encodes the claims and appends an HMAC-SHA256 signature, producing the
+// string that is rendered into the ticket QR code.
+func (t *TicketSigner) Sign(claims *TicketClaims) (string, error) {
+	body, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(body)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(t.mac(encoded)), nil
+}
+
+func (t *TicketSigner) Verify(payload string) (*TicketClaims, error) {
+	parts := strings.Split(payload, ".")
+	if len(parts) != 2 {
+		return nil, errors.New("malformed ticket payload")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.New("malformed ticket signature")
+	}
+
+	if !hmac.Equal(signature, t.mac(parts[0])) {
+		return nil, errors.New("invalid ticket signature")
+	}
+
+	body, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errors.New("malformed ticket payload")
+	}
+
+	var claims TicketClaims
+	if err := json.Unmarshal(body, &claims); err != nil {
+		return nil, errors.New("malformed ticket payload")
+	}
+
+	return &claims, nil
+}
+
+func (t *TicketSigner) mac(data string) []byte {
+	h := hmac.New(sha256.New, t.secretKey)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
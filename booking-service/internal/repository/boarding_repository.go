@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "ticket-booking/proto/booking"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrAlreadyBoarded = errors.New("ticket already checked in")
+
+type BoardingRepository interface {
+	Create(ctx context.Context, bookingId, scheduleId int64, gate string, checkedInBy int64) (time.Time, error)
+	GetManifest(ctx context.Context, scheduleId int64) ([]*pb.BoardingManifestEntry, error)
+}
+
+type pgBoardingRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewBoardingRepository(pool *pgxpool.Pool) BoardingRepository {
+	return &pgBoardingRepo{pool: pool}
+}
+
+func (r *pgBoardingRepo) Create(ctx context.Context, bookingId, scheduleId int64, gate string, checkedInBy int64) (time.Time, error) {
+	var boardedAt time.Time
+
+	// The unique booking_id makes the second scan of the same ticket a no-op
+	// rather than a second boarding record.
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO boardings (booking_id, schedule_id, gate, checked_in_by, boarded_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (booking_id) DO NOTHING
+		RETURNING boarded_at`,
+		bookingId, scheduleId, gate, checkedInBy).Scan(&boardedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, ErrAlreadyBoarded
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return boardedAt, nil
+}
+
+func (r *pgBoardingRepo) GetManifest(ctx context.Context, scheduleId int64) ([]*pb.BoardingManifestEntry, error) {
+	query := `
+		SELECT b.id, b.booking_code, b.user_id, b.seat_count,
+		       CASE
+		           WHEN bd.id IS NOT NULL THEN 'boarded'
+		           WHEN s.departure_time < NOW() THEN 'no_show'
+		           ELSE 'not_boarded'
+		       END AS boarding_status,
+		       bd.boarded_at, bd.gate
+		FROM bookings b
+		LEFT JOIN schedules s ON b.schedule_id = s.id
+		LEFT JOIN boardings bd ON bd.booking_id = b.id
+		WHERE b.schedule_id = $1 AND b.status = 2 AND b.deleted_at IS NULL
+		ORDER BY b.id ASC`
+
+	rows, err := r.pool.Query(ctx, query, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*pb.BoardingManifestEntry
+	for rows.Next() {
+		var e pb.BoardingManifestEntry
+		var boardedAt *time.Time
+		var gate *string
+
+		err := rows.Scan(&e.BookingId, &e.BookingCode, &e.UserId, &e.SeatCount, &e.Status, &boardedAt, &gate)
+		if err != nil {
+			return nil, err
+		}
+
+		if boardedAt != nil {
+			e.BoardedAt = boardedAt.Format(time.RFC3339)
+		}
+		if gate != nil {
+			e.Gate = *gate
+		}
+
+		res = append(res, &e)
+	}
+
+	return res, rows.Err()
+}
@@ -2,12 +2,18 @@ package repository
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
+	"strings"
 	"time"
+	_ "time/tzdata" // station zones must resolve without system tzdata
 
 	pb "ticket-booking/proto/booking"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -20,15 +26,68 @@ type ScheduleDetails struct {
 	TrainName     string
 }
 
+const (
+	SortRecent   = "recent"
+	SortUpcoming = "upcoming"
+)
+
+// BookingListFilter narrows and orders a user's booking history. Status is the
+// stored status code, zero meaning any.
+type BookingListFilter struct {
+	Status        int
+	DepartureFrom string
+	DepartureTo   string
+	Origin        string
+	Destination   string
+	Sort          string
+	Page          int32
+	Limit         int32
+	Cursor        *BookingCursor
+}
+
+// BookingCursor marks the last row of a page so the next page can continue
+// from it with a keyset condition instead of an OFFSET.
+type BookingCursor struct {
+	ID            int64     `json:"id"`
+	DepartureTime time.Time `json:"departure_time"`
+}
+
+func (c *BookingCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeBookingCursor(s string) (*BookingCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	var c BookingCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	return &c, nil
+}
+
 type BookingRepository interface {
 	Create(ctx context.Context, req *pb.CreateBookingRequest) (*pb.Booking, error)
 	GetByID(ctx context.Context, id int64) (*pb.Booking, error)
-	ListByUser(ctx context.Context, userId int64, page, limit int32) ([]*pb.Booking, int32, error)
+	ListByUser(ctx context.Context, userId int64, filter BookingListFilter) ([]*pb.Booking, int32, string, error)
 	UpdateStatus(ctx context.Context, bookingId int64, status int) (*pb.Booking, error)
-	Cancel(ctx context.Context, bookingId, userId int64) error
-	ExpireBookings(ctx context.Context) error
+	Cancel(ctx context.Context, bookingId, userId int64) (int64, error)
+	ExpireBookings(ctx context.Context) ([]int64, error)
+	WarnExpiringBookings(ctx context.Context) (int64, error)
+	AvailableSeats(ctx context.Context, scheduleIds []int64) (map[int64]int32, error)
 }
 
+var (
+	ErrNotEnoughSeats    = errors.New("not enough seats available")
+	ErrBookingNotFound   = errors.New("booking not found")
+	ErrBookingNotPending = errors.New("booking is no longer pending")
+	ErrTravellerNotFound = errors.New("saved traveller not found")
+)
+
 type pgBookingRepo struct {
 	pool *pgxpool.Pool
 }
@@ -42,7 +101,26 @@ func (r *pgBookingRepo) Create(ctx context.Context, req *pb.CreateBookingRequest
 	expiredAt := time.Now().Add(10 * time.Minute)
 	bookingCode := generateBookingCode()
 
-	err := r.pool.QueryRow(ctx, `
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Seats are held as soon as the booking is pending and handed back when it
+	// fails, expires or is cancelled.
+	tag, err := tx.Exec(ctx, `
+		UPDATE schedules SET available_seats = available_seats - $2
+		WHERE id = $1 AND available_seats >= $2 AND deleted_at IS NULL`,
+		req.ScheduleId, req.SeatCount)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, ErrNotEnoughSeats
+	}
+
+	err = tx.QueryRow(ctx, `
 		INSERT INTO bookings (user_id, schedule_id, seat_count, status, expires_at, booking_code, created_at) 
 		VALUES ($1,$2,$3,$4,$5,$6,NOW()) RETURNING id`,
 		req.UserId, req.ScheduleId, req.SeatCount, 1, expiredAt, bookingCode).Scan(&id)
@@ -50,6 +128,20 @@ func (r *pgBookingRepo) Create(ctx context.Context, req *pb.CreateBookingRequest
 		return nil, err
 	}
 
+	seats, err := allocateSeats(ctx, tx, id, req.ScheduleId, req.SeatCount, req.Class)
+	if err != nil {
+		return nil, err
+	}
+
+	passengers, err := addPassengers(ctx, tx, id, req.UserId, req.Passengers)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
 	// Get schedule details for booking response
 	scheduleDetails, err := r.getScheduleDetails(ctx, req.ScheduleId)
 	if err != nil {
@@ -71,6 +163,8 @@ func (r *pgBookingRepo) Create(ctx context.Context, req *pb.CreateBookingRequest
 		DepartureTime: scheduleDetails.DepartureTime,
 		ArrivalTime:   scheduleDetails.ArrivalTime,
 		TrainName:     scheduleDetails.TrainName,
+		Seats:         seats,
+		Passengers:    passengers,
 	}
 	return b, nil
 }
@@ -79,7 +173,8 @@ func (r *pgBookingRepo) GetByID(ctx context.Context, id int64) (*pb.Booking, err
 	query := `
 		SELECT b.id, b.user_id, b.schedule_id, b.seat_count, b.status, b.expires_at, b.created_at, 
 		       b.booking_code, b.total_price,
-		       s.origin, s.destination, s.departure_time, s.arrival_time, t.name as train_name
+		       s.origin, s.destination, s.departure_time, s.arrival_time, t.name as train_name,
+		       s.origin_timezone, s.destination_timezone
 		FROM bookings b
 		LEFT JOIN schedules s ON b.schedule_id = s.id
 		LEFT JOIN trains t ON s.train_id = t.id
@@ -89,12 +184,14 @@ func (r *pgBookingRepo) GetByID(ctx context.Context, id int64) (*pb.Booking, err
 	var statusInt int
 	var expiredAt, createdAt time.Time
 	var totalPrice *float64
-	var origin, destination, departureTime, arrivalTime, trainName *string
+	var origin, destination, trainName, originTz, destinationTz *string
+	var departureTime, arrivalTime *time.Time
 
 	err := r.pool.QueryRow(ctx, query, id).Scan(
 		&b.Id, &b.UserId, &b.ScheduleId, &b.SeatCount, &statusInt, &expiredAt, &createdAt,
 		&b.BookingCode, &totalPrice,
-		&origin, &destination, &departureTime, &arrivalTime, &trainName)
+		&origin, &destination, &departureTime, &arrivalTime, &trainName,
+		&originTz, &destinationTz)
 	if err != nil {
 		return nil, err
 	}
@@ -112,50 +209,116 @@ func (r *pgBookingRepo) GetByID(ctx context.Context, id int64) (*pb.Booking, err
 	if destination != nil {
 		b.Destination = *destination
 	}
-	if departureTime != nil {
-		b.DepartureTime = *departureTime
-	}
-	if arrivalTime != nil {
-		b.ArrivalTime = *arrivalTime
-	}
+	b.DepartureTime = stationTime(departureTime, originTz)
+	b.ArrivalTime = stationTime(arrivalTime, destinationTz)
 	if trainName != nil {
 		b.TrainName = *trainName
 	}
 
+	if err := r.attachDetails(ctx, []*pb.Booking{&b}); err != nil {
+		return nil, err
+	}
 	return &b, nil
 }
 
-func (r *pgBookingRepo) ListByUser(ctx context.Context, userId int64, page, limit int32) ([]*pb.Booking, int32, error) {
-	offset := (int(page) - 1) * int(limit)
+func (r *pgBookingRepo) ListByUser(ctx context.Context, userId int64, filter BookingListFilter) ([]*pb.Booking, int32, string, error) {
+	args := []interface{}{userId}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	where := "b.user_id = $1 AND b.deleted_at IS NULL"
+	if filter.Status != 0 {
+		where += " AND b.status = " + arg(filter.Status)
+	}
+	if filter.DepartureFrom != "" {
+		where += " AND DATE(s.departure_time AT TIME ZONE s.origin_timezone) >= " + arg(filter.DepartureFrom) + "::date"
+	}
+	if filter.DepartureTo != "" {
+		where += " AND DATE(s.departure_time AT TIME ZONE s.origin_timezone) <= " + arg(filter.DepartureTo) + "::date"
+	}
+	if filter.Origin != "" {
+		where += " AND s.origin ILIKE " + arg(containsPattern(filter.Origin)) + ` ESCAPE '\'`
+	}
+	if filter.Destination != "" {
+		where += " AND s.destination ILIKE " + arg(containsPattern(filter.Destination)) + ` ESCAPE '\'`
+	}
+
+	// Upcoming only lists trips that have not left yet. That also keeps out
+	// bookings without a departure time, so the keyset below never has to
+	// compare against NULL.
+	orderBy := "b.id DESC"
+	if filter.Sort == SortUpcoming {
+		where += " AND s.departure_time >= NOW()"
+		orderBy = "s.departure_time ASC, b.id ASC"
+	}
+
+	// Total is only worth paying for on the first page; later pages are
+	// reached through the cursor and skip the window count entirely.
+	totalColumn := "0"
+	if filter.Cursor == nil {
+		totalColumn = "COUNT(*) OVER ()"
+	}
+
+	if c := filter.Cursor; c != nil {
+		if filter.Sort == SortUpcoming {
+			where += fmt.Sprintf(" AND (s.departure_time, b.id) > (%s, %s)", arg(c.DepartureTime), arg(c.ID))
+		} else {
+			where += " AND b.id < " + arg(c.ID)
+		}
+	}
+
 	query := `
 		SELECT b.id, b.user_id, b.schedule_id, b.seat_count, b.status, b.expires_at, b.created_at,
 		       b.booking_code, b.total_price,
-		       s.origin, s.destination, s.departure_time, s.arrival_time, t.name as train_name
+		       s.origin, s.destination, s.departure_time, s.arrival_time, t.name as train_name,
+		       s.origin_timezone, s.destination_timezone,
+		       ` + totalColumn + `
 		FROM bookings b
 		LEFT JOIN schedules s ON b.schedule_id = s.id
 		LEFT JOIN trains t ON s.train_id = t.id
-		WHERE b.user_id = $1 AND b.deleted_at IS NULL 
-		ORDER BY b.id DESC LIMIT $2 OFFSET $3`
+		WHERE ` + where + `
+		ORDER BY ` + orderBy + `
+		LIMIT ` + arg(filter.Limit+1)
+	if filter.Cursor == nil && filter.Page > 1 {
+		query += " OFFSET " + arg((filter.Page-1)*filter.Limit)
+	}
 
-	rows, err := r.pool.Query(ctx, query, userId, limit, offset)
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, "", err
 	}
 	defer rows.Close()
 
 	var res []*pb.Booking
+	var total int64
+	var lastDeparture time.Time
 	for rows.Next() {
 		var b pb.Booking
 		var statusInt int
 		var expiredAt, createdAt time.Time
 		var totalPrice *float64
-		var origin, destination, departureTime, arrivalTime, trainName *string
+		var origin, destination, trainName, originTz, destinationTz *string
+		var departureTime, arrivalTime *time.Time
 
 		err := rows.Scan(&b.Id, &b.UserId, &b.ScheduleId, &b.SeatCount, &statusInt, &expiredAt, &createdAt,
 			&b.BookingCode, &totalPrice,
-			&origin, &destination, &departureTime, &arrivalTime, &trainName)
+			&origin, &destination, &departureTime, &arrivalTime, &trainName,
+			&originTz, &destinationTz,
+			&total)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, "", err
+		}
+
+		// One extra row is fetched only to learn whether another page exists.
+		if int32(len(res)) == filter.Limit {
+			next := &BookingCursor{ID: res[len(res)-1].Id, DepartureTime: lastDeparture}
+			rows.Close()
+			if err := r.attachDetails(ctx, res); err != nil {
+				return nil, 0, "", err
+			}
+			return res, int32(total), next.Encode(), nil
 		}
 
 		b.Status = mapStatusIntToString(statusInt)
@@ -172,11 +335,10 @@ func (r *pgBookingRepo) ListByUser(ctx context.Context, userId int64, page, limi
 			b.Destination = *destination
 		}
 		if departureTime != nil {
-			b.DepartureTime = *departureTime
-		}
-		if arrivalTime != nil {
-			b.ArrivalTime = *arrivalTime
+			lastDeparture = *departureTime
 		}
+		b.DepartureTime = stationTime(departureTime, originTz)
+		b.ArrivalTime = stationTime(arrivalTime, destinationTz)
 		if trainName != nil {
 			b.TrainName = *trainName
 		}
@@ -184,50 +346,420 @@ func (r *pgBookingRepo) ListByUser(ctx context.Context, userId int64, page, limi
 		res = append(res, &b)
 	}
 
-	// count
-	var total int32
-	if err := r.pool.QueryRow(ctx, `SELECT COUNT(1) FROM bookings WHERE user_id=$1 AND deleted_at IS NULL`, userId).Scan(&total); err != nil {
-		return nil, 0, err
+	if err := rows.Err(); err != nil {
+		return nil, 0, "", err
 	}
-	return res, total, nil
+	rows.Close()
+
+	if err := r.attachDetails(ctx, res); err != nil {
+		return nil, 0, "", err
+	}
+	return res, int32(total), "", nil
 }
 
+// UpdateStatus records the payment result of a pending booking. The pending
+// status is checked under the row lock, so a booking that expired or was
+// cancelled meanwhile, and has handed its seats back, cannot become paid.
 func (r *pgBookingRepo) UpdateStatus(ctx context.Context, bookingId int64, status int) (*pb.Booking, error) {
-	_, err := r.pool.Exec(ctx, `UPDATE bookings SET status=$1, updated_at=NOW() WHERE id=$2`, status, bookingId)
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer tx.Rollback(ctx)
+
+	var scheduleId int64
+	var seatCount int32
+	var previous int
+	err = tx.QueryRow(ctx, `
+		UPDATE bookings b SET status=$1, updated_at=NOW()
+		FROM (SELECT id, status FROM bookings WHERE id=$2 AND status = 1 AND deleted_at IS NULL FOR UPDATE) old
+		WHERE b.id = old.id
+		RETURNING b.schedule_id, b.seat_count, old.status`,
+		status, bookingId).Scan(&scheduleId, &seatCount, &previous)
+	if errors.Is(err, pgx.ErrNoRows) {
+		var exists bool
+		err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM bookings WHERE id=$1 AND deleted_at IS NULL)`, bookingId).Scan(&exists)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrBookingNotFound
+		}
+		return nil, ErrBookingNotPending
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if releasesSeats(previous, status) {
+		if err := releaseSeats(ctx, tx, scheduleId, seatCount); err != nil {
+			return nil, err
+		}
+		if err := releaseBookingSeats(ctx, tx, bookingId); err != nil {
+			return nil, err
+		}
+	}
+
+	// confirm once, when the booking becomes paid
+	if status == 2 && previous != 2 {
+		if err := queueBookingConfirmation(ctx, tx, bookingId); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
 	return r.GetByID(ctx, bookingId)
 }
 
-func (r *pgBookingRepo) Cancel(ctx context.Context, bookingId, userId int64) error {
-	_, err := r.pool.Exec(ctx, `UPDATE bookings SET deleted_at=NOW(), status=$1 WHERE id=$2 AND user_id=$3`, 0, bookingId, userId)
+// likeEscaper escapes the LIKE wildcards, and the backslash that escapes
+// them, so user input only ever matches itself.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern is the ILIKE pattern matching any text that contains s.
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+// holdsSeats reports whether a booking in status still has seats taken:
+// only pending and paid bookings do.
+func holdsSeats(status int) bool {
+	return status == 1 || status == 2
+}
+
+// releasesSeats reports whether moving a booking from previous to status
+// hands its seats back. Failed and expired bookings no longer hold seats,
+// and seats already handed back must not be counted twice.
+func releasesSeats(previous, status int) bool {
+	return holdsSeats(previous) && !holdsSeats(status)
+}
+
+// Cancel soft-deletes the booking and returns the schedule whose seats it
+// released, or 0 if the booking no longer held any.
+func (r *pgBookingRepo) Cancel(ctx context.Context, bookingId, userId int64) (int64, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var scheduleId int64
+	var seatCount int32
+	var status int
+	err = tx.QueryRow(ctx, `
+		SELECT schedule_id, seat_count, status FROM bookings
+		WHERE id=$1 AND user_id=$2 AND deleted_at IS NULL
+		FOR UPDATE`, bookingId, userId).Scan(&scheduleId, &seatCount, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrBookingNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE bookings SET deleted_at=NOW(), status=$1 WHERE id=$2`, 0, bookingId)
+	if err != nil {
+		return 0, err
+	}
+
+	released := holdsSeats(status)
+	if released {
+		if err := releaseSeats(ctx, tx, scheduleId, seatCount); err != nil {
+			return 0, err
+		}
+		if err := releaseBookingSeats(ctx, tx, bookingId); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	if !released {
+		return 0, nil
+	}
+	return scheduleId, nil
+}
+
+// ExpireBookings moves overdue pending bookings to expired, hands their seats
+// back and returns the schedules that changed.
+func (r *pgBookingRepo) ExpireBookings(ctx context.Context) ([]int64, error) {
+	rows, err := r.pool.Query(ctx, `
+		WITH expired AS (
+			UPDATE bookings SET status=4, updated_at=NOW()
+			WHERE expires_at < NOW() AND status = 1
+			RETURNING id, schedule_id, seat_count
+		), freed AS (
+			DELETE FROM booking_seats WHERE booking_id IN (SELECT id FROM expired)
+		)
+		UPDATE schedules s SET available_seats = s.available_seats + e.seats
+		FROM (SELECT schedule_id, SUM(seat_count) AS seats FROM expired GROUP BY schedule_id) e
+		WHERE s.id = e.schedule_id
+		RETURNING s.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scheduleIds []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		scheduleIds = append(scheduleIds, id)
+	}
+
+	return scheduleIds, rows.Err()
+}
+
+func (r *pgBookingRepo) AvailableSeats(ctx context.Context, scheduleIds []int64) (map[int64]int32, error) {
+	rows, err := r.pool.Query(ctx, `SELECT id, available_seats FROM schedules WHERE id = ANY($1) AND deleted_at IS NULL`, scheduleIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seats := make(map[int64]int32, len(scheduleIds))
+	for rows.Next() {
+		var id int64
+		var available int32
+		if err := rows.Scan(&id, &available); err != nil {
+			return nil, err
+		}
+		seats[id] = available
+	}
+
+	return seats, rows.Err()
+}
+
+func releaseSeats(ctx context.Context, tx pgx.Tx, scheduleId int64, seatCount int32) error {
+	_, err := tx.Exec(ctx, `UPDATE schedules SET available_seats = available_seats + $2 WHERE id = $1`, scheduleId, seatCount)
 	return err
 }
 
-func (r *pgBookingRepo) ExpireBookings(ctx context.Context) error {
-	_, err := r.pool.Exec(ctx, `UPDATE bookings SET status=4 WHERE expires_at < NOW() AND status = 1`)
+// allocateSeats assigns concrete seats to a new booking when the schedule's
+// train has a coach layout, filling coaches front to back so a party sits
+// together where possible. Trains without a layout only track seat counts.
+func allocateSeats(ctx context.Context, tx pgx.Tx, bookingId, scheduleId int64, seatCount int32, class string) ([]*pb.BookedSeat, error) {
+	var hasLayout bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM schedules s JOIN coach_seats cs ON cs.train_id = s.train_id WHERE s.id = $1
+		)`, scheduleId).Scan(&hasLayout)
+	if err != nil {
+		return nil, err
+	}
+	if !hasLayout {
+		if class != "" {
+			return nil, errors.New("this train does not offer seat classes")
+		}
+		return nil, nil
+	}
+
+	// The schedule row is already locked by the seat hold, so concurrent
+	// bookings cannot pick the same free seats.
+	rows, err := tx.Query(ctx, `
+		SELECT cs.coach_number, cs.seat_number, cs.class
+		FROM schedules s
+		JOIN coach_seats cs ON cs.train_id = s.train_id
+		WHERE s.id = $1 AND ($2 = '' OR cs.class = $2)
+		  AND NOT EXISTS (
+			SELECT 1 FROM booking_seats bs
+			WHERE bs.schedule_id = s.id AND bs.coach_number = cs.coach_number AND bs.seat_number = cs.seat_number
+		  )
+		ORDER BY cs.coach_number, cs.seat_row, cs.seat_column
+		LIMIT $3`, scheduleId, class, seatCount)
+	if err != nil {
+		return nil, err
+	}
+	var seats []*pb.BookedSeat
+	for rows.Next() {
+		var seat pb.BookedSeat
+		if err := rows.Scan(&seat.CoachNumber, &seat.SeatNumber, &seat.Class); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		seats = append(seats, &seat)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if int32(len(seats)) < seatCount {
+		if class != "" {
+			return nil, fmt.Errorf("not enough %s seats available", class)
+		}
+		return nil, ErrNotEnoughSeats
+	}
+
+	for _, seat := range seats {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO booking_seats (booking_id, schedule_id, coach_number, seat_number, class, created_at)
+			VALUES ($1, $2, $3, $4, $5, NOW())`,
+			bookingId, scheduleId, seat.CoachNumber, seat.SeatNumber, seat.Class)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return seats, nil
+}
+
+func releaseBookingSeats(ctx context.Context, tx pgx.Tx, bookingId int64) error {
+	_, err := tx.Exec(ctx, `DELETE FROM booking_seats WHERE booking_id = $1`, bookingId)
 	return err
 }
 
+// attachDetails loads the seats and passengers of the given bookings.
+func (r *pgBookingRepo) attachDetails(ctx context.Context, bookings []*pb.Booking) error {
+	if err := r.attachSeats(ctx, bookings); err != nil {
+		return err
+	}
+	return r.attachPassengers(ctx, bookings)
+}
+
+// attachSeats loads the seat assignments of the given bookings in one query.
+func (r *pgBookingRepo) attachSeats(ctx context.Context, bookings []*pb.Booking) error {
+	if len(bookings) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*pb.Booking, len(bookings))
+	ids := make([]int64, 0, len(bookings))
+	for _, b := range bookings {
+		byID[b.Id] = b
+		ids = append(ids, b.Id)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT booking_id, coach_number, seat_number, class
+		FROM booking_seats WHERE booking_id = ANY($1)
+		ORDER BY booking_id, id`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookingId int64
+		var seat pb.BookedSeat
+		if err := rows.Scan(&bookingId, &seat.CoachNumber, &seat.SeatNumber, &seat.Class); err != nil {
+			return err
+		}
+		if b, ok := byID[bookingId]; ok {
+			b.Seats = append(b.Seats, &seat)
+		}
+	}
+	return rows.Err()
+}
+
+// attachPassengers loads the passengers of the given bookings in one query.
+func (r *pgBookingRepo) attachPassengers(ctx context.Context, bookings []*pb.Booking) error {
+	if len(bookings) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*pb.Booking, len(bookings))
+	ids := make([]int64, 0, len(bookings))
+	for _, b := range bookings {
+		byID[b.Id] = b
+		ids = append(ids, b.Id)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT booking_id, COALESCE(traveller_id, 0), full_name, passenger_type
+		FROM booking_passengers WHERE booking_id = ANY($1)
+		ORDER BY booking_id, id`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookingId int64
+		var p pb.Passenger
+		if err := rows.Scan(&bookingId, &p.TravellerId, &p.FullName, &p.PassengerType); err != nil {
+			return err
+		}
+		if b, ok := byID[bookingId]; ok {
+			b.Passengers = append(b.Passengers, &p)
+		}
+	}
+	return rows.Err()
+}
+
+// addPassengers records who travels on a new booking. Saved travellers are
+// read from user-service's table and must belong to the booking's user;
+// only their name and type are copied, never the ID number.
+func addPassengers(ctx context.Context, tx pgx.Tx, bookingId, userId int64, passengers []*pb.Passenger) ([]*pb.Passenger, error) {
+	for _, p := range passengers {
+		if p.TravellerId != 0 {
+			err := tx.QueryRow(ctx, `
+				INSERT INTO booking_passengers (booking_id, traveller_id, full_name, passenger_type, created_at)
+				SELECT $1, t.id, t.full_name, t.passenger_type, NOW()
+				FROM travellers t WHERE t.id = $2 AND t.user_id = $3
+				RETURNING full_name, passenger_type`,
+				bookingId, p.TravellerId, userId).Scan(&p.FullName, &p.PassengerType)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrTravellerNotFound
+			}
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		_, err := tx.Exec(ctx, `
+			INSERT INTO booking_passengers (booking_id, full_name, passenger_type, created_at)
+			VALUES ($1, $2, $3, NOW())`,
+			bookingId, p.FullName, p.PassengerType)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return passengers, nil
+}
+
 func (r *pgBookingRepo) getScheduleDetails(ctx context.Context, scheduleId int64) (*ScheduleDetails, error) {
 	query := `
-		SELECT s.price, s.origin, s.destination, s.departure_time, s.arrival_time, t.name as train_name
+		SELECT s.price, s.origin, s.destination, s.departure_time, s.arrival_time, t.name as train_name,
+		       s.origin_timezone, s.destination_timezone
 		FROM schedules s
 		LEFT JOIN trains t ON s.train_id = t.id
 		WHERE s.id = $1`
 
 	var details ScheduleDetails
+	var departureTime, arrivalTime time.Time
+	var originTz, destinationTz string
 	err := r.pool.QueryRow(ctx, query, scheduleId).Scan(
 		&details.Price, &details.Origin, &details.Destination,
-		&details.DepartureTime, &details.ArrivalTime, &details.TrainName)
+		&departureTime, &arrivalTime, &details.TrainName,
+		&originTz, &destinationTz)
 	if err != nil {
 		return nil, err
 	}
 
+	details.DepartureTime = stationTime(&departureTime, &originTz)
+	details.ArrivalTime = stationTime(&arrivalTime, &destinationTz)
+
 	return &details, nil
 }
 
+// stationTime renders a schedule instant as RFC3339 in the station's own
+// zone (WIB/WITA/WIT), falling back to UTC when the zone is unknown.
+func stationTime(t *time.Time, timezone *string) string {
+	if t == nil {
+		return ""
+	}
+	if timezone != nil {
+		if loc, err := time.LoadLocation(*timezone); err == nil {
+			return t.In(loc).Format(time.RFC3339)
+		}
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
 func generateBookingCode() string {
 	const charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	b := make([]byte, 8)
@@ -237,6 +769,22 @@ func generateBookingCode() string {
 	return fmt.Sprintf("BK%s", string(b))
 }
 
+// StatusFromString is the inverse of mapStatusIntToString.
+func StatusFromString(s string) (int, bool) {
+	switch s {
+	case "pending":
+		return 1, true
+	case "success":
+		return 2, true
+	case "failed":
+		return 3, true
+	case "expired":
+		return 4, true
+	default:
+		return 0, false
+	}
+}
+
 func mapStatusIntToString(s int) string {
 	switch s {
 	case 1:
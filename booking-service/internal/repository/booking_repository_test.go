@@ -0,0 +1,52 @@
+package repository
+
+import "testing"
+
+func TestReleasesSeats(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous int
+		status   int
+		want     bool
+	}{
+		{"pending to paid", 1, 2, false},
+		{"pending to failed", 1, 3, true},
+		{"pending to expired", 1, 4, true},
+		{"paid to failed", 2, 3, true},
+		{"paid to paid", 2, 2, false},
+		{"failed again", 3, 3, false},
+		{"expired then failed", 4, 3, false},
+		{"failed then expired", 3, 4, false},
+		{"cancelled then failed", 0, 3, false},
+		{"pending to cancelled", 1, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := releasesSeats(tt.previous, tt.status); got != tt.want {
+				t.Errorf("releasesSeats(%d, %d) = %v, want %v", tt.previous, tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainsPattern(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", "%%"},
+		{"Bandung", "%Bandung%"},
+		{"%", `%\%%`},
+		{"100%", `%100\%%`},
+		{"a_b", `%a\_b%`},
+		{`c:\x`, `%c:\\x%`},
+		{`\%`, `%\\\%%`},
+	}
+
+	for _, tt := range tests {
+		if got := containsPattern(tt.in); got != tt.want {
+			t.Errorf("containsPattern(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+
+// CalendarEntry is a booking joined with the live state of its schedule, the
+// input for one calendar event. Sequence counts seconds between booking and
+// the latest change to either side, so it only ever grows.
+type CalendarEntry struct {
+	BookingID           int64
+	UserID              int64
+	BookingCode         string
+	SeatCount           int32
+	Seats               string
+	Status              int
+	Cancelled           bool
+	Origin              string
+	Destination         string
+	OriginTimezone      string
+	DestinationTimezone string
+	DepartureTime       time.Time
+	ArrivalTime         time.Time
+	TrainName           string
+	ScheduleStatus      string
+	DelayMinutes        int32
+	Platform            string
+	UpdatedAt           time.Time
+	Sequence            int64
+}
+
+type CalendarRepository interface {
+	Entry(ctx context.Context, bookingId int64) (*CalendarEntry, error)
+	EntriesByUser(ctx context.Context, userId int64, since time.Time) ([]*CalendarEntry, error)
+	CreateFeedToken(ctx context.Context, userId int64, tokenHash string) (bool, error)
+	RotateFeedToken(ctx context.Context, userId int64, tokenHash string) error
+	UserByFeedToken(ctx context.Context, tokenHash string) (int64, error)
+}
+
+type pgCalendarRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewCalendarRepository(pool *pgxpool.Pool) CalendarRepository {
+	return &pgCalendarRepo{pool: pool}
+}
+
+const calendarEntryQuery = `
+		SELECT b.id, b.user_id, b.booking_code, b.seat_count,
+		       COALESCE((SELECT string_agg(bs.coach_number || '-' || bs.seat_number, ', ' ORDER BY bs.id)
+		                 FROM booking_seats bs WHERE bs.booking_id = b.id), ''),
+		       b.status, b.deleted_at IS NOT NULL,
+		       s.origin, s.destination, s.origin_timezone, s.destination_timezone,
+		       s.departure_time, s.arrival_time, COALESCE(t.name, ''),
+		       s.status, s.delay_minutes, COALESCE(s.platform, ''),
+		       GREATEST(b.updated_at, b.deleted_at, s.updated_at)::timestamptz,
+		       EXTRACT(EPOCH FROM GREATEST(b.updated_at, b.deleted_at, s.updated_at) - b.created_at)::bigint
+		FROM bookings b
+		JOIN schedules s ON b.schedule_id = s.id
+		LEFT JOIN trains t ON s.train_id = t.id`
+
+func scanCalendarEntry(row pgx.Row) (*CalendarEntry, error) {
+	var e CalendarEntry
+	err := row.Scan(&e.BookingID, &e.UserID, &e.BookingCode, &e.SeatCount, &e.Seats, &e.Status, &e.Cancelled,
+		&e.Origin, &e.Destination, &e.OriginTimezone, &e.DestinationTimezone,
+		&e.DepartureTime, &e.ArrivalTime, &e.TrainName,
+		&e.ScheduleStatus, &e.DelayMinutes, &e.Platform,
+		&e.UpdatedAt, &e.Sequence)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *pgCalendarRepo) Entry(ctx context.Context, bookingId int64) (*CalendarEntry, error) {
+	e, err := scanCalendarEntry(r.pool.QueryRow(ctx, calendarEntryQuery+`
+		WHERE b.id = $1 AND b.deleted_at IS NULL`, bookingId))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrBookingNotFound
+	}
+	return e, err
+}
+
+// EntriesByUser returns the paid trips of a user departing after since, plus
+// cancelled ones so subscribed calendars drop them. Cancelling does not keep
+// the previous status, so a booking cancelled before payment shows up too,
+// already marked cancelled.
+func (r *pgCalendarRepo) EntriesByUser(ctx context.Context, userId int64, since time.Time) ([]*CalendarEntry, error) {
+	rows, err := r.pool.Query(ctx, calendarEntryQuery+`
+		WHERE b.user_id = $1 AND s.departure_time >= $2
+		  AND (b.status = 2 OR (b.status = 0 AND b.deleted_at IS NOT NULL))
+		ORDER BY s.departure_time ASC, b.id ASC`, userId, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*CalendarEntry
+	for rows.Next() {
+		e, err := scanCalendarEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+// CreateFeedToken creates the user's feed and reports false, leaving the
+// feed alone, when the user already has one.
+func (r *pgCalendarRepo) CreateFeedToken(ctx context.Context, userId int64, tokenHash string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		INSERT INTO calendar_feeds (user_id, token_hash, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO NOTHING`,
+		userId, tokenHash)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// RotateFeedToken creates the user's feed or replaces its token.
+func (r *pgCalendarRepo) RotateFeedToken(ctx context.Context, userId int64, tokenHash string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO calendar_feeds (user_id, token_hash, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, rotated_at = NOW()`,
+		userId, tokenHash)
+	return err
+}
+
+func (r *pgCalendarRepo) UserByFeedToken(ctx context.Context, tokenHash string) (int64, error) {
+	var userId int64
+	err := r.pool.QueryRow(ctx, `SELECT user_id FROM calendar_feeds WHERE token_hash = $1`, tokenHash).Scan(&userId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrCalendarFeedNotFound
+	}
+	return userId, err
+}
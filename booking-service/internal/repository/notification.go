@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Emails are queued in user-service's notification_outbox, in the same
+// transaction as the booking change they are about; user-service renders
+// the template in the user's locale and delivers it.
+const (
+	emailBookingConfirmation  = "booking_confirmation"
+	emailBookingExpiryWarning = "booking_expiry_warning"
+)
+
+// expiryWarningLead is how long before a pending booking expires its owner
+// is reminded to pay.
+const expiryWarningLead = 3 * time.Minute
+
+// queueBookingEmails queues the template for every booking the condition
+// selects, with everything the templates show.
+func queueBookingEmails(template, where string) string {
+	return fmt.Sprintf(`
+		INSERT INTO notification_outbox (template, locale, recipient, data, next_attempt_at, created_at)
+		SELECT '%s', u.locale, u.email, jsonb_build_object(
+			'username', u.username,
+			'booking_code', b.booking_code,
+			'seat_count', b.seat_count,
+			'seats', (SELECT string_agg(bs.coach_number || '-' || bs.seat_number, ', ' ORDER BY bs.coach_number, bs.seat_number)
+			          FROM booking_seats bs WHERE bs.booking_id = b.id),
+			'train_name', COALESCE(t.name, ''),
+			'origin', COALESCE(s.origin, ''),
+			'destination', COALESCE(s.destination, ''),
+			'departure_time', COALESCE(to_char(s.departure_time AT TIME ZONE s.origin_timezone, 'YYYY-MM-DD HH24:MI'), ''),
+			'minutes_left', GREATEST(CEIL(EXTRACT(EPOCH FROM b.expires_at - NOW()) / 60), 0)
+		), NOW(), NOW()
+		FROM bookings b
+		JOIN users u ON u.id = b.user_id AND u.deleted_at IS NULL
+		LEFT JOIN schedules s ON s.id = b.schedule_id
+		LEFT JOIN trains t ON t.id = s.train_id
+		WHERE %s`, template, where)
+}
+
+func queueBookingConfirmation(ctx context.Context, tx pgx.Tx, bookingId int64) error {
+	_, err := tx.Exec(ctx, queueBookingEmails(emailBookingConfirmation, "b.id = $1"), bookingId)
+	return err
+}
+
+// WarnExpiringBookings queues a reminder for every pending booking about to
+// expire. Each booking is warned at most once.
+func (r *pgBookingRepo) WarnExpiringBookings(ctx context.Context) (int64, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		UPDATE bookings SET expiry_warned_at = NOW()
+		WHERE status = 1 AND deleted_at IS NULL AND expiry_warned_at IS NULL
+		  AND expires_at > NOW() AND expires_at <= NOW() + make_interval(secs => $1)
+		RETURNING id`, expiryWarningLead.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	tag, err := tx.Exec(ctx, queueBookingEmails(emailBookingExpiryWarning, "b.id = ANY($1)"), ids)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), tx.Commit(ctx)
+}
@@ -0,0 +1,365 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"ticket-booking/booking-service/internal/document"
+	"ticket-booking/booking-service/internal/helper"
+	"ticket-booking/booking-service/internal/repository"
+	pb "ticket-booking/proto/booking"
+)
+
+var paymentStatuses = map[string]int{
+	"success": 2,
+	"failed":  3,
+	"expired": 4,
+}
+
+type BookingService interface {
+	CreateBooking(ctx context.Context, req *pb.CreateBookingRequest) (*pb.Booking, error)
+	GetBooking(ctx context.Context, id, userId int64, staff bool) (*pb.Booking, error)
+	ListUserBookings(ctx context.Context, req *pb.ListUserBookingsRequest) ([]*pb.Booking, int32, string, error)
+	CancelBooking(ctx context.Context, bookingId, userId int64) error
+	UpdatePaymentStatus(ctx context.Context, bookingId int64, status string) (*pb.Booking, error)
+	CheckIn(ctx context.Context, req *pb.CheckInRequest, checkedInBy int64) (*pb.Booking, time.Time, error)
+	GetBoardingManifest(ctx context.Context, scheduleId int64) ([]*pb.BoardingManifestEntry, error)
+	GetBookingDocument(ctx context.Context, bookingId, userId int64) ([]byte, error)
+	ExpireBookings(ctx context.Context) error
+	WarnExpiringBookings(ctx context.Context) error
+	WatchSeatAvailability(ctx context.Context, scheduleIds []int64, send func(*pb.SeatAvailabilityUpdate) error) error
+}
+
+// maxWatchedSchedules bounds how many schedules one availability stream may follow.
+const maxWatchedSchedules = 50
+
+type bookingService struct {
+	bookingRepo  repository.BookingRepository
+	boardingRepo repository.BoardingRepository
+	ticketSigner *helper.TicketSigner
+	seatHub      *seatHub
+}
+
+func NewBookingService(bookingRepo repository.BookingRepository, boardingRepo repository.BoardingRepository, ticketSigner *helper.TicketSigner) BookingService {
+	return &bookingService{
+		bookingRepo:  bookingRepo,
+		boardingRepo: boardingRepo,
+		ticketSigner: ticketSigner,
+		seatHub:      newSeatHub(),
+	}
+}
+
+func (s *bookingService) CreateBooking(ctx context.Context, req *pb.CreateBookingRequest) (*pb.Booking, error) {
+	if req.SeatCount <= 0 {
+		return nil, errors.New("seat count must be greater than zero")
+	}
+	req.Class = strings.ToLower(strings.TrimSpace(req.Class))
+	if err := checkPassengers(req); err != nil {
+		return nil, err
+	}
+
+	booking, err := s.bookingRepo.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishSeats(ctx, "held", booking.ScheduleId)
+	return booking, nil
+}
+
+// checkPassengers validates the passengers typed in by hand; saved
+// travellers are checked against the user when the booking is stored.
+func checkPassengers(req *pb.CreateBookingRequest) error {
+	if len(req.Passengers) == 0 {
+		return nil
+	}
+	if len(req.Passengers) != int(req.SeatCount) {
+		return errors.New("give one passenger per seat")
+	}
+
+	for _, p := range req.Passengers {
+		if p == nil {
+			return errors.New("passenger is empty")
+		}
+		if p.TravellerId != 0 {
+			continue
+		}
+
+		fullName, passengerType, err := pb.CleanPassenger(p.FullName, p.PassengerType)
+		if err != nil {
+			return err
+		}
+		p.FullName, p.PassengerType = fullName, passengerType
+	}
+	return nil
+}
+
+// GetBooking returns the booking to its owner, with the signed ticket once
+// paid. Staff may look up any booking but never get its ticket.
+func (s *bookingService) GetBooking(ctx context.Context, id, userId int64, staff bool) (*pb.Booking, error) {
+	booking, err := s.bookingRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if booking.UserId != userId {
+		if !staff {
+			return nil, errors.New("booking does not belong to user")
+		}
+		return booking, nil
+	}
+
+	if err := s.attachTicket(booking); err != nil {
+		return nil, err
+	}
+
+	return booking, nil
+}
+
+func (s *bookingService) ListUserBookings(ctx context.Context, req *pb.ListUserBookingsRequest) ([]*pb.Booking, int32, string, error) {
+	filter := repository.BookingListFilter{
+		DepartureFrom: req.DepartureFrom,
+		DepartureTo:   req.DepartureTo,
+		Origin:        req.Origin,
+		Destination:   req.Destination,
+		Sort:          req.Sort,
+		Page:          req.Page,
+		Limit:         req.Limit,
+	}
+
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 10
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	switch filter.Sort {
+	case "":
+		filter.Sort = repository.SortRecent
+	case repository.SortRecent, repository.SortUpcoming:
+	default:
+		return nil, 0, "", errors.New("invalid sort, expected recent or upcoming")
+	}
+
+	if req.Status != "" {
+		status, ok := repository.StatusFromString(req.Status)
+		if !ok {
+			return nil, 0, "", errors.New("invalid status filter")
+		}
+		filter.Status = status
+	}
+
+	for _, date := range []string{req.DepartureFrom, req.DepartureTo} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, 0, "", errors.New("departure dates must be in YYYY-MM-DD format")
+		}
+	}
+
+	if req.Cursor != "" {
+		cursor, err := repository.DecodeBookingCursor(req.Cursor)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		filter.Cursor = cursor
+	}
+
+	return s.bookingRepo.ListByUser(ctx, req.UserId, filter)
+}
+
+func (s *bookingService) CancelBooking(ctx context.Context, bookingId, userId int64) error {
+	scheduleId, err := s.bookingRepo.Cancel(ctx, bookingId, userId)
+	if err != nil {
+		return err
+	}
+
+	if scheduleId != 0 {
+		s.publishSeats(ctx, "cancelled", scheduleId)
+	}
+	return nil
+}
+
+// UpdatePaymentStatus records the payment result for a pending booking. It
+// is for staff, so it does not check who owns the booking.
+func (s *bookingService) UpdatePaymentStatus(ctx context.Context, bookingId int64, status string) (*pb.Booking, error) {
+	statusInt, ok := paymentStatuses[status]
+	if !ok {
+		return nil, errors.New("invalid payment status")
+	}
+
+	booking, err := s.bookingRepo.UpdateStatus(ctx, bookingId, statusInt)
+	if err != nil {
+		return nil, err
+	}
+
+	reason := status
+	if status == "success" {
+		reason = "paid"
+	}
+	s.publishSeats(ctx, reason, booking.ScheduleId)
+
+	if err := s.attachTicket(booking); err != nil {
+		return nil, err
+	}
+
+	return booking, nil
+}
+
+// CheckIn records boarding for a scanned ticket. checkedInBy is the staff
+// member scanning it.
+func (s *bookingService) CheckIn(ctx context.Context, req *pb.CheckInRequest, checkedInBy int64) (*pb.Booking, time.Time, error) {
+	claims, err := s.ticketSigner.Verify(req.TicketPayload)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	booking, err := s.bookingRepo.GetByID(ctx, claims.BookingID)
+	if err != nil {
+		return nil, time.Time{}, errors.New("booking not found")
+	}
+	if booking.BookingCode != claims.BookingCode || booking.ScheduleId != claims.ScheduleID {
+		return nil, time.Time{}, errors.New("ticket does not match booking")
+	}
+	if booking.Status != "success" {
+		return nil, time.Time{}, errors.New("ticket is not paid")
+	}
+
+	boardedAt, err := s.boardingRepo.Create(ctx, booking.Id, booking.ScheduleId, req.Gate, checkedInBy)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return booking, boardedAt, nil
+}
+
+func (s *bookingService) GetBoardingManifest(ctx context.Context, scheduleId int64) ([]*pb.BoardingManifestEntry, error) {
+	return s.boardingRepo.GetManifest(ctx, scheduleId)
+}
+
+func (s *bookingService) GetBookingDocument(ctx context.Context, bookingId, userId int64) ([]byte, error) {
+	booking, err := s.bookingRepo.GetByID(ctx, bookingId)
+	if err != nil {
+		return nil, errors.New("booking not found")
+	}
+	if booking.UserId != userId {
+		return nil, errors.New("booking does not belong to user")
+	}
+	if booking.Status != "success" {
+		return nil, errors.New("documents are only available for paid bookings")
+	}
+
+	if err := s.attachTicket(booking); err != nil {
+		return nil, err
+	}
+
+	return document.RenderItinerary(booking)
+}
+
+func (s *bookingService) ExpireBookings(ctx context.Context) error {
+	scheduleIds, err := s.bookingRepo.ExpireBookings(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.publishSeats(ctx, "expired", scheduleIds...)
+	return nil
+}
+
+// WarnExpiringBookings emails owners of unpaid bookings shortly before the
+// seats are released.
+func (s *bookingService) WarnExpiringBookings(ctx context.Context) error {
+	_, err := s.bookingRepo.WarnExpiringBookings(ctx)
+	return err
+}
+
+// WatchSeatAvailability sends the current seat count of every requested
+// schedule, then each change until the caller goes away.
+func (s *bookingService) WatchSeatAvailability(ctx context.Context, scheduleIds []int64, send func(*pb.SeatAvailabilityUpdate) error) error {
+	if len(scheduleIds) == 0 {
+		return errors.New("at least one schedule id is required")
+	}
+	if len(scheduleIds) > maxWatchedSchedules {
+		return fmt.Errorf("at most %d schedules can be watched at once", maxWatchedSchedules)
+	}
+
+	// Subscribe before reading the snapshot so no change falls in between.
+	watcher, unsubscribe := s.seatHub.subscribe(scheduleIds)
+	defer unsubscribe()
+
+	seats, err := s.bookingRepo.AvailableSeats(ctx, scheduleIds)
+	if err != nil {
+		return err
+	}
+	now := time.Now().Format(time.RFC3339)
+	for id, available := range seats {
+		if err := send(&pb.SeatAvailabilityUpdate{ScheduleId: id, AvailableSeats: available, Reason: "snapshot", UpdatedAt: now}); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-watcher.notify:
+			for _, update := range watcher.drain() {
+				if err := send(update); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// publishSeats reads the current seat counts after a booking change and pushes
+// them to watchers. Failures only cost watchers an update, never the booking.
+func (s *bookingService) publishSeats(ctx context.Context, reason string, scheduleIds ...int64) {
+	if len(scheduleIds) == 0 {
+		return
+	}
+
+	seats, err := s.bookingRepo.AvailableSeats(ctx, scheduleIds)
+	if err != nil {
+		log.Printf("read seat availability: %v", err)
+		return
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	for id, available := range seats {
+		s.seatHub.publish(&pb.SeatAvailabilityUpdate{
+			ScheduleId:     id,
+			AvailableSeats: available,
+			Reason:         reason,
+			UpdatedAt:      now,
+		})
+	}
+}
+
+// attachTicket signs the scannable ticket payload for paid bookings only, so an
+// unpaid or expired booking never carries something a gate would accept.
+func (s *bookingService) attachTicket(booking *pb.Booking) error {
+	if booking.Status != "success" {
+		return nil
+	}
+
+	payload, err := s.ticketSigner.Sign(&helper.TicketClaims{
+		BookingID:   booking.Id,
+		BookingCode: booking.BookingCode,
+		ScheduleID:  booking.ScheduleId,
+	})
+	if err != nil {
+		return err
+	}
+
+	booking.TicketPayload = payload
+	return nil
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ticket-booking/booking-service/internal/document"
+	"ticket-booking/booking-service/internal/repository"
+)
+
+// calendarHistory is how far back a feed still lists departed trips.
+const calendarHistory = 90 * 24 * time.Hour
+
+var ErrCalendarNotPaid = errors.New("calendar events are only available for paid bookings")
+
+type CalendarService interface {
+	GetBookingCalendar(ctx context.Context, bookingId, userId int64) ([]byte, error)
+	GetCalendarFeedToken(ctx context.Context, userId int64, rotate bool) (string, error)
+	GetCalendarFeed(ctx context.Context, token string) ([]byte, error)
+}
+
+type calendarService struct {
+	calendarRepo repository.CalendarRepository
+}
+
+func NewCalendarService(calendarRepo repository.CalendarRepository) CalendarService {
+	return &calendarService{calendarRepo: calendarRepo}
+}
+
+func (s *calendarService) GetBookingCalendar(ctx context.Context, bookingId, userId int64) ([]byte, error) {
+	entry, err := s.calendarRepo.Entry(ctx, bookingId)
+	if err != nil {
+		return nil, err
+	}
+	// Another user's booking is reported as missing so its id reveals nothing
+	if entry.UserID != userId {
+		return nil, repository.ErrBookingNotFound
+	}
+	if entry.Status != 2 {
+		return nil, ErrCalendarNotPaid
+	}
+
+	return document.RenderCalendar("", []document.CalendarEvent{calendarEvent(entry)}), nil
+}
+
+// GetCalendarFeedToken returns the secret that authorises the user's feed
+// URL. Only its hash is stored, so the token is shown once: when the feed is
+// first created, and on every rotation, which revokes the URLs handed out
+// before. Asking again without rotating returns an empty token.
+func (s *calendarService) GetCalendarFeedToken(ctx context.Context, userId int64, rotate bool) (string, error) {
+	if userId <= 0 {
+		return "", errors.New("user id is required")
+	}
+
+	token, err := newFeedToken()
+	if err != nil {
+		return "", err
+	}
+
+	if rotate {
+		if err := s.calendarRepo.RotateFeedToken(ctx, userId, hashFeedToken(token)); err != nil {
+			return "", err
+		}
+		return token, nil
+	}
+
+	created, err := s.calendarRepo.CreateFeedToken(ctx, userId, hashFeedToken(token))
+	if err != nil || !created {
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *calendarService) GetCalendarFeed(ctx context.Context, token string) ([]byte, error) {
+	if token == "" {
+		return nil, repository.ErrCalendarFeedNotFound
+	}
+
+	userId, err := s.calendarRepo.UserByFeedToken(ctx, hashFeedToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.calendarRepo.EntriesByUser(ctx, userId, time.Now().Add(-calendarHistory))
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]document.CalendarEvent, 0, len(entries))
+	for _, e := range entries {
+		events = append(events, calendarEvent(e))
+	}
+	return document.RenderCalendar("Train trips", events), nil
+}
+
+// calendarEvent shifts the trip by the announced delay and cancels the event
+// when either the booking or the train itself is cancelled.
+func calendarEvent(e *repository.CalendarEntry) document.CalendarEvent {
+	delay := time.Duration(e.DelayMinutes) * time.Minute
+	departure := e.DepartureTime.Add(delay)
+	arrival := e.ArrivalTime.Add(delay)
+
+	var desc strings.Builder
+	fmt.Fprintf(&desc, "Booking code: %s\n", e.BookingCode)
+	fmt.Fprintf(&desc, "Train: %s\n", e.TrainName)
+	fmt.Fprintf(&desc, "Route: %s - %s\n", e.Origin, e.Destination)
+	if e.Seats != "" {
+		fmt.Fprintf(&desc, "Seats: %s\n", e.Seats)
+	} else {
+		fmt.Fprintf(&desc, "Seats: %d\n", e.SeatCount)
+	}
+	fmt.Fprintf(&desc, "Departure: %s (%s)\n", localTime(departure, e.OriginTimezone), e.Origin)
+	fmt.Fprintf(&desc, "Arrival: %s (%s)", localTime(arrival, e.DestinationTimezone), e.Destination)
+	if e.Platform != "" {
+		fmt.Fprintf(&desc, "\nPlatform: %s", e.Platform)
+	}
+	if e.DelayMinutes > 0 {
+		fmt.Fprintf(&desc, "\nDelayed by %d minutes", e.DelayMinutes)
+	}
+
+	cancelled := e.Cancelled || e.ScheduleStatus == "cancelled"
+	if cancelled {
+		desc.WriteString("\nThis trip has been cancelled.")
+	}
+
+	location := e.Origin
+	if e.Platform != "" {
+		location += ", platform " + e.Platform
+	}
+
+	return document.CalendarEvent{
+		UID:         fmt.Sprintf("booking-%d@ticket-booking", e.BookingID),
+		Summary:     fmt.Sprintf("%s: %s → %s", e.TrainName, e.Origin, e.Destination),
+		Location:    location,
+		Description: desc.String(),
+		Start:       departure,
+		End:         arrival,
+		Cancelled:   cancelled,
+		Sequence:    int(e.Sequence),
+		Updated:     e.UpdatedAt,
+	}
+}
+
+func localTime(t time.Time, timezone string) string {
+	if loc, err := time.LoadLocation(timezone); err == nil {
+		t = t.In(loc)
+	}
+	return t.Format("Mon 02 Jan 2006 15:04 MST")
+}
+
+func newFeedToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
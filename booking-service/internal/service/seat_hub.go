@@ -0,0 +1,87 @@
+package service
+
+import (
+	"sync"
+
+	pb "ticket-booking/proto/booking"
+)
+
+// seatWatcher buffers the latest update per schedule for one stream. A slow
+// reader never blocks publishers: newer counts overwrite older ones, so the
+// reader always catches up on the current availability rather than a backlog.
+type seatWatcher struct {
+	mu      sync.Mutex
+	pending map[int64]*pb.SeatAvailabilityUpdate
+	notify  chan struct{}
+}
+
+func (w *seatWatcher) push(update *pb.SeatAvailabilityUpdate) {
+	w.mu.Lock()
+	w.pending[update.ScheduleId] = update
+	w.mu.Unlock()
+
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (w *seatWatcher) drain() []*pb.SeatAvailabilityUpdate {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	updates := make([]*pb.SeatAvailabilityUpdate, 0, len(w.pending))
+	for id, update := range w.pending {
+		updates = append(updates, update)
+		delete(w.pending, id)
+	}
+	return updates
+}
+
+// seatHub fans seat availability changes out to the WatchSeatAvailability
+// streams open on this instance.
+type seatHub struct {
+	mu       sync.Mutex
+	watchers map[int64]map[*seatWatcher]struct{}
+}
+
+func newSeatHub() *seatHub {
+	return &seatHub{watchers: make(map[int64]map[*seatWatcher]struct{})}
+}
+
+func (h *seatHub) subscribe(scheduleIds []int64) (*seatWatcher, func()) {
+	w := &seatWatcher{
+		pending: make(map[int64]*pb.SeatAvailabilityUpdate),
+		notify:  make(chan struct{}, 1),
+	}
+
+	h.mu.Lock()
+	for _, id := range scheduleIds {
+		if h.watchers[id] == nil {
+			h.watchers[id] = make(map[*seatWatcher]struct{})
+		}
+		h.watchers[id][w] = struct{}{}
+	}
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		for _, id := range scheduleIds {
+			delete(h.watchers[id], w)
+			if len(h.watchers[id]) == 0 {
+				delete(h.watchers, id)
+			}
+		}
+	}
+	return w, unsubscribe
+}
+
+func (h *seatHub) publish(update *pb.SeatAvailabilityUpdate) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for w := range h.watchers[update.ScheduleId] {
+		w.push(update)
+	}
+}
@@ -2,12 +2,21 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc"
+
 	"ticket-booking/booking-service/config"
+	"ticket-booking/booking-service/internal/handler"
+	"ticket-booking/booking-service/internal/helper"
+	"ticket-booking/booking-service/internal/repository"
+	"ticket-booking/booking-service/internal/service"
+	pb "ticket-booking/proto/booking"
 )
 
 func main() {
@@ -24,10 +33,54 @@ func main() {
 
 	log.Println("db connected")
 
-	mux := http.NewServeMux()
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK); _, _ = w.Write([]byte("ok")) })
-	log.Printf("%s listening on %s", cfg.ServiceName, cfg.Addr())
-	log.Fatal(http.ListenAndServe(cfg.Addr(), mux))
+	// Initialize repositories
+	bookingRepo := repository.NewBookingRepository(pool)
+	boardingRepo := repository.NewBoardingRepository(pool)
+	calendarRepo := repository.NewCalendarRepository(pool)
+
+	// Initialize services
+	bookingService := service.NewBookingService(bookingRepo, boardingRepo, helper.NewTicketSigner(cfg.TicketSecret))
+	calendarService := service.NewCalendarService(calendarRepo)
+
+	// Expire unpaid bookings so their held seats return to sale, warning
+	// their owners shortly before
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := bookingService.WarnExpiringBookings(context.Background()); err != nil {
+				log.Printf("warn expiring bookings: %v", err)
+			}
+			if err := bookingService.ExpireBookings(context.Background()); err != nil {
+				log.Printf("expire bookings: %v", err)
+			}
+		}
+	}()
+
+	// Start HTTP server for health check
+	go func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK); _, _ = w.Write([]byte("ok")) })
+		log.Printf("HTTP server listening on %s", cfg.Addr())
+		log.Fatal(http.ListenAndServe(cfg.Addr(), mux))
+	}()
+
+	// Start gRPC server
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPCPort))
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(handler.Permissions.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(handler.Permissions.StreamServerInterceptor()),
+	)
+	pb.RegisterBookingServiceServer(grpcServer, handler.NewGrpcServer(bookingService, calendarService))
+
+	log.Printf("%s gRPC server listening on port %d", cfg.ServiceName, cfg.GRPCPort)
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
 }
 
 func newDBPool(cfg *config.Config) (*pgxpool.Pool, error) {
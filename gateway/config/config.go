@@ -1,22 +1,30 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Port         string
-	UserHost     string
-	UserPort     int
-	TrainHost    string
-	TrainPort    int
-	ScheduleHost string
-	SchedulePort int
-	BookHost     string
-	BookPort     int
+	Port             string
+	UserHost         string
+	UserPort         int
+	UserGRPCPort     int
+	TrainHost        string
+	TrainPort        int
+	TrainGRPCPort    int
+	ScheduleHost     string
+	SchedulePort     int
+	ScheduleGRPCPort int
+	BookHost         string
+	BookPort         int
+	BookGRPCPort     int
+	PublicURL        string
+	JWKSURL          string
 }
 
 func LoadEnv() (*Config, error) {
@@ -38,16 +46,26 @@ func LoadEnv() (*Config, error) {
 		return def
 	}
 
+	port := getReqDefault("GATEWAY_PORT", "8080")
+	userHost := getReqDefault("USER_HOST", "localhost")
+	userPort := getPortDefault("USER_PORT", 8081)
+
 	return &Config{
-		Port:         getReqDefault("GATEWAY_PORT", "8080"),
-		UserHost:     getReqDefault("USER_HOST", "localhost"),
-		UserPort:     getPortDefault("USER_PORT", 8081),
-		TrainHost:    getReqDefault("TRAIN_HOST", "localhost"),
-		TrainPort:    getPortDefault("TRAIN_PORT", 8082),
-		ScheduleHost: getReqDefault("SCHEDULE_HOST", "localhost"),
-		SchedulePort: getPortDefault("SCHEDULE_PORT", 8083),
-		BookHost:     getReqDefault("BOOKING_HOST", "localhost"),
-		BookPort:     getPortDefault("BOOKING_PORT", 8084),
+		Port:             port,
+		UserHost:         userHost,
+		UserPort:         userPort,
+		UserGRPCPort:     getPortDefault("USER_GRPC_PORT", 50051),
+		TrainHost:        getReqDefault("TRAIN_HOST", "localhost"),
+		TrainPort:        getPortDefault("TRAIN_PORT", 8082),
+		TrainGRPCPort:    getPortDefault("TRAIN_GRPC_PORT", 50052),
+		ScheduleHost:     getReqDefault("SCHEDULE_HOST", "localhost"),
+		SchedulePort:     getPortDefault("SCHEDULE_PORT", 8083),
+		ScheduleGRPCPort: getPortDefault("SCHEDULE_GRPC_PORT", 50053),
+		BookHost:         getReqDefault("BOOKING_HOST", "localhost"),
+		BookPort:         getPortDefault("BOOKING_PORT", 8084),
+		BookGRPCPort:     getPortDefault("BOOKING_GRPC_PORT", 50054),
+		PublicURL:        strings.TrimSuffix(getReqDefault("GATEWAY_PUBLIC_URL", "http://localhost:"+port), "/"),
+		JWKSURL:          getReqDefault("JWKS_URL", fmt.Sprintf("http://%s:%d/.well-known/jwks.json", userHost, userPort)),
 	}, nil
 }
 
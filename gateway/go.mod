@@ -7,6 +7,7 @@ replace ticket-booking/proto => ../proto
 require (
 	github.com/gin-gonic/gin v1.11.0
 	github.com/gorilla/mux v1.8.1
+	github.com/gorilla/websocket v1.5.3
 	github.com/joho/godotenv v1.5.1
 	google.golang.org/grpc v1.76.0
 	ticket-booking/proto v0.0.0-00010101000000-000000000000
@@ -27,11 +27,13 @@ func NewBookingClient(host string, port int) (*BookingClient, error) {
 	return &BookingClient{client: client}, nil
 }
 
-func (c *BookingClient) CreateBooking(ctx context.Context, userID, scheduleID int64, seatCount int32) (*pb.CreateBookingResponse, error) {
+func (c *BookingClient) CreateBooking(ctx context.Context, userID, scheduleID int64, seatCount int32, class string, passengers []*pb.Passenger) (*pb.CreateBookingResponse, error) {
 	return c.client.CreateBooking(ctx, &pb.CreateBookingRequest{
 		UserId:     userID,
 		ScheduleId: scheduleID,
 		SeatCount:  seatCount,
+		Class:      class,
+		Passengers: passengers,
 	})
 }
 
@@ -41,12 +43,8 @@ func (c *BookingClient) GetBooking(ctx context.Context, bookingID int64) (*pb.Ge
 	})
 }
 
-func (c *BookingClient) ListUserBookings(ctx context.Context, userID int64, page, limit int32) (*pb.ListUserBookingsResponse, error) {
-	return c.client.ListUserBookings(ctx, &pb.ListUserBookingsRequest{
-		UserId: userID,
-		Page:   page,
-		Limit:  limit,
-	})
+func (c *BookingClient) ListUserBookings(ctx context.Context, req *pb.ListUserBookingsRequest) (*pb.ListUserBookingsResponse, error) {
+	return c.client.ListUserBookings(ctx, req)
 }
 
 func (c *BookingClient) CancelBooking(ctx context.Context, bookingID, userID int64) (*pb.CancelBookingResponse, error) {
@@ -56,10 +54,41 @@ func (c *BookingClient) CancelBooking(ctx context.Context, bookingID, userID int
 	})
 }
 
-func (c *BookingClient) UpdatePaymentStatus(ctx context.Context, bookingId, userId int64, status string) (*pb.UpdatePaymentStatusResponse, error) {
+func (c *BookingClient) UpdatePaymentStatus(ctx context.Context, bookingId int64, status string) (*pb.UpdatePaymentStatusResponse, error) {
 	return c.client.UpdatePaymentStatus(ctx, &pb.UpdatePaymentStatusRequest{
 		BookingId: bookingId,
-		UserId:    userId,
 		Status:    status,
 	})
 }
+
+func (c *BookingClient) GetBookingDocument(ctx context.Context, bookingID, userID int64) (*pb.GetBookingDocumentResponse, error) {
+	return c.client.GetBookingDocument(ctx, &pb.GetBookingDocumentRequest{
+		BookingId: bookingID,
+		UserId:    userID,
+	})
+}
+
+func (c *BookingClient) GetBookingCalendar(ctx context.Context, bookingID, userID int64) (*pb.GetBookingCalendarResponse, error) {
+	return c.client.GetBookingCalendar(ctx, &pb.GetBookingCalendarRequest{
+		BookingId: bookingID,
+		UserId:    userID,
+	})
+}
+
+func (c *BookingClient) GetCalendarFeedToken(ctx context.Context, rotate bool) (*pb.GetCalendarFeedTokenResponse, error) {
+	return c.client.GetCalendarFeedToken(ctx, &pb.GetCalendarFeedTokenRequest{
+		Rotate: rotate,
+	})
+}
+
+func (c *BookingClient) GetCalendarFeed(ctx context.Context, token string) (*pb.GetCalendarFeedResponse, error) {
+	return c.client.GetCalendarFeed(ctx, &pb.GetCalendarFeedRequest{
+		Token: token,
+	})
+}
+
+func (c *BookingClient) WatchSeatAvailability(ctx context.Context, scheduleIDs []int64) (pb.BookingService_WatchSeatAvailabilityClient, error) {
+	return c.client.WatchSeatAvailability(ctx, &pb.WatchSeatAvailabilityRequest{
+		ScheduleIds: scheduleIDs,
+	})
+}
@@ -10,6 +10,9 @@ import (
 	pb "ticket-booking/proto/schedule"
 )
 
+// maxTimetableMessage matches the limit schedule-service accepts for GTFS archives.
+const maxTimetableMessage = 32 << 20
+
 type ScheduleClient struct {
 	client pb.ScheduleServiceClient
 }
@@ -33,23 +36,43 @@ func (c *ScheduleClient) GetSchedule(ctx context.Context, scheduleID int64) (*pb
 	})
 }
 
-func (c *ScheduleClient) ListSchedules(ctx context.Context, origin, destination, departureDate string, page, limit int32) (*pb.ListSchedulesResponse, error) {
-	return c.client.ListSchedules(ctx, &pb.ListSchedulesRequest{
-		Origin:        origin,
-		Destination:   destination,
-		DepartureDate: departureDate,
-		Page:          page,
-		Limit:         limit,
-	})
+func (c *ScheduleClient) ListSchedules(ctx context.Context, req *pb.ListSchedulesRequest) (*pb.ListSchedulesResponse, error) {
+	return c.client.ListSchedules(ctx, req)
 }
 
-func (c *ScheduleClient) CreateSchedule(ctx context.Context, trainID int64, origin, destination, departureTime, arrivalTime string, price float64) (*pb.CreateScheduleResponse, error) {
-	return c.client.CreateSchedule(ctx, &pb.CreateScheduleRequest{
-		TrainId:       trainID,
-		Origin:        origin,
-		Destination:   destination,
-		DepartureTime: departureTime,
-		ArrivalTime:   arrivalTime,
-		Price:         price,
+func (c *ScheduleClient) CreateSchedule(ctx context.Context, req *pb.CreateScheduleRequest) (*pb.CreateScheduleResponse, error) {
+	return c.client.CreateSchedule(ctx, req)
+}
+
+func (c *ScheduleClient) UpdateSchedule(ctx context.Context, req *pb.UpdateScheduleRequest) (*pb.UpdateScheduleResponse, error) {
+	return c.client.UpdateSchedule(ctx, req)
+}
+
+func (c *ScheduleClient) ReassignTrain(ctx context.Context, req *pb.ReassignTrainRequest) (*pb.ReassignTrainResponse, error) {
+	return c.client.ReassignTrain(ctx, req)
+}
+
+func (c *ScheduleClient) SearchCalendar(ctx context.Context, req *pb.SearchCalendarRequest) (*pb.SearchCalendarResponse, error) {
+	return c.client.SearchCalendar(ctx, req)
+}
+
+func (c *ScheduleClient) UpdateScheduleStatus(ctx context.Context, req *pb.UpdateScheduleStatusRequest) (*pb.UpdateScheduleStatusResponse, error) {
+	return c.client.UpdateScheduleStatus(ctx, req)
+}
+
+func (c *ScheduleClient) WatchSchedule(ctx context.Context, scheduleID int64) (pb.ScheduleService_WatchScheduleClient, error) {
+	return c.client.WatchSchedule(ctx, &pb.WatchScheduleRequest{
+		ScheduleId: scheduleID,
 	})
 }
+
+func (c *ScheduleClient) ImportGTFS(ctx context.Context, req *pb.ImportGTFSRequest) (*pb.ImportGTFSResponse, error) {
+	return c.client.ImportGTFS(ctx, req, grpc.MaxCallSendMsgSize(maxTimetableMessage))
+}
+
+func (c *ScheduleClient) ExportGTFS(ctx context.Context, fromDate, toDate string) (*pb.ExportGTFSResponse, error) {
+	return c.client.ExportGTFS(ctx, &pb.ExportGTFSRequest{
+		FromDate: fromDate,
+		ToDate:   toDate,
+	}, grpc.MaxCallRecvMsgSize(maxTimetableMessage))
+}
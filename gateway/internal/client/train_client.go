@@ -33,11 +33,8 @@ func (c *TrainClient) GetTrain(ctx context.Context, trainID int64) (*pb.GetTrain
 	})
 }
 
-func (c *TrainClient) ListTrains(ctx context.Context, page, limit int32) (*pb.ListTrainsResponse, error) {
-	return c.client.ListTrains(ctx, &pb.ListTrainsRequest{
-		Page:  page,
-		Limit: limit,
-	})
+func (c *TrainClient) ListTrains(ctx context.Context, req *pb.ListTrainsRequest) (*pb.ListTrainsResponse, error) {
+	return c.client.ListTrains(ctx, req)
 }
 
 func (c *TrainClient) CreateTrain(ctx context.Context, name, trainType string, capacity int32) (*pb.CreateTrainResponse, error) {
@@ -63,3 +60,14 @@ func (c *TrainClient) DeleteTrain(ctx context.Context, trainID int64) (*pb.Delet
 		TrainId: trainID,
 	})
 }
+
+func (c *TrainClient) ListAmenities(ctx context.Context) (*pb.ListAmenitiesResponse, error) {
+	return c.client.ListAmenities(ctx, &pb.ListAmenitiesRequest{})
+}
+
+func (c *TrainClient) SetTrainAmenities(ctx context.Context, trainID int64, amenities []string) (*pb.SetTrainAmenitiesResponse, error) {
+	return c.client.SetTrainAmenities(ctx, &pb.SetTrainAmenitiesRequest{
+		TrainId:   trainID,
+		Amenities: amenities,
+	})
+}
@@ -61,3 +61,92 @@ func (c *UserClient) ResetPassword(ctx context.Context, token, newPassword strin
 		NewPassword: newPassword,
 	})
 }
+
+func (c *UserClient) GrantRole(ctx context.Context, userID int64, role string) (*pb.GrantRoleResponse, error) {
+	return c.client.GrantRole(ctx, &pb.GrantRoleRequest{
+		UserId: userID,
+		Role:   role,
+	})
+}
+
+func (c *UserClient) RevokeRole(ctx context.Context, userID int64, role string) (*pb.RevokeRoleResponse, error) {
+	return c.client.RevokeRole(ctx, &pb.RevokeRoleRequest{
+		UserId: userID,
+		Role:   role,
+	})
+}
+
+func (c *UserClient) UnlockUser(ctx context.Context, userID int64) (*pb.UnlockUserResponse, error) {
+	return c.client.UnlockUser(ctx, &pb.UnlockUserRequest{
+		UserId: userID,
+	})
+}
+
+func (c *UserClient) RequireTwoFactor(ctx context.Context, userID int64, required bool) (*pb.RequireTwoFactorResponse, error) {
+	return c.client.RequireTwoFactor(ctx, &pb.RequireTwoFactorRequest{
+		UserId:   userID,
+		Required: required,
+	})
+}
+
+func (c *UserClient) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.GetUserResponse, error) {
+	return c.client.UpdateProfile(ctx, req)
+}
+
+func (c *UserClient) ChangeEmail(ctx context.Context, password, newEmail string) (*pb.ChangeEmailResponse, error) {
+	return c.client.ChangeEmail(ctx, &pb.ChangeEmailRequest{
+		Password: password,
+		NewEmail: newEmail,
+	})
+}
+
+func (c *UserClient) ChangePassword(ctx context.Context, currentPassword, newPassword string) (*pb.ChangePasswordResponse, error) {
+	return c.client.ChangePassword(ctx, &pb.ChangePasswordRequest{
+		CurrentPassword: currentPassword,
+		NewPassword:     newPassword,
+	})
+}
+
+func (c *UserClient) DeleteAccount(ctx context.Context, password, code string) (*pb.DeleteAccountResponse, error) {
+	return c.client.DeleteAccount(ctx, &pb.DeleteAccountRequest{
+		Password: password,
+		Code:     code,
+	})
+}
+
+func (c *UserClient) ListTravellers(ctx context.Context) (*pb.ListTravellersResponse, error) {
+	return c.client.ListTravellers(ctx, &pb.ListTravellersRequest{})
+}
+
+func (c *UserClient) CreateTraveller(ctx context.Context, fullName, idNumber, passengerType string) (*pb.Traveller, error) {
+	return c.client.CreateTraveller(ctx, &pb.CreateTravellerRequest{
+		FullName:      fullName,
+		IdNumber:      idNumber,
+		PassengerType: passengerType,
+	})
+}
+
+func (c *UserClient) UpdateTraveller(ctx context.Context, travellerID int64, fullName, idNumber, passengerType string) (*pb.Traveller, error) {
+	return c.client.UpdateTraveller(ctx, &pb.UpdateTravellerRequest{
+		TravellerId:   travellerID,
+		FullName:      fullName,
+		IdNumber:      idNumber,
+		PassengerType: passengerType,
+	})
+}
+
+func (c *UserClient) DeleteTraveller(ctx context.Context, travellerID int64) (*pb.DeleteTravellerResponse, error) {
+	return c.client.DeleteTraveller(ctx, &pb.DeleteTravellerRequest{
+		TravellerId: travellerID,
+	})
+}
+
+func (c *UserClient) IsSessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	resp, err := c.client.CheckSession(ctx, &pb.CheckSessionRequest{
+		SessionId: sessionID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Revoked, nil
+}
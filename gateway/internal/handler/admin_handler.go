@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"ticket-booking/gateway/internal/client"
+)
+
+// AdminHandler serves /api/admin/users. Routes are guarded by RequireRole,
+// and user-service checks the roles again on the RPCs that change a user.
+type AdminHandler struct {
+	userClient *client.UserClient
+}
+
+func NewAdminHandler(userClient *client.UserClient) *AdminHandler {
+	return &AdminHandler{userClient: userClient}
+}
+
+// GetUser shows a user with their roles, for admins and support staff.
+func (h *AdminHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	userId, _, err := adminUserPath(r.URL.Path)
+	if err != nil {
+		http.Error(w, "Invalid user_id", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.userClient.GetUser(r.Context(), userId)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GrantRole takes {"role": "operator"} and returns the user's roles.
+func (h *AdminHandler) GrantRole(w http.ResponseWriter, r *http.Request) {
+	userId, _, err := adminUserPath(r.URL.Path)
+	if err != nil {
+		http.Error(w, "Invalid user_id", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Role string `json:"role"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.userClient.GrantRole(r.Context(), userId, req.Role)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// RevokeRole removes the role named in the path and returns the user's roles.
+func (h *AdminHandler) RevokeRole(w http.ResponseWriter, r *http.Request) {
+	userId, role, err := adminUserPath(r.URL.Path)
+	if err != nil || role == "" {
+		http.Error(w, "Invalid user_id or role", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.userClient.RevokeRole(r.Context(), userId, role)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// UnlockUser lifts a login lockout so the user can try again right away.
+func (h *AdminHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	userId, _, err := adminUserPath(r.URL.Path)
+	if err != nil {
+		http.Error(w, "Invalid user_id", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.userClient.UnlockUser(r.Context(), userId)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// RequireTwoFactor takes {"required": true} and makes two-factor
+// authentication mandatory for the user, or optional again.
+func (h *AdminHandler) RequireTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userId, _, err := adminUserPath(r.URL.Path)
+	if err != nil {
+		http.Error(w, "Invalid user_id", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Required bool `json:"required"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.userClient.RequireTwoFactor(r.Context(), userId, req.Required)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// adminUserPath reads the user ID and, if present, the role from
+// /api/admin/users/:id[/roles[/:role]].
+func adminUserPath(path string) (int64, string, error) {
+	parts := strings.Split(strings.TrimPrefix(path, "/api/admin/users/"), "/")
+	userId, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, "", err
+	}
+	if len(parts) == 3 && parts[1] == "roles" {
+		return userId, parts[2], nil
+	}
+	return userId, "", nil
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"ticket-booking/gateway/internal/client"
+)
+
+// AmenityHandler serves the amenity catalogue and the amenities set on each
+// train. They sit outside /api/trains because that prefix is handed to the
+// train-service proxy as a whole.
+type AmenityHandler struct {
+	trainClient *client.TrainClient
+}
+
+func NewAmenityHandler(trainClient *client.TrainClient) *AmenityHandler {
+	return &AmenityHandler{trainClient: trainClient}
+}
+
+func (h *AmenityHandler) ListAmenities(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.trainClient.ListAmenities(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// SetTrainAmenities replaces the train's own amenities with the codes in the
+// body; its coaches keep theirs.
+func (h *AmenityHandler) SetTrainAmenities(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(r.URL.Path, "/")
+	trainId, err := strconv.ParseInt(parts[len(parts)-1], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid train_id", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Amenities []string `json:"amenities"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.trainClient.SetTrainAmenities(r.Context(), trainId, req.Amenities)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -1,13 +1,14 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"ticket-booking/gateway/internal/client"
+	"ticket-booking/gateway/internal/middleware"
+	pb "ticket-booking/proto/booking"
 )
 
 type BookingHandler struct {
@@ -19,10 +20,17 @@ func NewBookingHandler(bookingClient *client.BookingClient) *BookingHandler {
 }
 
 func (h *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
+	userId, err := strconv.ParseInt(r.Header.Get(middleware.UserIDHeader), 10, 64)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	var req struct {
-		UserId     int64 `json:"user_id"`
-		ScheduleId int64 `json:"schedule_id"`
-		SeatCount  int32 `json:"seat_count"`
+		ScheduleId int64           `json:"schedule_id"`
+		SeatCount  int32           `json:"seat_count"`
+		Class      string          `json:"class"`
+		Passengers []*pb.Passenger `json:"passengers"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -30,7 +38,7 @@ func (h *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.bookingClient.CreateBooking(context.Background(), req.UserId, req.ScheduleId, req.SeatCount)
+	resp, err := h.bookingClient.CreateBooking(r.Context(), userId, req.ScheduleId, req.SeatCount, req.Class, req.Passengers)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -55,7 +63,7 @@ func (h *BookingHandler) GetBooking(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := h.bookingClient.GetBooking(context.Background(), bookingId)
+	resp, err := h.bookingClient.GetBooking(r.Context(), bookingId)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -74,12 +82,23 @@ func (h *BookingHandler) ListUserBookings(w http.ResponseWriter, r *http.Request
 	}
 
 	userIdStr := parts[len(parts)-1]
-	userId, err := strconv.ParseInt(userIdStr, 10, 64)
+	pathUserId, err := strconv.ParseInt(userIdStr, 10, 64)
 	if err != nil {
 		http.Error(w, "Invalid user_id", http.StatusBadRequest)
 		return
 	}
 
+	// Users only see their own history
+	userId, err := strconv.ParseInt(r.Header.Get(middleware.UserIDHeader), 10, 64)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if pathUserId != userId {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
 	pageStr := r.URL.Query().Get("page")
 	limitStr := r.URL.Query().Get("limit")
 
@@ -98,7 +117,19 @@ func (h *BookingHandler) ListUserBookings(w http.ResponseWriter, r *http.Request
 		}
 	}
 
-	resp, err := h.bookingClient.ListUserBookings(context.Background(), userId, page, limit)
+	query := r.URL.Query()
+	resp, err := h.bookingClient.ListUserBookings(r.Context(), &pb.ListUserBookingsRequest{
+		UserId:        userId,
+		Page:          page,
+		Limit:         limit,
+		Status:        query.Get("status"),
+		DepartureFrom: query.Get("departure_from"),
+		DepartureTo:   query.Get("departure_to"),
+		Origin:        query.Get("origin"),
+		Destination:   query.Get("destination"),
+		Sort:          query.Get("sort"),
+		Cursor:        query.Get("cursor"),
+	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -108,10 +139,11 @@ func (h *BookingHandler) ListUserBookings(w http.ResponseWriter, r *http.Request
 	json.NewEncoder(w).Encode(resp)
 }
 
+// UpdatePaymentStatus records a payment result. Only staff may call it, so
+// customers cannot mark their own bookings paid.
 func (h *BookingHandler) UpdatePaymentStatus(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		BookingId int64  `json:"booking_id"`
-		UserId    int64  `json:"user_id"`
 		Status    string `json:"status"` 
 	}
 
@@ -120,7 +152,7 @@ func (h *BookingHandler) UpdatePaymentStatus(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	resp, err := h.bookingClient.UpdatePaymentStatus(context.Background(), req.BookingId, req.UserId, req.Status)
+	resp, err := h.bookingClient.UpdatePaymentStatus(r.Context(), req.BookingId, req.Status)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -145,16 +177,13 @@ func (h *BookingHandler) CancelBooking(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var req struct {
-		UserId int64 `json:"user_id"`
-	}
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	userId, err := strconv.ParseInt(r.Header.Get(middleware.UserIDHeader), 10, 64)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
-	resp, err := h.bookingClient.CancelBooking(context.Background(), bookingId, req.UserId)
+	resp, err := h.bookingClient.CancelBooking(r.Context(), bookingId, userId)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -163,3 +192,35 @@ func (h *BookingHandler) CancelBooking(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
 }
+
+func (h *BookingHandler) GetBookingDocument(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/document")
+	parts := strings.Split(path, "/")
+	if len(parts) < 4 {
+		http.Error(w, "Invalid booking_id", http.StatusBadRequest)
+		return
+	}
+
+	bookingIdStr := parts[len(parts)-1]
+	bookingId, err := strconv.ParseInt(bookingIdStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid booking_id", http.StatusBadRequest)
+		return
+	}
+
+	userId, err := strconv.ParseInt(r.Header.Get(middleware.UserIDHeader), 10, 64)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	resp, err := h.bookingClient.GetBookingDocument(r.Context(), bookingId, userId)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", resp.ContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+resp.FileName+`"`)
+	w.Write(resp.Content)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"ticket-booking/gateway/internal/client"
+	"ticket-booking/gateway/internal/middleware"
+)
+
+// calendarFeedPath is the public prefix of subscribable feeds; the token in
+// the URL is the only credential, so calendar apps can poll it unattended.
+const calendarFeedPath = "/api/calendar/"
+
+type CalendarHandler struct {
+	bookingClient *client.BookingClient
+	publicURL     string
+}
+
+func NewCalendarHandler(bookingClient *client.BookingClient, publicURL string) *CalendarHandler {
+	return &CalendarHandler{bookingClient: bookingClient, publicURL: publicURL}
+}
+
+func (h *CalendarHandler) GetBookingCalendar(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/calendar"), "/")
+	bookingId, err := strconv.ParseInt(parts[len(parts)-1], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid booking_id", http.StatusBadRequest)
+		return
+	}
+
+	userId, err := strconv.ParseInt(r.Header.Get(middleware.UserIDHeader), 10, 64)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	resp, err := h.bookingClient.GetBookingCalendar(r.Context(), bookingId, userId)
+	if err != nil {
+		writeCalendarError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", resp.ContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+resp.FileName+`"`)
+	w.Write(resp.Content)
+}
+
+// GetCalendarFeedURL returns the caller's feed URL the first time it is
+// asked for. The token is not kept, so after that GET only reports that the
+// feed is active; POSTing rotates the token, so a leaked or lost URL stops
+// working, and returns the new URL.
+func (h *CalendarHandler) GetCalendarFeedURL(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.bookingClient.GetCalendarFeedToken(r.Context(), r.Method == http.MethodPost)
+	if err != nil {
+		writeCalendarError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if resp.Token == "" {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"active":  true,
+			"message": "The feed URL is only shown once; POST to issue a new one",
+		})
+		return
+	}
+
+	feedURL := h.publicURL + calendarFeedPath + resp.Token + ".ics"
+	webcalURL := feedURL
+	if i := strings.Index(feedURL, "://"); i >= 0 {
+		webcalURL = "webcal" + feedURL[i:]
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"active":     true,
+		"url":        feedURL,
+		"webcal_url": webcalURL,
+	})
+}
+
+func (h *CalendarHandler) GetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, calendarFeedPath), ".ics")
+
+	resp, err := h.bookingClient.GetCalendarFeed(r.Context(), token)
+	if err != nil {
+		writeCalendarError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", resp.ContentType)
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	w.Write(resp.Content)
+}
+
+// writeCalendarError answers 404 for unknown bookings and for feed tokens that
+// are unknown or were revoked by a rotation.
+func writeCalendarError(w http.ResponseWriter, err error) {
+	switch status.Code(err) {
+	case codes.NotFound:
+		http.Error(w, "Not found", http.StatusNotFound)
+	case codes.FailedPrecondition:
+		http.Error(w, status.Convert(err).Message(), http.StatusConflict)
+	case codes.Unauthenticated:
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	default:
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
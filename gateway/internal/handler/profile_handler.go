@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"ticket-booking/gateway/internal/client"
+	"ticket-booking/gateway/internal/middleware"
+	pb "ticket-booking/proto/user"
+)
+
+// ProfileHandler serves /api/users/me, the caller's own account. The
+// identity RequireAuth attached to the request context tells user-service
+// whose account it is.
+type ProfileHandler struct {
+	userClient *client.UserClient
+}
+
+func NewProfileHandler(userClient *client.UserClient) *ProfileHandler {
+	return &ProfileHandler{userClient: userClient}
+}
+
+func (h *ProfileHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	userId, err := strconv.ParseInt(r.Header.Get(middleware.UserIDHeader), 10, 64)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	resp, err := h.userClient.GetUser(r.Context(), userId)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// UpdateProfile changes the fields present in the body and keeps the rest.
+// An empty string clears a field.
+func (h *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	userId, err := strconv.ParseInt(r.Header.Get(middleware.UserIDHeader), 10, 64)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		FullName    *string `json:"full_name"`
+		Phone       *string `json:"phone"`
+		DateOfBirth *string `json:"date_of_birth"`
+		Locale      *string `json:"locale"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	current, err := h.userClient.GetUser(r.Context(), userId)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	profile := &pb.UpdateProfileRequest{
+		FullName:    current.FullName,
+		Phone:       current.Phone,
+		DateOfBirth: current.DateOfBirth,
+		Locale:      current.Locale,
+	}
+	if req.FullName != nil {
+		profile.FullName = *req.FullName
+	}
+	if req.Phone != nil {
+		profile.Phone = *req.Phone
+	}
+	if req.DateOfBirth != nil {
+		profile.DateOfBirth = *req.DateOfBirth
+	}
+	if req.Locale != nil {
+		profile.Locale = *req.Locale
+	}
+
+	resp, err := h.userClient.UpdateProfile(r.Context(), profile)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ChangeEmail takes {"password", "new_email"}. The address only changes
+// once the link sent to it is followed.
+func (h *ProfileHandler) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Password string `json:"password"`
+		NewEmail string `json:"new_email"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.userClient.ChangeEmail(r.Context(), req.Password, req.NewEmail)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ChangePassword takes {"current_password", "new_password"}. Every other
+// session is logged out; the response holds tokens for a new one.
+func (h *ProfileHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		CurrentPassword string `json:"current_password"`
+		NewPassword     string `json:"new_password"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.userClient.ChangePassword(r.Context(), req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// DeleteAccount takes {"password"} and, with two-factor authentication on,
+// a "code". Bookings are kept but no longer name the user.
+func (h *ProfileHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Password string `json:"password"`
+		Code     string `json:"code"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.userClient.DeleteAccount(r.Context(), req.Password, req.Code)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// writeUserError turns a user-service status into the matching HTTP one.
+func writeUserError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	code := http.StatusInternalServerError
+	switch st.Code() {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.Unauthenticated:
+		code = http.StatusUnauthorized
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.AlreadyExists:
+		code = http.StatusConflict
+	case codes.FailedPrecondition:
+		code = http.StatusConflict
+	case codes.ResourceExhausted:
+		code = http.StatusTooManyRequests
+	}
+	http.Error(w, st.Message(), code)
+}
@@ -3,11 +3,15 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"ticket-booking/gateway/internal/client"
+	"ticket-booking/gateway/internal/middleware"
+	pb "ticket-booking/proto/schedule"
 )
 
 type ScheduleHandler struct {
@@ -19,28 +23,46 @@ func NewScheduleHandler(scheduleClient *client.ScheduleClient) *ScheduleHandler
 }
 
 func (h *ScheduleHandler) SearchSchedules(w http.ResponseWriter, r *http.Request) {
-	origin := r.URL.Query().Get("origin")
-	destination := r.URL.Query().Get("destination")
-	departureDate := r.URL.Query().Get("departure_date")
-	pageStr := r.URL.Query().Get("page")
-	limitStr := r.URL.Query().Get("limit")
-
-	page := int32(1)
-	limit := int32(10)
-
-	if pageStr != "" {
-		if p, err := strconv.ParseInt(pageStr, 10, 32); err == nil {
-			page = int32(p)
+	query := r.URL.Query()
+	req := &pb.ListSchedulesRequest{
+		Origin:            query.Get("origin"),
+		Destination:       query.Get("destination"),
+		DepartureDate:     query.Get("departure_date"),
+		DepartureTimeFrom: query.Get("departure_time_from"),
+		DepartureTimeTo:   query.Get("departure_time_to"),
+		TrainType:         query.Get("train_type"),
+		SortBy:            query.Get("sort_by"),
+		SortOrder:         query.Get("sort_order"),
+		Page:              1,
+		Limit:             10,
+	}
+
+	int32Params := map[string]*int32{
+		"page":                 &req.Page,
+		"limit":                &req.Limit,
+		"min_available_seats":  &req.MinAvailableSeats,
+		"max_duration_minutes": &req.MaxDurationMinutes,
+	}
+	for name, target := range int32Params {
+		if v := query.Get(name); v != "" {
+			if n, err := strconv.ParseInt(v, 10, 32); err == nil {
+				*target = int32(n)
+			}
 		}
 	}
 
-	if limitStr != "" {
-		if l, err := strconv.ParseInt(limitStr, 10, 32); err == nil {
-			limit = int32(l)
+	if v := query.Get("max_price"); v != "" {
+		if p, err := strconv.ParseFloat(v, 64); err == nil {
+			req.MaxPrice = p
 		}
 	}
 
-	resp, err := h.scheduleClient.ListSchedules(context.Background(), origin, destination, departureDate, page, limit)
+	// amenities=wifi,dining_car or repeated amenities=... both work.
+	for _, v := range query["amenities"] {
+		req.Amenities = append(req.Amenities, strings.Split(v, ",")...)
+	}
+
+	resp, err := h.scheduleClient.ListSchedules(context.Background(), req)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -50,6 +72,31 @@ func (h *ScheduleHandler) SearchSchedules(w http.ResponseWriter, r *http.Request
 	json.NewEncoder(w).Encode(resp)
 }
 
+func (h *ScheduleHandler) SearchCalendar(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &pb.SearchCalendarRequest{
+		Origin:        query.Get("origin"),
+		Destination:   query.Get("destination"),
+		DepartureDate: query.Get("departure_date"),
+		Month:         query.Get("month"),
+	}
+
+	if windowStr := query.Get("window_days"); windowStr != "" {
+		if wd, err := strconv.ParseInt(windowStr, 10, 32); err == nil {
+			req.WindowDays = int32(wd)
+		}
+	}
+
+	resp, err := h.scheduleClient.SearchCalendar(context.Background(), req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
 func (h *ScheduleHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
 	path := r.URL.Path
 	parts := strings.Split(path, "/")
@@ -77,12 +124,126 @@ func (h *ScheduleHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
 
 func (h *ScheduleHandler) CreateSchedule(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		TrainId       int64   `json:"train_id"`
-		Origin        string  `json:"origin"`
-		Destination   string  `json:"destination"`
-		DepartureTime string  `json:"departure_time"`
-		ArrivalTime   string  `json:"arrival_time"`
-		Price         float64 `json:"price"`
+		TrainId             int64   `json:"train_id"`
+		Origin              string  `json:"origin"`
+		Destination         string  `json:"destination"`
+		DepartureTime       string  `json:"departure_time"`
+		ArrivalTime         string  `json:"arrival_time"`
+		Price               float64 `json:"price"`
+		OriginTimezone      string  `json:"origin_timezone"`
+		DestinationTimezone string  `json:"destination_timezone"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.scheduleClient.CreateSchedule(r.Context(), &pb.CreateScheduleRequest{
+		TrainId:             req.TrainId,
+		Origin:              req.Origin,
+		Destination:         req.Destination,
+		DepartureTime:       req.DepartureTime,
+		ArrivalTime:         req.ArrivalTime,
+		Price:               req.Price,
+		OriginTimezone:      req.OriginTimezone,
+		DestinationTimezone: req.DestinationTimezone,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *ScheduleHandler) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	scheduleId, err := scheduleIDFromPath(r.URL.Path, "")
+	if err != nil {
+		http.Error(w, "Invalid schedule_id", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Origin              string  `json:"origin"`
+		Destination         string  `json:"destination"`
+		DepartureTime       string  `json:"departure_time"`
+		ArrivalTime         string  `json:"arrival_time"`
+		Price               float64 `json:"price"`
+		OriginTimezone      string  `json:"origin_timezone"`
+		DestinationTimezone string  `json:"destination_timezone"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.scheduleClient.UpdateSchedule(r.Context(), &pb.UpdateScheduleRequest{
+		ScheduleId:          scheduleId,
+		Origin:              req.Origin,
+		Destination:         req.Destination,
+		DepartureTime:       req.DepartureTime,
+		ArrivalTime:         req.ArrivalTime,
+		Price:               req.Price,
+		OriginTimezone:      req.OriginTimezone,
+		DestinationTimezone: req.DestinationTimezone,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ReassignTrain puts another train on the schedule. With dry_run the
+// response shows the seat moves and unseated bookings without applying them.
+func (h *ScheduleHandler) ReassignTrain(w http.ResponseWriter, r *http.Request) {
+	scheduleId, err := scheduleIDFromPath(r.URL.Path, "/reassign")
+	if err != nil {
+		http.Error(w, "Invalid schedule_id", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		TrainId int64 `json:"train_id"`
+		DryRun  bool  `json:"dry_run"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.scheduleClient.ReassignTrain(r.Context(), &pb.ReassignTrainRequest{
+		ScheduleId: scheduleId,
+		TrainId:    req.TrainId,
+		DryRun:     req.DryRun,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *ScheduleHandler) UpdateScheduleStatus(w http.ResponseWriter, r *http.Request) {
+	scheduleId, err := scheduleIDFromPath(r.URL.Path, "/status")
+	if err != nil {
+		http.Error(w, "Invalid schedule_id", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Status       string `json:"status"`
+		DelayMinutes int32  `json:"delay_minutes"`
+		Platform     string `json:"platform"`
+		Reason       string `json:"reason"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -90,12 +251,127 @@ func (h *ScheduleHandler) CreateSchedule(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	resp, err := h.scheduleClient.CreateSchedule(context.Background(), req.TrainId, req.Origin, req.Destination, req.DepartureTime, req.ArrivalTime, req.Price)
+	updatedBy, _ := strconv.ParseInt(r.Header.Get(middleware.UserIDHeader), 10, 64)
+
+	resp, err := h.scheduleClient.UpdateScheduleStatus(r.Context(), &pb.UpdateScheduleStatusRequest{
+		ScheduleId:   scheduleId,
+		Status:       req.Status,
+		DelayMinutes: req.DelayMinutes,
+		Platform:     req.Platform,
+		Reason:       req.Reason,
+		UpdatedBy:    updatedBy,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// StreamScheduleStatus relays WatchSchedule to the browser as Server-Sent
+// Events, one "status" event per update, until either side disconnects.
+func (h *ScheduleHandler) StreamScheduleStatus(w http.ResponseWriter, r *http.Request) {
+	scheduleId, err := scheduleIDFromPath(r.URL.Path, "/status/stream")
+	if err != nil {
+		http.Error(w, "Invalid schedule_id", http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	stream, err := h.scheduleClient.WatchSchedule(r.Context(), scheduleId)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			return
+		}
+
+		data, err := json.Marshal(update)
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(w, "event: status\ndata: %s\n\n", data)
+		flusher.Flush()
+	}
+}
+
+func scheduleIDFromPath(path, suffix string) (int64, error) {
+	parts := strings.Split(strings.TrimSuffix(path, suffix), "/")
+	return strconv.ParseInt(parts[len(parts)-1], 10, 64)
+}
+
+// ImportGTFS takes the feed as the multipart file "feed". Form fields
+// dry_run, train_type, default_capacity, default_price, from_date and to_date
+// tune the import.
+func (h *ScheduleHandler) ImportGTFS(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 32<<20)
+
+	file, _, err := r.FormFile("feed")
+	if err != nil {
+		http.Error(w, "feed file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	archive, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, "Invalid feed file", http.StatusBadRequest)
+		return
+	}
+
+	req := &pb.ImportGTFSRequest{
+		Archive:   archive,
+		DryRun:    r.FormValue("dry_run") == "true",
+		TrainType: r.FormValue("train_type"),
+		FromDate:  r.FormValue("from_date"),
+		ToDate:    r.FormValue("to_date"),
+	}
+	if v := r.FormValue("default_capacity"); v != "" {
+		if c, err := strconv.ParseInt(v, 10, 32); err == nil {
+			req.DefaultCapacity = int32(c)
+		}
+	}
+	if v := r.FormValue("default_price"); v != "" {
+		if p, err := strconv.ParseFloat(v, 64); err == nil {
+			req.DefaultPrice = p
+		}
+	}
+
+	resp, err := h.scheduleClient.ImportGTFS(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
 }
+
+func (h *ScheduleHandler) ExportGTFS(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.scheduleClient.ExportGTFS(context.Background(), r.URL.Query().Get("from_date"), r.URL.Query().Get("to_date"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", resp.ContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+resp.FileName+`"`)
+	w.Write(resp.Content)
+}
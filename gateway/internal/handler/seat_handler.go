@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc/status"
+
+	"ticket-booking/gateway/internal/client"
+)
+
+const (
+	seatWriteWait  = 10 * time.Second
+	seatPongWait   = 60 * time.Second
+	seatPingPeriod = (seatPongWait * 9) / 10
+)
+
+var seatUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// Seat availability is public and read-only, so any origin may subscribe.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+type SeatHandler struct {
+	bookingClient *client.BookingClient
+}
+
+func NewSeatHandler(bookingClient *client.BookingClient) *SeatHandler {
+	return &SeatHandler{bookingClient: bookingClient}
+}
+
+// StreamSeatAvailability upgrades to a WebSocket and relays seat availability
+// for the comma separated schedule_ids as JSON messages.
+//
+// Each connection is written to directly from its upstream stream, so a slow
+// browser stalls only its own gRPC stream; booking-service coalesces updates
+// for it meanwhile. A client that cannot take a write within seatWriteWait is
+// disconnected.
+func (h *SeatHandler) StreamSeatAvailability(w http.ResponseWriter, r *http.Request) {
+	scheduleIds, err := parseScheduleIDs(r.URL.Query().Get("schedule_ids"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := seatUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := h.bookingClient.WatchSeatAvailability(ctx, scheduleIds)
+	if err != nil {
+		closeSeatConn(conn, websocket.CloseInternalServerErr, "availability unavailable")
+		return
+	}
+
+	// Browsers only send control frames; reading notices disconnects and
+	// keeps the pong deadline moving.
+	conn.SetReadLimit(512)
+	conn.SetReadDeadline(time.Now().Add(seatPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(seatPongWait))
+	})
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(seatPingPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(seatWriteWait)); err != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				closeSeatConn(conn, websocket.CloseInternalServerErr, status.Convert(err).Message())
+			}
+			return
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(seatWriteWait))
+		if err := conn.WriteJSON(update); err != nil {
+			return
+		}
+	}
+}
+
+func closeSeatConn(conn *websocket.Conn, code int, reason string) {
+	msg := websocket.FormatCloseMessage(code, reason)
+	conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(seatWriteWait))
+}
+
+func parseScheduleIDs(value string) ([]int64, error) {
+	if value == "" {
+		return nil, errors.New("schedule_ids is required")
+	}
+
+	var ids []int64
+	for _, part := range strings.Split(value, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid schedule_ids")
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"ticket-booking/gateway/internal/client"
+	pb "ticket-booking/proto/train"
 
 	"github.com/gin-gonic/gin"
 )
@@ -30,7 +32,7 @@ func (h *TrainHandler) CreateTrain(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.trainClient.CreateTrain(context.Background(), req.Name, req.Type, req.Capacity)
+	resp, err := h.trainClient.CreateTrain(c.Request.Context(), req.Name, req.Type, req.Capacity)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -70,7 +72,18 @@ func (h *TrainHandler) ListTrains(c *gin.Context) {
 		limit = 10
 	}
 
-	resp, err := h.trainClient.ListTrains(context.Background(), int32(page), int32(limit))
+	req := &pb.ListTrainsRequest{
+		Page:   int32(page),
+		Limit:  int32(limit),
+		Query:  c.Query("q"),
+		Type:   c.Query("type"),
+		Status: c.Query("status"),
+	}
+	for _, v := range c.QueryArray("amenities") {
+		req.Amenities = append(req.Amenities, strings.Split(v, ",")...)
+	}
+
+	resp, err := h.trainClient.ListTrains(context.Background(), req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -99,7 +112,7 @@ func (h *TrainHandler) UpdateTrain(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.trainClient.UpdateTrain(context.Background(), trainId, req.Name, req.Type, req.Status, req.Capacity)
+	resp, err := h.trainClient.UpdateTrain(c.Request.Context(), trainId, req.Name, req.Type, req.Status, req.Capacity)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -116,7 +129,7 @@ func (h *TrainHandler) DeleteTrain(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.trainClient.DeleteTrain(context.Background(), trainId)
+	resp, err := h.trainClient.DeleteTrain(c.Request.Context(), trainId)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
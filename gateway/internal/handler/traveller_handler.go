@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"ticket-booking/gateway/internal/client"
+)
+
+// TravellerHandler serves /api/users/me/travellers, the co-travellers the
+// caller saved. Their IDs can be given as traveller_id when booking.
+type TravellerHandler struct {
+	userClient *client.UserClient
+}
+
+func NewTravellerHandler(userClient *client.UserClient) *TravellerHandler {
+	return &TravellerHandler{userClient: userClient}
+}
+
+func (h *TravellerHandler) ListTravellers(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.userClient.ListTravellers(r.Context())
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// CreateTraveller takes {"full_name", "id_number", "passenger_type"}. The
+// ID number is stored encrypted and only its last digits are shown back.
+func (h *TravellerHandler) CreateTraveller(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		FullName      string `json:"full_name"`
+		IdNumber      string `json:"id_number"`
+		PassengerType string `json:"passenger_type"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.userClient.CreateTraveller(r.Context(), req.FullName, req.IdNumber, req.PassengerType)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// UpdateTraveller replaces the traveller's details; leaving id_number out
+// keeps the stored one.
+func (h *TravellerHandler) UpdateTraveller(w http.ResponseWriter, r *http.Request) {
+	travellerId, err := travellerPath(r.URL.Path)
+	if err != nil {
+		http.Error(w, "Invalid traveller_id", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		FullName      string `json:"full_name"`
+		IdNumber      string `json:"id_number"`
+		PassengerType string `json:"passenger_type"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.userClient.UpdateTraveller(r.Context(), travellerId, req.FullName, req.IdNumber, req.PassengerType)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *TravellerHandler) DeleteTraveller(w http.ResponseWriter, r *http.Request) {
+	travellerId, err := travellerPath(r.URL.Path)
+	if err != nil {
+		http.Error(w, "Invalid traveller_id", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.userClient.DeleteTraveller(r.Context(), travellerId)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// travellerPath reads the traveller ID from /api/users/me/travellers/:id.
+func travellerPath(path string) (int64, error) {
+	return strconv.ParseInt(strings.TrimPrefix(path, "/api/users/me/travellers/"), 10, 64)
+}
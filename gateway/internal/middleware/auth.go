@@ -1,23 +1,46 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
+
+	"ticket-booking/proto/auth"
 )
 
+// UserIDHeader carries the authenticated user ID to handlers and upstream
+// services. RequireAuth overwrites it, so clients cannot supply their own.
+const UserIDHeader = "X-User-ID"
+
+// UserRolesHeader carries the caller's roles, comma-separated, the same way.
+const UserRolesHeader = "X-User-Roles"
+
+// SessionChecker tells whether the session an access token belongs to (its
+// sid claim) was revoked by logout, refresh token reuse or a password reset.
+type SessionChecker interface {
+	IsSessionRevoked(ctx context.Context, sessionID string) (bool, error)
+}
+
+// AuthMiddleware verifies access tokens with the public keys from jwks;
+// the gateway never holds a signing key.
 type AuthMiddleware struct {
-	jwtSecret string
+	jwks     *JWKS
+	sessions SessionChecker
 }
 
-func NewAuthMiddleware(jwtSecret string) *AuthMiddleware {
-	return &AuthMiddleware{jwtSecret: jwtSecret}
+func NewAuthMiddleware(jwks *JWKS, sessions SessionChecker) *AuthMiddleware {
+	return &AuthMiddleware{jwks: jwks, sessions: sessions}
 }
 
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		c.Request.Header.Del(UserIDHeader)
+		c.Request.Header.Del(UserRolesHeader)
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
@@ -33,9 +56,7 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		}
 
 		tokenString := parts[1]
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			return []byte(m.jwtSecret), nil
-		})
+		token, err := jwt.Parse(tokenString, m.jwks.Keyfunc)
 
 		if err != nil || !token.Valid {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
@@ -50,12 +71,96 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
-		c.Set("user_id", int64(claims["user_id"].(float64)))
+		// Tokens issued before sessions existed have no sid and cannot be
+		// revoked, so they are refused
+		sid, _ := claims["sid"].(string)
+		if sid == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			c.Abort()
+			return
+		}
+		revoked, err := m.sessions.IsSessionRevoked(c.Request.Context(), sid)
+		if err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Could not verify session"})
+			c.Abort()
+			return
+		}
+		if revoked {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
+			c.Abort()
+			return
+		}
+
+		// Users an admin requires 2FA of can only enroll (at /api/auth/2fa)
+		// until they have set it up and refreshed their token
+		if setup, _ := claims["two_factor_setup"].(bool); setup {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Set up two-factor authentication first"})
+			c.Abort()
+			return
+		}
+
+		userID := int64(claims["user_id"].(float64))
+		roles := claimRoles(claims)
+		c.Set("user_id", userID)
 		c.Set("username", claims["username"].(string))
+		c.Set("roles", roles)
+		emailVerified, _ := claims["email_verified"].(bool)
+		c.Set("email_verified", emailVerified)
+		c.Request.Header.Set(UserIDHeader, strconv.FormatInt(userID, 10))
+		c.Request.Header.Set(UserRolesHeader, strings.Join(roles, ","))
+		// gRPC calls made with the request context carry the identity on
+		c.Request = c.Request.WithContext(auth.NewOutgoingContext(c.Request.Context(), auth.Identity{
+			UserID: userID,
+			Roles:  roles,
+		}))
+		c.Next()
+	}
+}
+
+// RequireRole lets the request through only if RequireAuth found one of
+// roles in the token. Admins pass every check.
+func (m *AuthMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := auth.Identity{Roles: c.GetStringSlice("roles")}
+		if !id.HasAnyRole(roles...) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Requires one of the roles: " + strings.Join(roles, ", ")})
+			c.Abort()
+			return
+		}
 		c.Next()
 	}
 }
 
+// RequireVerifiedEmail blocks users who have not verified their email yet.
+// The claim is only updated when the token is refreshed, so a user who just
+// verified has to refresh first.
+func (m *AuthMiddleware) RequireVerifiedEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool("email_verified") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Verify your email address first"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// claimRoles reads the roles claim. Tokens issued before roles existed have
+// none, so their holders are treated as customers.
+func claimRoles(claims jwt.MapClaims) []string {
+	raw, _ := claims["roles"].([]interface{})
+	roles := make([]string, 0, len(raw)+1)
+	for _, r := range raw {
+		if role, ok := r.(string); ok && auth.IsRole(role) {
+			roles = append(roles, role)
+		}
+	}
+	if len(roles) == 0 {
+		roles = append(roles, auth.RoleCustomer)
+	}
+	return roles
+}
+
 func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -71,13 +176,11 @@ func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 		}
 
 		tokenString := parts[1]
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			return []byte(m.jwtSecret), nil
-		})
+		token, err := jwt.Parse(tokenString, m.jwks.Keyfunc)
 
 		if err == nil && token.Valid {
 			claims, ok := token.Claims.(jwt.MapClaims)
-			if ok {
+			if ok && m.sessionActive(c.Request.Context(), claims) {
 				c.Set("user_id", int64(claims["user_id"].(float64)))
 				c.Set("username", claims["username"].(string))
 			}
@@ -86,3 +189,18 @@ func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// sessionActive reports whether the token's session is known and not
+// revoked; a failed check counts as inactive. Tokens only good for 2FA
+// enrollment count as inactive too.
+func (m *AuthMiddleware) sessionActive(ctx context.Context, claims jwt.MapClaims) bool {
+	sid, _ := claims["sid"].(string)
+	if sid == "" {
+		return false
+	}
+	if setup, _ := claims["two_factor_setup"].(bool); setup {
+		return false
+	}
+	revoked, err := m.sessions.IsSessionRevoked(ctx, sid)
+	return err == nil && !revoked
+}
@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	// jwksTTL is how long fetched keys are trusted before refetching
+	jwksTTL = 10 * time.Minute
+	// jwksMinRefresh limits refetches, so tokens with made-up kids or an
+	// unreachable user-service do not cause a fetch per request
+	jwksMinRefresh = 30 * time.Second
+)
+
+// JWKS verifies tokens against the public keys user-service publishes. Keys
+// are cached; a token naming a kid the cache does not know triggers a
+// refetch, which is how a rotated-in key gets picked up.
+type JWKS struct {
+	url    string
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+	triedAt   time.Time
+}
+
+func NewJWKS(url string) *JWKS {
+	return &JWKS{
+		url:    url,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Keyfunc is a jwt.Keyfunc accepting only RS256 tokens signed by a
+// published key.
+func (j *JWKS) Keyfunc(token *jwt.Token) (interface{}, error) {
+	if token.Method != jwt.SigningMethodRS256 {
+		return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+	}
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("token has no kid")
+	}
+	return j.key(kid)
+}
+
+func (j *JWKS) key(kid string) (*rsa.PublicKey, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	key, ok := j.keys[kid]
+	if ok && time.Since(j.fetchedAt) < jwksTTL {
+		return key, nil
+	}
+	if time.Since(j.triedAt) >= jwksMinRefresh {
+		j.triedAt = time.Now()
+		err := j.fetch()
+		// Known keys keep working while user-service is unreachable
+		if err != nil && !ok {
+			return nil, err
+		}
+		key, ok = j.keys[kid]
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown kid %s", kid)
+	}
+	return key, nil
+}
+
+func (j *JWKS) fetch() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jwks: %s", resp.Status)
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return err
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || k.Kid == "" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return fmt.Errorf("jwks: key %s: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return fmt.Errorf("jwks: key %s: %w", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	j.keys = keys
+	j.fetchedAt = time.Now()
+	return nil
+}
@@ -2,12 +2,16 @@ package main
 
 import (
 	"log"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 
 	"ticket-booking/gateway/config"
+	"ticket-booking/gateway/internal/client"
+	"ticket-booking/gateway/internal/handler"
 	"ticket-booking/gateway/internal/middleware"
 	"ticket-booking/gateway/internal/proxy"
+	"ticket-booking/proto/auth"
 )
 
 func main() {
@@ -24,8 +28,41 @@ func main() {
 		cfg.ScheduleHost, cfg.SchedulePort,
 	)
 
-	// Initialize middleware (for protected routes)
-	authMiddleware := middleware.NewAuthMiddleware("your-jwt-secret-key-here")
+	// Booking-service only exposes gRPC, so booking routes go through the gRPC client
+	bookingClient, err := client.NewBookingClient(cfg.BookHost, cfg.BookGRPCPort)
+	if err != nil {
+		log.Fatalf("booking client: %v", err)
+	}
+	bookingHandler := handler.NewBookingHandler(bookingClient)
+	seatHandler := handler.NewSeatHandler(bookingClient)
+	calendarHandler := handler.NewCalendarHandler(bookingClient, cfg.PublicURL)
+
+	// Schedule search runs over gRPC as the schedule-service HTTP port only serves health
+	scheduleClient, err := client.NewScheduleClient(cfg.ScheduleHost, cfg.ScheduleGRPCPort)
+	if err != nil {
+		log.Fatalf("schedule client: %v", err)
+	}
+	scheduleHandler := handler.NewScheduleHandler(scheduleClient)
+
+	// Amenities are read and set over gRPC to train-service
+	trainClient, err := client.NewTrainClient(cfg.TrainHost, cfg.TrainGRPCPort)
+	if err != nil {
+		log.Fatalf("train client: %v", err)
+	}
+	amenityHandler := handler.NewAmenityHandler(trainClient)
+
+	// Session checks and role management go over gRPC to user-service
+	userClient, err := client.NewUserClient(cfg.UserHost, cfg.UserGRPCPort)
+	if err != nil {
+		log.Fatalf("user client: %v", err)
+	}
+	adminHandler := handler.NewAdminHandler(userClient)
+	profileHandler := handler.NewProfileHandler(userClient)
+	travellerHandler := handler.NewTravellerHandler(userClient)
+
+	// Initialize middleware (for protected routes); tokens are checked
+	// against the keys user-service publishes
+	authMiddleware := middleware.NewAuthMiddleware(middleware.NewJWKS(cfg.JWKSURL), userClient)
 
 	r := gin.Default()
 
@@ -39,24 +76,82 @@ func main() {
 		authGroup.Any("/*path", reverseProxy.ProxyToUserService())
 	}
 
-	// Schedule routes - direct proxy to schedule-service
+	// Schedule routes - gRPC to schedule-service; timetable changes are for operators
 	scheduleGroup := r.Group("/api/schedules")
 	{
-		scheduleGroup.Any("/*path", reverseProxy.ProxyToScheduleService())
+		scheduleGroup.GET("/search", gin.WrapF(scheduleHandler.SearchSchedules))
+		scheduleGroup.GET("/calendar", gin.WrapF(scheduleHandler.SearchCalendar))
+		scheduleGroup.GET("/availability/ws", gin.WrapF(seatHandler.StreamSeatAvailability))
+		scheduleGroup.GET("/gtfs/export", gin.WrapF(scheduleHandler.ExportGTFS))
+		scheduleGroup.POST("/gtfs/import", authMiddleware.RequireAuth(), authMiddleware.RequireRole(auth.RoleOperator), gin.WrapF(scheduleHandler.ImportGTFS))
+		scheduleGroup.GET("/:id", gin.WrapF(scheduleHandler.GetSchedule))
+		scheduleGroup.PUT("/:id", authMiddleware.RequireAuth(), authMiddleware.RequireRole(auth.RoleOperator), gin.WrapF(scheduleHandler.UpdateSchedule))
+		scheduleGroup.GET("/:id/status/stream", gin.WrapF(scheduleHandler.StreamScheduleStatus))
+		scheduleGroup.POST("/:id/status", authMiddleware.RequireAuth(), authMiddleware.RequireRole(auth.RoleOperator), gin.WrapF(scheduleHandler.UpdateScheduleStatus))
+		scheduleGroup.POST("/:id/reassign", authMiddleware.RequireAuth(), authMiddleware.RequireRole(auth.RoleOperator), gin.WrapF(scheduleHandler.ReassignTrain))
+		scheduleGroup.POST("", authMiddleware.RequireAuth(), authMiddleware.RequireRole(auth.RoleOperator), gin.WrapF(scheduleHandler.CreateSchedule))
 	}
 
-	// Booking routes - with auth middleware + proxy to booking-service
+	// Booking routes - with auth middleware + gRPC to booking-service
 	bookingGroup := r.Group("/api/bookings")
 	bookingGroup.Use(authMiddleware.RequireAuth())
 	{
-		bookingGroup.Any("/*path", reverseProxy.ProxyToBookingService())
+		bookingGroup.POST("/create", authMiddleware.RequireVerifiedEmail(), gin.WrapF(bookingHandler.CreateBooking))
+		bookingGroup.PUT("/payment-status", authMiddleware.RequireRole(auth.RoleSupport), gin.WrapF(bookingHandler.UpdatePaymentStatus))
+		bookingGroup.GET("/calendar-feed", gin.WrapF(calendarHandler.GetCalendarFeedURL))
+		bookingGroup.POST("/calendar-feed", gin.WrapF(calendarHandler.GetCalendarFeedURL))
+		bookingGroup.GET("/user/:user_id", gin.WrapF(bookingHandler.ListUserBookings))
+		bookingGroup.GET("/:id", gin.WrapF(bookingHandler.GetBooking))
+		bookingGroup.GET("/:id/document", gin.WrapF(bookingHandler.GetBookingDocument))
+		bookingGroup.GET("/:id/calendar", gin.WrapF(calendarHandler.GetBookingCalendar))
+		bookingGroup.DELETE("/:id", gin.WrapF(bookingHandler.CancelBooking))
 	}
 
-	// Train routes - with auth middleware + proxy to train-service
+	// Calendar feed - public, the secret token in the path authorises it
+	r.GET("/api/calendar/:token", gin.WrapF(calendarHandler.GetCalendarFeed))
+
+	// Train routes - with auth middleware + proxy to train-service; changes are for operators
 	trainGroup := r.Group("/api/trains")
 	trainGroup.Use(authMiddleware.RequireAuth())
 	{
-		trainGroup.Any("/*path", reverseProxy.ProxyToTrainService())
+		trainGroup.GET("/*path", reverseProxy.ProxyToTrainService())
+		trainGroup.HEAD("/*path", reverseProxy.ProxyToTrainService())
+		for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			trainGroup.Handle(method, "/*path", authMiddleware.RequireRole(auth.RoleOperator), reverseProxy.ProxyToTrainService())
+		}
+	}
+
+	// Amenity routes - the catalogue is public, setting a train's amenities is for operators
+	amenityGroup := r.Group("/api/amenities")
+	{
+		amenityGroup.GET("", gin.WrapF(amenityHandler.ListAmenities))
+		amenityGroup.PUT("/trains/:id", authMiddleware.RequireAuth(), authMiddleware.RequireRole(auth.RoleOperator), gin.WrapF(amenityHandler.SetTrainAmenities))
+	}
+
+	// Account routes - the caller's own profile, gRPC to user-service
+	profileGroup := r.Group("/api/users/me")
+	profileGroup.Use(authMiddleware.RequireAuth())
+	{
+		profileGroup.GET("", gin.WrapF(profileHandler.GetProfile))
+		profileGroup.PATCH("", gin.WrapF(profileHandler.UpdateProfile))
+		profileGroup.DELETE("", gin.WrapF(profileHandler.DeleteAccount))
+		profileGroup.PUT("/email", gin.WrapF(profileHandler.ChangeEmail))
+		profileGroup.PUT("/password", gin.WrapF(profileHandler.ChangePassword))
+		profileGroup.GET("/travellers", gin.WrapF(travellerHandler.ListTravellers))
+		profileGroup.POST("/travellers", gin.WrapF(travellerHandler.CreateTraveller))
+		profileGroup.PUT("/travellers/:id", gin.WrapF(travellerHandler.UpdateTraveller))
+		profileGroup.DELETE("/travellers/:id", gin.WrapF(travellerHandler.DeleteTraveller))
+	}
+
+	// Admin routes - gRPC to user-service
+	adminGroup := r.Group("/api/admin")
+	adminGroup.Use(authMiddleware.RequireAuth())
+	{
+		adminGroup.GET("/users/:id", authMiddleware.RequireRole(auth.RoleSupport), gin.WrapF(adminHandler.GetUser))
+		adminGroup.POST("/users/:id/roles", authMiddleware.RequireRole(auth.RoleAdmin), gin.WrapF(adminHandler.GrantRole))
+		adminGroup.DELETE("/users/:id/roles/:role", authMiddleware.RequireRole(auth.RoleAdmin), gin.WrapF(adminHandler.RevokeRole))
+		adminGroup.POST("/users/:id/unlock", authMiddleware.RequireRole(auth.RoleAdmin), gin.WrapF(adminHandler.UnlockUser))
+		adminGroup.PUT("/users/:id/two-factor", authMiddleware.RequireRole(auth.RoleAdmin), gin.WrapF(adminHandler.RequireTwoFactor))
 	}
 
 	// Gateway health check
@@ -77,9 +172,9 @@ func main() {
 	log.Printf("🚀 Gateway (Reverse Proxy) listening on %s", cfg.Addr())
 	log.Printf("📡 Proxying to services:")
 	log.Printf("   - User Service: http://%s:%d", cfg.UserHost, cfg.UserPort)
-	log.Printf("   - Booking Service: http://%s:%d", cfg.BookHost, cfg.BookPort)
+	log.Printf("   - Booking Service: %s:%d (gRPC)", cfg.BookHost, cfg.BookGRPCPort)
 	log.Printf("   - Train Service: http://%s:%d", cfg.TrainHost, cfg.TrainPort)
-	log.Printf("   - Schedule Service: http://%s:%d", cfg.ScheduleHost, cfg.SchedulePort)
+	log.Printf("   - Schedule Service: %s:%d (gRPC)", cfg.ScheduleHost, cfg.ScheduleGRPCPort)
 
 	log.Fatal(r.Run(cfg.Addr()))
 }
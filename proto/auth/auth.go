@@ -0,0 +1,134 @@
+// Package auth carries the caller's identity from the gateway to the services
+// as gRPC metadata and checks it against per-RPC role rules.
+//
+// The gateway verifies the JWT and is the only client allowed to set this
+// metadata; the services trust it the same way they trust X-User-ID on the
+// HTTP side, so their gRPC ports must not be reachable from outside.
+package auth
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	RoleCustomer = "customer"
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+	RoleSupport  = "support"
+)
+
+// Roles lists every role; every user is a customer.
+var Roles = []string{RoleCustomer, RoleOperator, RoleAdmin, RoleSupport}
+
+func IsRole(role string) bool {
+	for _, r := range Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	userIDKey = "x-user-id"
+	rolesKey  = "x-user-roles"
+)
+
+// Identity is an authenticated caller.
+type Identity struct {
+	UserID int64
+	Roles  []string
+}
+
+// HasAnyRole reports whether the caller holds one of roles. Admins pass
+// every check.
+func (id Identity) HasAnyRole(roles ...string) bool {
+	for _, have := range id.Roles {
+		if have == RoleAdmin {
+			return true
+		}
+		for _, want := range roles {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewOutgoingContext attaches the identity to every gRPC call made with ctx.
+func NewOutgoingContext(ctx context.Context, id Identity) context.Context {
+	return metadata.AppendToOutgoingContext(ctx,
+		userIDKey, strconv.FormatInt(id.UserID, 10),
+		rolesKey, strings.Join(id.Roles, ","))
+}
+
+// FromIncomingContext reads the identity the gateway attached to a call.
+func FromIncomingContext(ctx context.Context) (Identity, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Identity{}, false
+	}
+	ids := md.Get(userIDKey)
+	if len(ids) == 0 {
+		return Identity{}, false
+	}
+	userID, err := strconv.ParseInt(ids[0], 10, 64)
+	if err != nil || userID <= 0 {
+		return Identity{}, false
+	}
+
+	id := Identity{UserID: userID}
+	for _, v := range md.Get(rolesKey) {
+		for _, role := range strings.Split(v, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				id.Roles = append(id.Roles, role)
+			}
+		}
+	}
+	return id, true
+}
+
+// Rules maps full gRPC method names to the roles allowed to call them.
+// Methods that are not listed are open to every caller.
+type Rules map[string][]string
+
+func (r Rules) check(ctx context.Context, method string) error {
+	roles, ok := r[method]
+	if !ok {
+		return nil
+	}
+	id, ok := FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if !id.HasAnyRole(roles...) {
+		return status.Errorf(codes.PermissionDenied, "requires one of the roles: %s", strings.Join(roles, ", "))
+	}
+	return nil
+}
+
+func (r Rules) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := r.check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (r Rules) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := r.check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
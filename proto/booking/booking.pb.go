@@ -11,27 +11,49 @@ import (
 
 // Booking represents a booking
 type Booking struct {
-	Id            int64   `json:"id"`
-	UserId        int64   `json:"user_id"`
-	ScheduleId    int64   `json:"schedule_id"`
-	BookingCode   string  `json:"booking_code"`
-	Status        string  `json:"status"`
-	TotalPrice    float64 `json:"total_price"`
-	SeatCount     int32   `json:"seat_count"`
-	CreatedAt     string  `json:"created_at"`
-	ExpiresAt     string  `json:"expires_at"`
-	Origin        string  `json:"origin"`
-	Destination   string  `json:"destination"`
-	DepartureTime string  `json:"departure_time"`
-	ArrivalTime   string  `json:"arrival_time"`
-	TrainName     string  `json:"train_name"`
-}
-
-// CreateBookingRequest represents create booking request
+	Id            int64         `json:"id"`
+	UserId        int64         `json:"user_id"`
+	ScheduleId    int64         `json:"schedule_id"`
+	BookingCode   string        `json:"booking_code"`
+	Status        string        `json:"status"`
+	TotalPrice    float64       `json:"total_price"`
+	SeatCount     int32         `json:"seat_count"`
+	CreatedAt     string        `json:"created_at"`
+	ExpiresAt     string        `json:"expires_at"`
+	Origin        string        `json:"origin"`
+	Destination   string        `json:"destination"`
+	DepartureTime string        `json:"departure_time"`
+	ArrivalTime   string        `json:"arrival_time"`
+	TrainName     string        `json:"train_name"`
+	TicketPayload string        `json:"ticket_payload,omitempty"`
+	Seats         []*BookedSeat `json:"seats,omitempty"`
+	Passengers    []*Passenger  `json:"passengers,omitempty"`
+}
+
+// BookedSeat is a seat held by a booking on a train with a coach layout
+type BookedSeat struct {
+	CoachNumber int32  `json:"coach_number"`
+	SeatNumber  string `json:"seat_number"`
+	Class       string `json:"class"`
+}
+
+// Passenger is someone travelling on a booking. Setting TravellerId fills
+// the name and type from one of the user's saved travellers.
+type Passenger struct {
+	TravellerId   int64  `json:"traveller_id,omitempty"`
+	FullName      string `json:"full_name"`
+	PassengerType string `json:"passenger_type"`
+}
+
+// CreateBookingRequest represents create booking request. Passengers is
+// optional; when given there is one per seat. UserId is replaced by the
+// caller in the gRPC metadata.
 type CreateBookingRequest struct {
-	UserId     int64 `json:"user_id"`
-	ScheduleId int64 `json:"schedule_id"`
-	SeatCount  int32 `json:"seat_count"`
+	UserId     int64        `json:"user_id"`
+	ScheduleId int64        `json:"schedule_id"`
+	SeatCount  int32        `json:"seat_count"`
+	Class      string       `json:"class,omitempty"`
+	Passengers []*Passenger `json:"passengers,omitempty"`
 }
 
 // CreateBookingResponse represents create booking response
@@ -49,20 +71,33 @@ type GetBookingResponse struct {
 	Booking *Booking `json:"booking"`
 }
 
-// ListUserBookingsRequest represents list user bookings request
+// ListUserBookingsRequest represents list user bookings request.
+// Sort is "recent" (newest booking first, the default) or "upcoming"
+// (trips not yet departed, earliest first). When Cursor is set it takes
+// precedence over Page.
 type ListUserBookingsRequest struct {
-	UserId int64 `json:"user_id"`
-	Page   int32 `json:"page"`
-	Limit  int32 `json:"limit"`
-}
-
-// ListUserBookingsResponse represents list user bookings response
+	UserId        int64  `json:"user_id"`
+	Page          int32  `json:"page"`
+	Limit         int32  `json:"limit"`
+	Status        string `json:"status"`
+	DepartureFrom string `json:"departure_from"`
+	DepartureTo   string `json:"departure_to"`
+	Origin        string `json:"origin"`
+	Destination   string `json:"destination"`
+	Sort          string `json:"sort"`
+	Cursor        string `json:"cursor"`
+}
+
+// ListUserBookingsResponse represents list user bookings response.
+// Total is only filled in for the first page of a listing.
 type ListUserBookingsResponse struct {
-	Bookings []*Booking `json:"bookings"`
-	Total    int32      `json:"total"`
+	Bookings   []*Booking `json:"bookings"`
+	Total      int32      `json:"total"`
+	NextCursor string     `json:"next_cursor"`
 }
 
-// CancelBookingRequest represents cancel booking request
+// CancelBookingRequest represents cancel booking request. UserId is
+// ignored; booking-service acts for the caller in the gRPC metadata.
 type CancelBookingRequest struct {
 	BookingId int64 `json:"booking_id"`
 	UserId    int64 `json:"user_id"`
@@ -77,7 +112,6 @@ type CancelBookingResponse struct {
 // UpdatePaymentStatusRequest represents update payment status request
 type UpdatePaymentStatusRequest struct {
 	BookingId int64  `json:"booking_id"`
-	UserId    int64  `json:"user_id"`
 	Status    string `json:"status"`
 }
 
@@ -88,6 +122,114 @@ type UpdatePaymentStatusResponse struct {
 	Booking *Booking `json:"booking"`
 }
 
+// CheckInRequest represents check in request. The boarding is recorded
+// against the staff member in the gRPC metadata.
+type CheckInRequest struct {
+	TicketPayload string `json:"ticket_payload"`
+	Gate          string `json:"gate"`
+}
+
+// CheckInResponse represents check in response
+type CheckInResponse struct {
+	Success   bool     `json:"success"`
+	Message   string   `json:"message"`
+	Booking   *Booking `json:"booking"`
+	BoardedAt string   `json:"boarded_at"`
+}
+
+// GetBoardingManifestRequest represents get boarding manifest request
+type GetBoardingManifestRequest struct {
+	ScheduleId int64 `json:"schedule_id"`
+}
+
+// BoardingManifestEntry represents a single booking on a boarding manifest
+type BoardingManifestEntry struct {
+	BookingId   int64  `json:"booking_id"`
+	BookingCode string `json:"booking_code"`
+	UserId      int64  `json:"user_id"`
+	SeatCount   int32  `json:"seat_count"`
+	Status      string `json:"status"`
+	BoardedAt   string `json:"boarded_at"`
+	Gate        string `json:"gate"`
+}
+
+// GetBoardingManifestResponse represents get boarding manifest response
+type GetBoardingManifestResponse struct {
+	ScheduleId int64                    `json:"schedule_id"`
+	Entries    []*BoardingManifestEntry `json:"entries"`
+	Boarded    int32                    `json:"boarded"`
+	NotBoarded int32                    `json:"not_boarded"`
+	NoShow     int32                    `json:"no_show"`
+}
+
+// GetBookingDocumentRequest represents get booking document request.
+// UserId is ignored; booking-service acts for the caller in the gRPC
+// metadata.
+type GetBookingDocumentRequest struct {
+	BookingId int64 `json:"booking_id"`
+	UserId    int64 `json:"user_id"`
+}
+
+// GetBookingDocumentResponse represents get booking document response
+type GetBookingDocumentResponse struct {
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content"`
+}
+
+// WatchSeatAvailabilityRequest represents watch seat availability request
+type WatchSeatAvailabilityRequest struct {
+	ScheduleIds []int64 `json:"schedule_ids"`
+}
+
+// SeatAvailabilityUpdate represents the seats left on a schedule after a
+// booking was held, paid, expired or cancelled
+type SeatAvailabilityUpdate struct {
+	ScheduleId     int64  `json:"schedule_id"`
+	AvailableSeats int32  `json:"available_seats"`
+	Reason         string `json:"reason"`
+	UpdatedAt      string `json:"updated_at"`
+}
+
+// GetBookingCalendarRequest represents get booking calendar request
+type GetBookingCalendarRequest struct {
+	BookingId int64 `json:"booking_id"`
+	UserId    int64 `json:"user_id"`
+}
+
+// GetBookingCalendarResponse carries an RFC 5545 calendar for one booking
+type GetBookingCalendarResponse struct {
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content"`
+}
+
+// GetCalendarFeedTokenRequest represents get calendar feed token request for
+// the calling user. Rotate replaces the user's token, invalidating subscribed
+// feed URLs.
+type GetCalendarFeedTokenRequest struct {
+	Rotate bool `json:"rotate"`
+}
+
+// GetCalendarFeedTokenResponse represents get calendar feed token response.
+// Token is empty when the user already has a feed and did not rotate it, as
+// only the token's hash is kept.
+type GetCalendarFeedTokenResponse struct {
+	Token string `json:"token"`
+}
+
+// GetCalendarFeedRequest represents get calendar feed request
+type GetCalendarFeedRequest struct {
+	Token string `json:"token"`
+}
+
+// GetCalendarFeedResponse carries the subscribable calendar of a user's trips
+type GetCalendarFeedResponse struct {
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content"`
+}
+
 // BookingServiceClient is the client API for BookingService service.
 type BookingServiceClient interface {
 	CreateBooking(ctx context.Context, in *CreateBookingRequest, opts ...grpc.CallOption) (*CreateBookingResponse, error)
@@ -95,6 +237,13 @@ type BookingServiceClient interface {
 	ListUserBookings(ctx context.Context, in *ListUserBookingsRequest, opts ...grpc.CallOption) (*ListUserBookingsResponse, error)
 	CancelBooking(ctx context.Context, in *CancelBookingRequest, opts ...grpc.CallOption) (*CancelBookingResponse, error)
 	UpdatePaymentStatus(ctx context.Context, in *UpdatePaymentStatusRequest, opts ...grpc.CallOption) (*UpdatePaymentStatusResponse, error)
+	CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*CheckInResponse, error)
+	GetBoardingManifest(ctx context.Context, in *GetBoardingManifestRequest, opts ...grpc.CallOption) (*GetBoardingManifestResponse, error)
+	GetBookingDocument(ctx context.Context, in *GetBookingDocumentRequest, opts ...grpc.CallOption) (*GetBookingDocumentResponse, error)
+	WatchSeatAvailability(ctx context.Context, in *WatchSeatAvailabilityRequest, opts ...grpc.CallOption) (BookingService_WatchSeatAvailabilityClient, error)
+	GetBookingCalendar(ctx context.Context, in *GetBookingCalendarRequest, opts ...grpc.CallOption) (*GetBookingCalendarResponse, error)
+	GetCalendarFeedToken(ctx context.Context, in *GetCalendarFeedTokenRequest, opts ...grpc.CallOption) (*GetCalendarFeedTokenResponse, error)
+	GetCalendarFeed(ctx context.Context, in *GetCalendarFeedRequest, opts ...grpc.CallOption) (*GetCalendarFeedResponse, error)
 }
 
 type bookingServiceClient struct {
@@ -150,6 +299,92 @@ func (c *bookingServiceClient) UpdatePaymentStatus(ctx context.Context, in *Upda
 	return out, nil
 }
 
+func (c *bookingServiceClient) CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*CheckInResponse, error) {
+	out := new(CheckInResponse)
+	err := c.cc.Invoke(ctx, "/booking.BookingService/CheckIn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) GetBoardingManifest(ctx context.Context, in *GetBoardingManifestRequest, opts ...grpc.CallOption) (*GetBoardingManifestResponse, error) {
+	out := new(GetBoardingManifestResponse)
+	err := c.cc.Invoke(ctx, "/booking.BookingService/GetBoardingManifest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) GetBookingDocument(ctx context.Context, in *GetBookingDocumentRequest, opts ...grpc.CallOption) (*GetBookingDocumentResponse, error) {
+	out := new(GetBookingDocumentResponse)
+	err := c.cc.Invoke(ctx, "/booking.BookingService/GetBookingDocument", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) WatchSeatAvailability(ctx context.Context, in *WatchSeatAvailabilityRequest, opts ...grpc.CallOption) (BookingService_WatchSeatAvailabilityClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BookingService_serviceDesc.Streams[0], "/booking.BookingService/WatchSeatAvailability", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bookingServiceWatchSeatAvailabilityClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BookingService_WatchSeatAvailabilityClient interface {
+	Recv() (*SeatAvailabilityUpdate, error)
+	grpc.ClientStream
+}
+
+type bookingServiceWatchSeatAvailabilityClient struct {
+	grpc.ClientStream
+}
+
+func (x *bookingServiceWatchSeatAvailabilityClient) Recv() (*SeatAvailabilityUpdate, error) {
+	m := new(SeatAvailabilityUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bookingServiceClient) GetBookingCalendar(ctx context.Context, in *GetBookingCalendarRequest, opts ...grpc.CallOption) (*GetBookingCalendarResponse, error) {
+	out := new(GetBookingCalendarResponse)
+	err := c.cc.Invoke(ctx, "/booking.BookingService/GetBookingCalendar", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) GetCalendarFeedToken(ctx context.Context, in *GetCalendarFeedTokenRequest, opts ...grpc.CallOption) (*GetCalendarFeedTokenResponse, error) {
+	out := new(GetCalendarFeedTokenResponse)
+	err := c.cc.Invoke(ctx, "/booking.BookingService/GetCalendarFeedToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) GetCalendarFeed(ctx context.Context, in *GetCalendarFeedRequest, opts ...grpc.CallOption) (*GetCalendarFeedResponse, error) {
+	out := new(GetCalendarFeedResponse)
+	err := c.cc.Invoke(ctx, "/booking.BookingService/GetCalendarFeed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BookingServiceServer is the server API for BookingService service.
 type BookingServiceServer interface {
 	CreateBooking(context.Context, *CreateBookingRequest) (*CreateBookingResponse, error)
@@ -157,6 +392,13 @@ type BookingServiceServer interface {
 	ListUserBookings(context.Context, *ListUserBookingsRequest) (*ListUserBookingsResponse, error)
 	CancelBooking(context.Context, *CancelBookingRequest) (*CancelBookingResponse, error)
 	UpdatePaymentStatus(context.Context, *UpdatePaymentStatusRequest) (*UpdatePaymentStatusResponse, error)
+	CheckIn(context.Context, *CheckInRequest) (*CheckInResponse, error)
+	GetBoardingManifest(context.Context, *GetBoardingManifestRequest) (*GetBoardingManifestResponse, error)
+	GetBookingDocument(context.Context, *GetBookingDocumentRequest) (*GetBookingDocumentResponse, error)
+	WatchSeatAvailability(*WatchSeatAvailabilityRequest, BookingService_WatchSeatAvailabilityServer) error
+	GetBookingCalendar(context.Context, *GetBookingCalendarRequest) (*GetBookingCalendarResponse, error)
+	GetCalendarFeedToken(context.Context, *GetCalendarFeedTokenRequest) (*GetCalendarFeedTokenResponse, error)
+	GetCalendarFeed(context.Context, *GetCalendarFeedRequest) (*GetCalendarFeedResponse, error)
 }
 
 // UnimplementedBookingServiceServer can be embedded to have forward compatible implementations.
@@ -183,6 +425,34 @@ func (*UnimplementedBookingServiceServer) UpdatePaymentStatus(context.Context, *
 	return nil, status.Errorf(codes.Unimplemented, "method UpdatePaymentStatus not implemented")
 }
 
+func (*UnimplementedBookingServiceServer) CheckIn(context.Context, *CheckInRequest) (*CheckInResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckIn not implemented")
+}
+
+func (*UnimplementedBookingServiceServer) GetBoardingManifest(context.Context, *GetBoardingManifestRequest) (*GetBoardingManifestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBoardingManifest not implemented")
+}
+
+func (*UnimplementedBookingServiceServer) GetBookingDocument(context.Context, *GetBookingDocumentRequest) (*GetBookingDocumentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBookingDocument not implemented")
+}
+
+func (*UnimplementedBookingServiceServer) WatchSeatAvailability(*WatchSeatAvailabilityRequest, BookingService_WatchSeatAvailabilityServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSeatAvailability not implemented")
+}
+
+func (*UnimplementedBookingServiceServer) GetBookingCalendar(context.Context, *GetBookingCalendarRequest) (*GetBookingCalendarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBookingCalendar not implemented")
+}
+
+func (*UnimplementedBookingServiceServer) GetCalendarFeedToken(context.Context, *GetCalendarFeedTokenRequest) (*GetCalendarFeedTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCalendarFeedToken not implemented")
+}
+
+func (*UnimplementedBookingServiceServer) GetCalendarFeed(context.Context, *GetCalendarFeedRequest) (*GetCalendarFeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCalendarFeed not implemented")
+}
+
 func RegisterBookingServiceServer(s *grpc.Server, srv BookingServiceServer) {
 	s.RegisterService(&_BookingService_serviceDesc, srv)
 }
@@ -211,8 +481,38 @@ var _BookingService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdatePaymentStatus",
 			Handler:    _BookingService_UpdatePaymentStatus_Handler,
 		},
+		{
+			MethodName: "CheckIn",
+			Handler:    _BookingService_CheckIn_Handler,
+		},
+		{
+			MethodName: "GetBoardingManifest",
+			Handler:    _BookingService_GetBoardingManifest_Handler,
+		},
+		{
+			MethodName: "GetBookingDocument",
+			Handler:    _BookingService_GetBookingDocument_Handler,
+		},
+		{
+			MethodName: "GetBookingCalendar",
+			Handler:    _BookingService_GetBookingCalendar_Handler,
+		},
+		{
+			MethodName: "GetCalendarFeedToken",
+			Handler:    _BookingService_GetCalendarFeedToken_Handler,
+		},
+		{
+			MethodName: "GetCalendarFeed",
+			Handler:    _BookingService_GetCalendarFeed_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSeatAvailability",
+			Handler:       _BookingService_WatchSeatAvailability_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "booking.proto",
 }
 
@@ -305,3 +605,132 @@ func _BookingService_UpdatePaymentStatus_Handler(srv interface{}, ctx context.Co
 	}
 	return interceptor(ctx, in, info, handler)
 }
+
+func _BookingService_CheckIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).CheckIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/CheckIn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).CheckIn(ctx, req.(*CheckInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_GetBoardingManifest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBoardingManifestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetBoardingManifest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/GetBoardingManifest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetBoardingManifest(ctx, req.(*GetBoardingManifestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_GetBookingDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBookingDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetBookingDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/GetBookingDocument",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetBookingDocument(ctx, req.(*GetBookingDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_WatchSeatAvailability_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSeatAvailabilityRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BookingServiceServer).WatchSeatAvailability(m, &bookingServiceWatchSeatAvailabilityServer{stream})
+}
+
+type BookingService_WatchSeatAvailabilityServer interface {
+	Send(*SeatAvailabilityUpdate) error
+	grpc.ServerStream
+}
+
+type bookingServiceWatchSeatAvailabilityServer struct {
+	grpc.ServerStream
+}
+
+func (x *bookingServiceWatchSeatAvailabilityServer) Send(m *SeatAvailabilityUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BookingService_GetBookingCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBookingCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetBookingCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/GetBookingCalendar",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetBookingCalendar(ctx, req.(*GetBookingCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_GetCalendarFeedToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCalendarFeedTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetCalendarFeedToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/GetCalendarFeedToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetCalendarFeedToken(ctx, req.(*GetCalendarFeedTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_GetCalendarFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCalendarFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetCalendarFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/GetCalendarFeed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetCalendarFeed(ctx, req.(*GetCalendarFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
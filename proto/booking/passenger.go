@@ -0,0 +1,35 @@
+package booking
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// PassengerTypes are the kinds of passenger a booking or a saved traveller
+// can have. The first one is the default.
+var PassengerTypes = []string{"adult", "child", "infant", "senior"}
+
+// CleanPassenger trims the name and type of a passenger and checks them.
+// An empty type becomes the default one. Booking-service checks the
+// passengers of a booking with it, user-service the saved travellers.
+func CleanPassenger(fullName, passengerType string) (string, string, error) {
+	fullName = strings.TrimSpace(fullName)
+	if fullName == "" {
+		return "", "", errors.New("full name is required")
+	}
+	if utf8.RuneCountInString(fullName) > 100 {
+		return "", "", errors.New("full name must be at most 100 characters")
+	}
+
+	passengerType = strings.ToLower(strings.TrimSpace(passengerType))
+	if passengerType == "" {
+		passengerType = PassengerTypes[0]
+	}
+	for _, pt := range PassengerTypes {
+		if pt == passengerType {
+			return fullName, passengerType, nil
+		}
+	}
+	return "", "", errors.New("invalid passenger type, expected one of: " + strings.Join(PassengerTypes, ", "))
+}
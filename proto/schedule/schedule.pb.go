@@ -17,15 +17,24 @@ type Schedule struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id             int64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	TrainId        int64   `protobuf:"varint,2,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
-	TrainName      string  `protobuf:"bytes,3,opt,name=train_name,json=trainName,proto3" json:"train_name,omitempty"`
-	Origin         string  `protobuf:"bytes,4,opt,name=origin,proto3" json:"origin,omitempty"`
-	Destination    string  `protobuf:"bytes,5,opt,name=destination,proto3" json:"destination,omitempty"`
-	DepartureTime  string  `protobuf:"bytes,6,opt,name=departure_time,json=departureTime,proto3" json:"departure_time,omitempty"`
-	ArrivalTime    string  `protobuf:"bytes,7,opt,name=arrival_time,json=arrivalTime,proto3" json:"arrival_time,omitempty"`
-	Price          float64 `protobuf:"fixed64,8,opt,name=price,proto3" json:"price,omitempty"`
-	AvailableSeats int32   `protobuf:"varint,9,opt,name=available_seats,json=availableSeats,proto3" json:"available_seats,omitempty"`
+	Id                     int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TrainId                int64    `protobuf:"varint,2,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
+	TrainName              string   `protobuf:"bytes,3,opt,name=train_name,json=trainName,proto3" json:"train_name,omitempty"`
+	Origin                 string   `protobuf:"bytes,4,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination            string   `protobuf:"bytes,5,opt,name=destination,proto3" json:"destination,omitempty"`
+	DepartureTime          string   `protobuf:"bytes,6,opt,name=departure_time,json=departureTime,proto3" json:"departure_time,omitempty"`
+	ArrivalTime            string   `protobuf:"bytes,7,opt,name=arrival_time,json=arrivalTime,proto3" json:"arrival_time,omitempty"`
+	Price                  float64  `protobuf:"fixed64,8,opt,name=price,proto3" json:"price,omitempty"`
+	AvailableSeats         int32    `protobuf:"varint,9,opt,name=available_seats,json=availableSeats,proto3" json:"available_seats,omitempty"`
+	OriginTimezone         string   `protobuf:"bytes,10,opt,name=origin_timezone,json=originTimezone,proto3" json:"origin_timezone,omitempty"`
+	DestinationTimezone    string   `protobuf:"bytes,11,opt,name=destination_timezone,json=destinationTimezone,proto3" json:"destination_timezone,omitempty"`
+	DurationMinutes        int32    `protobuf:"varint,12,opt,name=duration_minutes,json=durationMinutes,proto3" json:"duration_minutes,omitempty"`
+	Status                 string   `protobuf:"bytes,13,opt,name=status,proto3" json:"status,omitempty"`
+	DelayMinutes           int32    `protobuf:"varint,14,opt,name=delay_minutes,json=delayMinutes,proto3" json:"delay_minutes,omitempty"`
+	Platform               string   `protobuf:"bytes,15,opt,name=platform,proto3" json:"platform,omitempty"`
+	EstimatedDepartureTime string   `protobuf:"bytes,16,opt,name=estimated_departure_time,json=estimatedDepartureTime,proto3" json:"estimated_departure_time,omitempty"`
+	EstimatedArrivalTime   string   `protobuf:"bytes,17,opt,name=estimated_arrival_time,json=estimatedArrivalTime,proto3" json:"estimated_arrival_time,omitempty"`
+	Amenities              []string `protobuf:"bytes,18,rep,name=amenities,proto3" json:"amenities,omitempty"`
 }
 
 func (x *Schedule) Reset() {
@@ -105,6 +114,69 @@ func (x *Schedule) GetAvailableSeats() int32 {
 	return 0
 }
 
+func (x *Schedule) GetOriginTimezone() string {
+	if x != nil {
+		return x.OriginTimezone
+	}
+	return ""
+}
+
+func (x *Schedule) GetDestinationTimezone() string {
+	if x != nil {
+		return x.DestinationTimezone
+	}
+	return ""
+}
+
+func (x *Schedule) GetDurationMinutes() int32 {
+	if x != nil {
+		return x.DurationMinutes
+	}
+	return 0
+}
+
+func (x *Schedule) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Schedule) GetDelayMinutes() int32 {
+	if x != nil {
+		return x.DelayMinutes
+	}
+	return 0
+}
+
+func (x *Schedule) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *Schedule) GetEstimatedDepartureTime() string {
+	if x != nil {
+		return x.EstimatedDepartureTime
+	}
+	return ""
+}
+
+func (x *Schedule) GetEstimatedArrivalTime() string {
+	if x != nil {
+		return x.EstimatedArrivalTime
+	}
+	return ""
+}
+
+func (x *Schedule) GetAmenities() []string {
+	if x != nil {
+		return x.Amenities
+	}
+	return nil
+}
+
 // GetScheduleRequest represents get schedule request
 type GetScheduleRequest struct {
 	state         protoimpl.MessageState
@@ -171,11 +243,20 @@ type ListSchedulesRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Origin        string `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"`
-	Destination   string `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
-	DepartureDate string `protobuf:"bytes,3,opt,name=departure_date,json=departureDate,proto3" json:"departure_date,omitempty"`
-	Page          int32  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	Origin             string   `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination        string   `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	DepartureDate      string   `protobuf:"bytes,3,opt,name=departure_date,json=departureDate,proto3" json:"departure_date,omitempty"`
+	Page               int32    `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	Limit              int32    `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	DepartureTimeFrom  string   `protobuf:"bytes,6,opt,name=departure_time_from,json=departureTimeFrom,proto3" json:"departure_time_from,omitempty"`
+	DepartureTimeTo    string   `protobuf:"bytes,7,opt,name=departure_time_to,json=departureTimeTo,proto3" json:"departure_time_to,omitempty"`
+	TrainType          string   `protobuf:"bytes,8,opt,name=train_type,json=trainType,proto3" json:"train_type,omitempty"`
+	MaxPrice           float64  `protobuf:"fixed64,9,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	MinAvailableSeats  int32    `protobuf:"varint,10,opt,name=min_available_seats,json=minAvailableSeats,proto3" json:"min_available_seats,omitempty"`
+	MaxDurationMinutes int32    `protobuf:"varint,11,opt,name=max_duration_minutes,json=maxDurationMinutes,proto3" json:"max_duration_minutes,omitempty"`
+	SortBy             string   `protobuf:"bytes,12,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	SortOrder          string   `protobuf:"bytes,13,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	Amenities          []string `protobuf:"bytes,14,rep,name=amenities,proto3" json:"amenities,omitempty"`
 }
 
 func (x *ListSchedulesRequest) Reset() {
@@ -227,6 +308,69 @@ func (x *ListSchedulesRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *ListSchedulesRequest) GetDepartureTimeFrom() string {
+	if x != nil {
+		return x.DepartureTimeFrom
+	}
+	return ""
+}
+
+func (x *ListSchedulesRequest) GetDepartureTimeTo() string {
+	if x != nil {
+		return x.DepartureTimeTo
+	}
+	return ""
+}
+
+func (x *ListSchedulesRequest) GetTrainType() string {
+	if x != nil {
+		return x.TrainType
+	}
+	return ""
+}
+
+func (x *ListSchedulesRequest) GetMaxPrice() float64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListSchedulesRequest) GetMinAvailableSeats() int32 {
+	if x != nil {
+		return x.MinAvailableSeats
+	}
+	return 0
+}
+
+func (x *ListSchedulesRequest) GetMaxDurationMinutes() int32 {
+	if x != nil {
+		return x.MaxDurationMinutes
+	}
+	return 0
+}
+
+func (x *ListSchedulesRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListSchedulesRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListSchedulesRequest) GetAmenities() []string {
+	if x != nil {
+		return x.Amenities
+	}
+	return nil
+}
+
 // ListSchedulesResponse represents list schedules response
 type ListSchedulesResponse struct {
 	state         protoimpl.MessageState
@@ -271,12 +415,14 @@ type CreateScheduleRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TrainId       int64   `protobuf:"varint,1,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
-	Origin        string  `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`
-	Destination   string  `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
-	DepartureTime string  `protobuf:"bytes,4,opt,name=departure_time,json=departureTime,proto3" json:"departure_time,omitempty"`
-	ArrivalTime   string  `protobuf:"bytes,5,opt,name=arrival_time,json=arrivalTime,proto3" json:"arrival_time,omitempty"`
-	Price         float64 `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`
+	TrainId             int64   `protobuf:"varint,1,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
+	Origin              string  `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination         string  `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	DepartureTime       string  `protobuf:"bytes,4,opt,name=departure_time,json=departureTime,proto3" json:"departure_time,omitempty"`
+	ArrivalTime         string  `protobuf:"bytes,5,opt,name=arrival_time,json=arrivalTime,proto3" json:"arrival_time,omitempty"`
+	Price               float64 `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`
+	OriginTimezone      string  `protobuf:"bytes,7,opt,name=origin_timezone,json=originTimezone,proto3" json:"origin_timezone,omitempty"`
+	DestinationTimezone string  `protobuf:"bytes,8,opt,name=destination_timezone,json=destinationTimezone,proto3" json:"destination_timezone,omitempty"`
 }
 
 func (x *CreateScheduleRequest) Reset() {
@@ -335,6 +481,20 @@ func (x *CreateScheduleRequest) GetPrice() float64 {
 	return 0
 }
 
+func (x *CreateScheduleRequest) GetOriginTimezone() string {
+	if x != nil {
+		return x.OriginTimezone
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetDestinationTimezone() string {
+	if x != nil {
+		return x.DestinationTimezone
+	}
+	return ""
+}
+
 // CreateScheduleResponse represents create schedule response
 type CreateScheduleResponse struct {
 	state         protoimpl.MessageState
@@ -365,130 +525,1333 @@ func (x *CreateScheduleResponse) GetSchedule() *Schedule {
 	return nil
 }
 
-// ScheduleServiceClient is the client API for ScheduleService service.
-type ScheduleServiceClient interface {
-	GetSchedule(ctx context.Context, in *GetScheduleRequest, opts ...grpc.CallOption) (*GetScheduleResponse, error)
-	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
-	CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*CreateScheduleResponse, error)
+// SearchCalendarRequest represents search calendar request
+type SearchCalendarRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Origin        string `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination   string `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	DepartureDate string `protobuf:"bytes,3,opt,name=departure_date,json=departureDate,proto3" json:"departure_date,omitempty"`
+	WindowDays    int32  `protobuf:"varint,4,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	Month         string `protobuf:"bytes,5,opt,name=month,proto3" json:"month,omitempty"`
 }
 
-type scheduleServiceClient struct {
-	cc grpc.ClientConnInterface
+func (x *SearchCalendarRequest) Reset() {
+	*x = SearchCalendarRequest{}
 }
 
-func NewScheduleServiceClient(cc grpc.ClientConnInterface) ScheduleServiceClient {
-	return &scheduleServiceClient{cc}
+func (x *SearchCalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (c *scheduleServiceClient) GetSchedule(ctx context.Context, in *GetScheduleRequest, opts ...grpc.CallOption) (*GetScheduleResponse, error) {
-	out := new(GetScheduleResponse)
-	err := c.cc.Invoke(ctx, "/schedule.ScheduleService/GetSchedule", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (*SearchCalendarRequest) ProtoMessage() {}
+
+func (x *SearchCalendarRequest) ProtoReflect() protoreflect.Message {
+	return nil
 }
 
-func (c *scheduleServiceClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error) {
-	out := new(ListSchedulesResponse)
-	err := c.cc.Invoke(ctx, "/schedule.ScheduleService/ListSchedules", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *SearchCalendarRequest) GetOrigin() string {
+	if x != nil {
+		return x.Origin
 	}
-	return out, nil
+	return ""
 }
 
-func (c *scheduleServiceClient) CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*CreateScheduleResponse, error) {
-	out := new(CreateScheduleResponse)
-	err := c.cc.Invoke(ctx, "/schedule.ScheduleService/CreateSchedule", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *SearchCalendarRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
 	}
-	return out, nil
+	return ""
 }
 
-// ScheduleServiceServer is the server API for ScheduleService service.
-type ScheduleServiceServer interface {
-	GetSchedule(context.Context, *GetScheduleRequest) (*GetScheduleResponse, error)
-	ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error)
-	CreateSchedule(context.Context, *CreateScheduleRequest) (*CreateScheduleResponse, error)
+func (x *SearchCalendarRequest) GetDepartureDate() string {
+	if x != nil {
+		return x.DepartureDate
+	}
+	return ""
 }
 
-// UnimplementedScheduleServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedScheduleServiceServer struct {
+func (x *SearchCalendarRequest) GetWindowDays() int32 {
+	if x != nil {
+		return x.WindowDays
+	}
+	return 0
 }
 
-func (*UnimplementedScheduleServiceServer) GetSchedule(context.Context, *GetScheduleRequest) (*GetScheduleResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetSchedule not implemented")
+func (x *SearchCalendarRequest) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
 }
 
-func (*UnimplementedScheduleServiceServer) ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
+// CalendarDay represents the cheapest fare and seat availability for one day
+type CalendarDay struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Date           string  `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	MinPrice       float64 `protobuf:"fixed64,2,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	AvailableSeats int32   `protobuf:"varint,3,opt,name=available_seats,json=availableSeats,proto3" json:"available_seats,omitempty"`
+	ScheduleCount  int32   `protobuf:"varint,4,opt,name=schedule_count,json=scheduleCount,proto3" json:"schedule_count,omitempty"`
 }
 
-func (*UnimplementedScheduleServiceServer) CreateSchedule(context.Context, *CreateScheduleRequest) (*CreateScheduleResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateSchedule not implemented")
+func (x *CalendarDay) Reset() {
+	*x = CalendarDay{}
 }
 
-func RegisterScheduleServiceServer(s *grpc.Server, srv ScheduleServiceServer) {
-	s.RegisterService(&_ScheduleService_serviceDesc, srv)
+func (x *CalendarDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var _ScheduleService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "schedule.ScheduleService",
-	HandlerType: (*ScheduleServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetSchedule",
-			Handler:    _ScheduleService_GetSchedule_Handler,
-		},
-		{
-			MethodName: "ListSchedules",
-			Handler:    _ScheduleService_ListSchedules_Handler,
-		},
-		{
-			MethodName: "CreateSchedule",
-			Handler:    _ScheduleService_CreateSchedule_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "schedule.proto",
+func (*CalendarDay) ProtoMessage() {}
+
+func (x *CalendarDay) ProtoReflect() protoreflect.Message {
+	return nil
 }
 
-func _ScheduleService_GetSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetScheduleRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ScheduleServiceServer).GetSchedule(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/schedule.ScheduleService/GetSchedule",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ScheduleServiceServer).GetSchedule(ctx, req.(*GetScheduleRequest))
+func (x *CalendarDay) GetDate() string {
+	if x != nil {
+		return x.Date
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _ScheduleService_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListSchedulesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (x *CalendarDay) GetMinPrice() float64 {
+	if x != nil {
+		return x.MinPrice
 	}
-	if interceptor == nil {
-		return srv.(ScheduleServiceServer).ListSchedules(ctx, in)
+	return 0
+}
+
+func (x *CalendarDay) GetAvailableSeats() int32 {
+	if x != nil {
+		return x.AvailableSeats
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/schedule.ScheduleService/ListSchedules",
+	return 0
+}
+
+func (x *CalendarDay) GetScheduleCount() int32 {
+	if x != nil {
+		return x.ScheduleCount
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ScheduleServiceServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	return 0
+}
+
+// SearchCalendarResponse represents search calendar response
+type SearchCalendarResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Days []*CalendarDay `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+}
+
+func (x *SearchCalendarResponse) Reset() {
+	*x = SearchCalendarResponse{}
+}
+
+func (x *SearchCalendarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCalendarResponse) ProtoMessage() {}
+
+func (x *SearchCalendarResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *SearchCalendarResponse) GetDays() []*CalendarDay {
+	if x != nil {
+		return x.Days
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
+}
+
+// UpdateScheduleStatusRequest represents an operator's live status update for a schedule
+type UpdateScheduleStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ScheduleId   int64  `protobuf:"varint,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	Status       string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	DelayMinutes int32  `protobuf:"varint,3,opt,name=delay_minutes,json=delayMinutes,proto3" json:"delay_minutes,omitempty"`
+	Platform     string `protobuf:"bytes,4,opt,name=platform,proto3" json:"platform,omitempty"`
+	Reason       string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	UpdatedBy    int64  `protobuf:"varint,6,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+}
+
+func (x *UpdateScheduleStatusRequest) Reset() {
+	*x = UpdateScheduleStatusRequest{}
+}
+
+func (x *UpdateScheduleStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScheduleStatusRequest) ProtoMessage() {}
+
+func (x *UpdateScheduleStatusRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *UpdateScheduleStatusRequest) GetScheduleId() int64 {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return 0
+}
+
+func (x *UpdateScheduleStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateScheduleStatusRequest) GetDelayMinutes() int32 {
+	if x != nil {
+		return x.DelayMinutes
+	}
+	return 0
+}
+
+func (x *UpdateScheduleStatusRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *UpdateScheduleStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *UpdateScheduleStatusRequest) GetUpdatedBy() int64 {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return 0
+}
+
+// UpdateScheduleStatusResponse represents update schedule status response
+type UpdateScheduleStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Schedule *Schedule `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+}
+
+func (x *UpdateScheduleStatusResponse) Reset() {
+	*x = UpdateScheduleStatusResponse{}
+}
+
+func (x *UpdateScheduleStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScheduleStatusResponse) ProtoMessage() {}
+
+func (x *UpdateScheduleStatusResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *UpdateScheduleStatusResponse) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+// WatchScheduleRequest represents watch schedule request
+type WatchScheduleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ScheduleId int64 `protobuf:"varint,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+}
+
+func (x *WatchScheduleRequest) Reset() {
+	*x = WatchScheduleRequest{}
+}
+
+func (x *WatchScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchScheduleRequest) ProtoMessage() {}
+
+func (x *WatchScheduleRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *WatchScheduleRequest) GetScheduleId() int64 {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return 0
+}
+
+// ScheduleStatusUpdate represents one live status change pushed to watchers
+type ScheduleStatusUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ScheduleId             int64  `protobuf:"varint,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	Status                 string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	DelayMinutes           int32  `protobuf:"varint,3,opt,name=delay_minutes,json=delayMinutes,proto3" json:"delay_minutes,omitempty"`
+	Platform               string `protobuf:"bytes,4,opt,name=platform,proto3" json:"platform,omitempty"`
+	Reason                 string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	EstimatedDepartureTime string `protobuf:"bytes,6,opt,name=estimated_departure_time,json=estimatedDepartureTime,proto3" json:"estimated_departure_time,omitempty"`
+	EstimatedArrivalTime   string `protobuf:"bytes,7,opt,name=estimated_arrival_time,json=estimatedArrivalTime,proto3" json:"estimated_arrival_time,omitempty"`
+	UpdatedAt              string `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *ScheduleStatusUpdate) Reset() {
+	*x = ScheduleStatusUpdate{}
+}
+
+func (x *ScheduleStatusUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleStatusUpdate) ProtoMessage() {}
+
+func (x *ScheduleStatusUpdate) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ScheduleStatusUpdate) GetScheduleId() int64 {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return 0
+}
+
+func (x *ScheduleStatusUpdate) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScheduleStatusUpdate) GetDelayMinutes() int32 {
+	if x != nil {
+		return x.DelayMinutes
+	}
+	return 0
+}
+
+func (x *ScheduleStatusUpdate) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *ScheduleStatusUpdate) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ScheduleStatusUpdate) GetEstimatedDepartureTime() string {
+	if x != nil {
+		return x.EstimatedDepartureTime
+	}
+	return ""
+}
+
+func (x *ScheduleStatusUpdate) GetEstimatedArrivalTime() string {
+	if x != nil {
+		return x.EstimatedArrivalTime
+	}
+	return ""
+}
+
+func (x *ScheduleStatusUpdate) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// ImportGTFSRequest represents a GTFS feed upload; with dry_run set only the diff is computed
+type ImportGTFSRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Archive         []byte  `protobuf:"bytes,1,opt,name=archive,proto3" json:"archive,omitempty"`
+	DryRun          bool    `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	TrainType       string  `protobuf:"bytes,3,opt,name=train_type,json=trainType,proto3" json:"train_type,omitempty"`
+	DefaultCapacity int32   `protobuf:"varint,4,opt,name=default_capacity,json=defaultCapacity,proto3" json:"default_capacity,omitempty"`
+	DefaultPrice    float64 `protobuf:"fixed64,5,opt,name=default_price,json=defaultPrice,proto3" json:"default_price,omitempty"`
+	FromDate        string  `protobuf:"bytes,6,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	ToDate          string  `protobuf:"bytes,7,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+}
+
+func (x *ImportGTFSRequest) Reset() {
+	*x = ImportGTFSRequest{}
+}
+
+func (x *ImportGTFSRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportGTFSRequest) ProtoMessage() {}
+
+func (x *ImportGTFSRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ImportGTFSRequest) GetArchive() []byte {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+func (x *ImportGTFSRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportGTFSRequest) GetTrainType() string {
+	if x != nil {
+		return x.TrainType
+	}
+	return ""
+}
+
+func (x *ImportGTFSRequest) GetDefaultCapacity() int32 {
+	if x != nil {
+		return x.DefaultCapacity
+	}
+	return 0
+}
+
+func (x *ImportGTFSRequest) GetDefaultPrice() float64 {
+	if x != nil {
+		return x.DefaultPrice
+	}
+	return 0
+}
+
+func (x *ImportGTFSRequest) GetFromDate() string {
+	if x != nil {
+		return x.FromDate
+	}
+	return ""
+}
+
+func (x *ImportGTFSRequest) GetToDate() string {
+	if x != nil {
+		return x.ToDate
+	}
+	return ""
+}
+
+// GTFSChange represents one station, train or schedule an import creates or updates
+type GTFSChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entity string `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	Action string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Ref    string `protobuf:"bytes,3,opt,name=ref,proto3" json:"ref,omitempty"`
+	Detail string `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (x *GTFSChange) Reset() {
+	*x = GTFSChange{}
+}
+
+func (x *GTFSChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GTFSChange) ProtoMessage() {}
+
+func (x *GTFSChange) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *GTFSChange) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *GTFSChange) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *GTFSChange) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *GTFSChange) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+// ImportGTFSResponse represents the outcome, or with dry_run the planned outcome, of an import
+type ImportGTFSResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DryRun             bool          `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	StationsCreated    int32         `protobuf:"varint,2,opt,name=stations_created,json=stationsCreated,proto3" json:"stations_created,omitempty"`
+	StationsUpdated    int32         `protobuf:"varint,3,opt,name=stations_updated,json=stationsUpdated,proto3" json:"stations_updated,omitempty"`
+	TrainsCreated      int32         `protobuf:"varint,4,opt,name=trains_created,json=trainsCreated,proto3" json:"trains_created,omitempty"`
+	SchedulesCreated   int32         `protobuf:"varint,5,opt,name=schedules_created,json=schedulesCreated,proto3" json:"schedules_created,omitempty"`
+	SchedulesUpdated   int32         `protobuf:"varint,6,opt,name=schedules_updated,json=schedulesUpdated,proto3" json:"schedules_updated,omitempty"`
+	SchedulesUnchanged int32         `protobuf:"varint,7,opt,name=schedules_unchanged,json=schedulesUnchanged,proto3" json:"schedules_unchanged,omitempty"`
+	Changes            []*GTFSChange `protobuf:"bytes,8,rep,name=changes,proto3" json:"changes,omitempty"`
+	Warnings           []string      `protobuf:"bytes,9,rep,name=warnings,proto3" json:"warnings,omitempty"`
+}
+
+func (x *ImportGTFSResponse) Reset() {
+	*x = ImportGTFSResponse{}
+}
+
+func (x *ImportGTFSResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportGTFSResponse) ProtoMessage() {}
+
+func (x *ImportGTFSResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ImportGTFSResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportGTFSResponse) GetStationsCreated() int32 {
+	if x != nil {
+		return x.StationsCreated
+	}
+	return 0
+}
+
+func (x *ImportGTFSResponse) GetStationsUpdated() int32 {
+	if x != nil {
+		return x.StationsUpdated
+	}
+	return 0
+}
+
+func (x *ImportGTFSResponse) GetTrainsCreated() int32 {
+	if x != nil {
+		return x.TrainsCreated
+	}
+	return 0
+}
+
+func (x *ImportGTFSResponse) GetSchedulesCreated() int32 {
+	if x != nil {
+		return x.SchedulesCreated
+	}
+	return 0
+}
+
+func (x *ImportGTFSResponse) GetSchedulesUpdated() int32 {
+	if x != nil {
+		return x.SchedulesUpdated
+	}
+	return 0
+}
+
+func (x *ImportGTFSResponse) GetSchedulesUnchanged() int32 {
+	if x != nil {
+		return x.SchedulesUnchanged
+	}
+	return 0
+}
+
+func (x *ImportGTFSResponse) GetChanges() []*GTFSChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ImportGTFSResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+// ExportGTFSRequest represents export gtfs request
+type ExportGTFSRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromDate string `protobuf:"bytes,1,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	ToDate   string `protobuf:"bytes,2,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+}
+
+func (x *ExportGTFSRequest) Reset() {
+	*x = ExportGTFSRequest{}
+}
+
+func (x *ExportGTFSRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportGTFSRequest) ProtoMessage() {}
+
+func (x *ExportGTFSRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ExportGTFSRequest) GetFromDate() string {
+	if x != nil {
+		return x.FromDate
+	}
+	return ""
+}
+
+func (x *ExportGTFSRequest) GetToDate() string {
+	if x != nil {
+		return x.ToDate
+	}
+	return ""
+}
+
+// ExportGTFSResponse represents export gtfs response
+type ExportGTFSResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FileName    string `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	ContentType string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Content     []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *ExportGTFSResponse) Reset() {
+	*x = ExportGTFSResponse{}
+}
+
+func (x *ExportGTFSResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportGTFSResponse) ProtoMessage() {}
+
+func (x *ExportGTFSResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ExportGTFSResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ExportGTFSResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportGTFSResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// UpdateScheduleRequest represents update schedule request. The train is
+// kept; empty timezones keep the ones already stored.
+type UpdateScheduleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ScheduleId          int64   `protobuf:"varint,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	Origin              string  `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination         string  `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	DepartureTime       string  `protobuf:"bytes,4,opt,name=departure_time,json=departureTime,proto3" json:"departure_time,omitempty"`
+	ArrivalTime         string  `protobuf:"bytes,5,opt,name=arrival_time,json=arrivalTime,proto3" json:"arrival_time,omitempty"`
+	Price               float64 `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`
+	OriginTimezone      string  `protobuf:"bytes,7,opt,name=origin_timezone,json=originTimezone,proto3" json:"origin_timezone,omitempty"`
+	DestinationTimezone string  `protobuf:"bytes,8,opt,name=destination_timezone,json=destinationTimezone,proto3" json:"destination_timezone,omitempty"`
+}
+
+func (x *UpdateScheduleRequest) Reset() {
+	*x = UpdateScheduleRequest{}
+}
+
+func (x *UpdateScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScheduleRequest) ProtoMessage() {}
+
+func (x *UpdateScheduleRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *UpdateScheduleRequest) GetScheduleId() int64 {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return 0
+}
+
+func (x *UpdateScheduleRequest) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *UpdateScheduleRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *UpdateScheduleRequest) GetDepartureTime() string {
+	if x != nil {
+		return x.DepartureTime
+	}
+	return ""
+}
+
+func (x *UpdateScheduleRequest) GetArrivalTime() string {
+	if x != nil {
+		return x.ArrivalTime
+	}
+	return ""
+}
+
+func (x *UpdateScheduleRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *UpdateScheduleRequest) GetOriginTimezone() string {
+	if x != nil {
+		return x.OriginTimezone
+	}
+	return ""
+}
+
+func (x *UpdateScheduleRequest) GetDestinationTimezone() string {
+	if x != nil {
+		return x.DestinationTimezone
+	}
+	return ""
+}
+
+// UpdateScheduleResponse represents update schedule response
+type UpdateScheduleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Schedule *Schedule `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+}
+
+func (x *UpdateScheduleResponse) Reset() {
+	*x = UpdateScheduleResponse{}
+}
+
+func (x *UpdateScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScheduleResponse) ProtoMessage() {}
+
+func (x *UpdateScheduleResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *UpdateScheduleResponse) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+// ReassignTrainRequest represents a request to put another train on a schedule; with dry_run set only the outcome is computed
+type ReassignTrainRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ScheduleId int64 `protobuf:"varint,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	TrainId    int64 `protobuf:"varint,2,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
+	DryRun     bool  `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+}
+
+func (x *ReassignTrainRequest) Reset() {
+	*x = ReassignTrainRequest{}
+}
+
+func (x *ReassignTrainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignTrainRequest) ProtoMessage() {}
+
+func (x *ReassignTrainRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ReassignTrainRequest) GetScheduleId() int64 {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return 0
+}
+
+func (x *ReassignTrainRequest) GetTrainId() int64 {
+	if x != nil {
+		return x.TrainId
+	}
+	return 0
+}
+
+func (x *ReassignTrainRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// SeatMove represents one booked seat and where it lands on the new train
+type SeatMove struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BookingId   int64  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	BookingCode string `protobuf:"bytes,2,opt,name=booking_code,json=bookingCode,proto3" json:"booking_code,omitempty"`
+	Class       string `protobuf:"bytes,3,opt,name=class,proto3" json:"class,omitempty"`
+	FromCoach   int32  `protobuf:"varint,4,opt,name=from_coach,json=fromCoach,proto3" json:"from_coach,omitempty"`
+	FromSeat    string `protobuf:"bytes,5,opt,name=from_seat,json=fromSeat,proto3" json:"from_seat,omitempty"`
+	ToCoach     int32  `protobuf:"varint,6,opt,name=to_coach,json=toCoach,proto3" json:"to_coach,omitempty"`
+	ToSeat      string `protobuf:"bytes,7,opt,name=to_seat,json=toSeat,proto3" json:"to_seat,omitempty"`
+}
+
+func (x *SeatMove) Reset() {
+	*x = SeatMove{}
+}
+
+func (x *SeatMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatMove) ProtoMessage() {}
+
+func (x *SeatMove) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *SeatMove) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *SeatMove) GetBookingCode() string {
+	if x != nil {
+		return x.BookingCode
+	}
+	return ""
+}
+
+func (x *SeatMove) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *SeatMove) GetFromCoach() int32 {
+	if x != nil {
+		return x.FromCoach
+	}
+	return 0
+}
+
+func (x *SeatMove) GetFromSeat() string {
+	if x != nil {
+		return x.FromSeat
+	}
+	return ""
+}
+
+func (x *SeatMove) GetToCoach() int32 {
+	if x != nil {
+		return x.ToCoach
+	}
+	return 0
+}
+
+func (x *SeatMove) GetToSeat() string {
+	if x != nil {
+		return x.ToSeat
+	}
+	return ""
+}
+
+// UnseatedBooking represents a booking that keeps its ticket but lost its seat assignment
+type UnseatedBooking struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BookingId   int64  `protobuf:"varint,1,opt,name=booking_id,json=bookingId,proto3" json:"booking_id,omitempty"`
+	BookingCode string `protobuf:"bytes,2,opt,name=booking_code,json=bookingCode,proto3" json:"booking_code,omitempty"`
+	Class       string `protobuf:"bytes,3,opt,name=class,proto3" json:"class,omitempty"`
+	SeatCount   int32  `protobuf:"varint,4,opt,name=seat_count,json=seatCount,proto3" json:"seat_count,omitempty"`
+	Reason      string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *UnseatedBooking) Reset() {
+	*x = UnseatedBooking{}
+}
+
+func (x *UnseatedBooking) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnseatedBooking) ProtoMessage() {}
+
+func (x *UnseatedBooking) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *UnseatedBooking) GetBookingId() int64 {
+	if x != nil {
+		return x.BookingId
+	}
+	return 0
+}
+
+func (x *UnseatedBooking) GetBookingCode() string {
+	if x != nil {
+		return x.BookingCode
+	}
+	return ""
+}
+
+func (x *UnseatedBooking) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *UnseatedBooking) GetSeatCount() int32 {
+	if x != nil {
+		return x.SeatCount
+	}
+	return 0
+}
+
+func (x *UnseatedBooking) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// ReassignTrainResponse represents the outcome, or with dry_run the planned outcome, of a reassignment
+type ReassignTrainResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Schedule  *Schedule          `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	DryRun    bool               `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	SoldSeats int32              `protobuf:"varint,3,opt,name=sold_seats,json=soldSeats,proto3" json:"sold_seats,omitempty"`
+	Capacity  int32              `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Moves     []*SeatMove        `protobuf:"bytes,5,rep,name=moves,proto3" json:"moves,omitempty"`
+	Unseated  []*UnseatedBooking `protobuf:"bytes,6,rep,name=unseated,proto3" json:"unseated,omitempty"`
+}
+
+func (x *ReassignTrainResponse) Reset() {
+	*x = ReassignTrainResponse{}
+}
+
+func (x *ReassignTrainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignTrainResponse) ProtoMessage() {}
+
+func (x *ReassignTrainResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ReassignTrainResponse) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *ReassignTrainResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ReassignTrainResponse) GetSoldSeats() int32 {
+	if x != nil {
+		return x.SoldSeats
+	}
+	return 0
+}
+
+func (x *ReassignTrainResponse) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *ReassignTrainResponse) GetMoves() []*SeatMove {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+func (x *ReassignTrainResponse) GetUnseated() []*UnseatedBooking {
+	if x != nil {
+		return x.Unseated
+	}
+	return nil
+}
+
+// ScheduleServiceClient is the client API for ScheduleService service.
+type ScheduleServiceClient interface {
+	GetSchedule(ctx context.Context, in *GetScheduleRequest, opts ...grpc.CallOption) (*GetScheduleResponse, error)
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
+	CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*CreateScheduleResponse, error)
+	SearchCalendar(ctx context.Context, in *SearchCalendarRequest, opts ...grpc.CallOption) (*SearchCalendarResponse, error)
+	UpdateScheduleStatus(ctx context.Context, in *UpdateScheduleStatusRequest, opts ...grpc.CallOption) (*UpdateScheduleStatusResponse, error)
+	WatchSchedule(ctx context.Context, in *WatchScheduleRequest, opts ...grpc.CallOption) (ScheduleService_WatchScheduleClient, error)
+	ImportGTFS(ctx context.Context, in *ImportGTFSRequest, opts ...grpc.CallOption) (*ImportGTFSResponse, error)
+	ExportGTFS(ctx context.Context, in *ExportGTFSRequest, opts ...grpc.CallOption) (*ExportGTFSResponse, error)
+	UpdateSchedule(ctx context.Context, in *UpdateScheduleRequest, opts ...grpc.CallOption) (*UpdateScheduleResponse, error)
+	ReassignTrain(ctx context.Context, in *ReassignTrainRequest, opts ...grpc.CallOption) (*ReassignTrainResponse, error)
+}
+
+type scheduleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScheduleServiceClient(cc grpc.ClientConnInterface) ScheduleServiceClient {
+	return &scheduleServiceClient{cc}
+}
+
+func (c *scheduleServiceClient) GetSchedule(ctx context.Context, in *GetScheduleRequest, opts ...grpc.CallOption) (*GetScheduleResponse, error) {
+	out := new(GetScheduleResponse)
+	err := c.cc.Invoke(ctx, "/schedule.ScheduleService/GetSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error) {
+	out := new(ListSchedulesResponse)
+	err := c.cc.Invoke(ctx, "/schedule.ScheduleService/ListSchedules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*CreateScheduleResponse, error) {
+	out := new(CreateScheduleResponse)
+	err := c.cc.Invoke(ctx, "/schedule.ScheduleService/CreateSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) SearchCalendar(ctx context.Context, in *SearchCalendarRequest, opts ...grpc.CallOption) (*SearchCalendarResponse, error) {
+	out := new(SearchCalendarResponse)
+	err := c.cc.Invoke(ctx, "/schedule.ScheduleService/SearchCalendar", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) UpdateScheduleStatus(ctx context.Context, in *UpdateScheduleStatusRequest, opts ...grpc.CallOption) (*UpdateScheduleStatusResponse, error) {
+	out := new(UpdateScheduleStatusResponse)
+	err := c.cc.Invoke(ctx, "/schedule.ScheduleService/UpdateScheduleStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) WatchSchedule(ctx context.Context, in *WatchScheduleRequest, opts ...grpc.CallOption) (ScheduleService_WatchScheduleClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ScheduleService_serviceDesc.Streams[0], "/schedule.ScheduleService/WatchSchedule", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &scheduleServiceWatchScheduleClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ScheduleService_WatchScheduleClient interface {
+	Recv() (*ScheduleStatusUpdate, error)
+	grpc.ClientStream
+}
+
+type scheduleServiceWatchScheduleClient struct {
+	grpc.ClientStream
+}
+
+func (x *scheduleServiceWatchScheduleClient) Recv() (*ScheduleStatusUpdate, error) {
+	m := new(ScheduleStatusUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *scheduleServiceClient) ImportGTFS(ctx context.Context, in *ImportGTFSRequest, opts ...grpc.CallOption) (*ImportGTFSResponse, error) {
+	out := new(ImportGTFSResponse)
+	err := c.cc.Invoke(ctx, "/schedule.ScheduleService/ImportGTFS", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ExportGTFS(ctx context.Context, in *ExportGTFSRequest, opts ...grpc.CallOption) (*ExportGTFSResponse, error) {
+	out := new(ExportGTFSResponse)
+	err := c.cc.Invoke(ctx, "/schedule.ScheduleService/ExportGTFS", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) UpdateSchedule(ctx context.Context, in *UpdateScheduleRequest, opts ...grpc.CallOption) (*UpdateScheduleResponse, error) {
+	out := new(UpdateScheduleResponse)
+	err := c.cc.Invoke(ctx, "/schedule.ScheduleService/UpdateSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduleServiceClient) ReassignTrain(ctx context.Context, in *ReassignTrainRequest, opts ...grpc.CallOption) (*ReassignTrainResponse, error) {
+	out := new(ReassignTrainResponse)
+	err := c.cc.Invoke(ctx, "/schedule.ScheduleService/ReassignTrain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScheduleServiceServer is the server API for ScheduleService service.
+type ScheduleServiceServer interface {
+	GetSchedule(context.Context, *GetScheduleRequest) (*GetScheduleResponse, error)
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error)
+	CreateSchedule(context.Context, *CreateScheduleRequest) (*CreateScheduleResponse, error)
+	SearchCalendar(context.Context, *SearchCalendarRequest) (*SearchCalendarResponse, error)
+	UpdateScheduleStatus(context.Context, *UpdateScheduleStatusRequest) (*UpdateScheduleStatusResponse, error)
+	WatchSchedule(*WatchScheduleRequest, ScheduleService_WatchScheduleServer) error
+	ImportGTFS(context.Context, *ImportGTFSRequest) (*ImportGTFSResponse, error)
+	ExportGTFS(context.Context, *ExportGTFSRequest) (*ExportGTFSResponse, error)
+	UpdateSchedule(context.Context, *UpdateScheduleRequest) (*UpdateScheduleResponse, error)
+	ReassignTrain(context.Context, *ReassignTrainRequest) (*ReassignTrainResponse, error)
+}
+
+// UnimplementedScheduleServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedScheduleServiceServer struct {
+}
+
+func (*UnimplementedScheduleServiceServer) GetSchedule(context.Context, *GetScheduleRequest) (*GetScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchedule not implemented")
+}
+
+func (*UnimplementedScheduleServiceServer) ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
+}
+
+func (*UnimplementedScheduleServiceServer) CreateSchedule(context.Context, *CreateScheduleRequest) (*CreateScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSchedule not implemented")
+}
+
+func (*UnimplementedScheduleServiceServer) SearchCalendar(context.Context, *SearchCalendarRequest) (*SearchCalendarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchCalendar not implemented")
+}
+
+func (*UnimplementedScheduleServiceServer) UpdateScheduleStatus(context.Context, *UpdateScheduleStatusRequest) (*UpdateScheduleStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateScheduleStatus not implemented")
+}
+
+func (*UnimplementedScheduleServiceServer) WatchSchedule(*WatchScheduleRequest, ScheduleService_WatchScheduleServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSchedule not implemented")
+}
+
+func (*UnimplementedScheduleServiceServer) ImportGTFS(context.Context, *ImportGTFSRequest) (*ImportGTFSResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportGTFS not implemented")
+}
+
+func (*UnimplementedScheduleServiceServer) ExportGTFS(context.Context, *ExportGTFSRequest) (*ExportGTFSResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportGTFS not implemented")
+}
+
+func (*UnimplementedScheduleServiceServer) UpdateSchedule(context.Context, *UpdateScheduleRequest) (*UpdateScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSchedule not implemented")
+}
+
+func (*UnimplementedScheduleServiceServer) ReassignTrain(context.Context, *ReassignTrainRequest) (*ReassignTrainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReassignTrain not implemented")
+}
+
+func RegisterScheduleServiceServer(s *grpc.Server, srv ScheduleServiceServer) {
+	s.RegisterService(&_ScheduleService_serviceDesc, srv)
+}
+
+var _ScheduleService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "schedule.ScheduleService",
+	HandlerType: (*ScheduleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSchedule",
+			Handler:    _ScheduleService_GetSchedule_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _ScheduleService_ListSchedules_Handler,
+		},
+		{
+			MethodName: "CreateSchedule",
+			Handler:    _ScheduleService_CreateSchedule_Handler,
+		},
+		{
+			MethodName: "SearchCalendar",
+			Handler:    _ScheduleService_SearchCalendar_Handler,
+		},
+		{
+			MethodName: "UpdateScheduleStatus",
+			Handler:    _ScheduleService_UpdateScheduleStatus_Handler,
+		},
+		{
+			MethodName: "ImportGTFS",
+			Handler:    _ScheduleService_ImportGTFS_Handler,
+		},
+		{
+			MethodName: "ExportGTFS",
+			Handler:    _ScheduleService_ExportGTFS_Handler,
+		},
+		{
+			MethodName: "UpdateSchedule",
+			Handler:    _ScheduleService_UpdateSchedule_Handler,
+		},
+		{
+			MethodName: "ReassignTrain",
+			Handler:    _ScheduleService_ReassignTrain_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSchedule",
+			Handler:       _ScheduleService_WatchSchedule_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "schedule.proto",
+}
+
+func _ScheduleService_GetSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).GetSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedule.ScheduleService/GetSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).GetSchedule(ctx, req.(*GetScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedule.ScheduleService/ListSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
 func _ScheduleService_CreateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
@@ -508,3 +1871,132 @@ func _ScheduleService_CreateSchedule_Handler(srv interface{}, ctx context.Contex
 	}
 	return interceptor(ctx, in, info, handler)
 }
+
+func _ScheduleService_SearchCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchCalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).SearchCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedule.ScheduleService/SearchCalendar",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).SearchCalendar(ctx, req.(*SearchCalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_UpdateScheduleStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateScheduleStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).UpdateScheduleStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedule.ScheduleService/UpdateScheduleStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).UpdateScheduleStatus(ctx, req.(*UpdateScheduleStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_WatchSchedule_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchScheduleRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScheduleServiceServer).WatchSchedule(m, &scheduleServiceWatchScheduleServer{stream})
+}
+
+type ScheduleService_WatchScheduleServer interface {
+	Send(*ScheduleStatusUpdate) error
+	grpc.ServerStream
+}
+
+type scheduleServiceWatchScheduleServer struct {
+	grpc.ServerStream
+}
+
+func (x *scheduleServiceWatchScheduleServer) Send(m *ScheduleStatusUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ScheduleService_ImportGTFS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportGTFSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ImportGTFS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedule.ScheduleService/ImportGTFS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ImportGTFS(ctx, req.(*ImportGTFSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ExportGTFS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportGTFSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ExportGTFS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedule.ScheduleService/ExportGTFS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ExportGTFS(ctx, req.(*ExportGTFSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_UpdateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).UpdateSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedule.ScheduleService/UpdateSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).UpdateSchedule(ctx, req.(*UpdateScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduleService_ReassignTrain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReassignTrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduleServiceServer).ReassignTrain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedule.ScheduleService/ReassignTrain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduleServiceServer).ReassignTrain(ctx, req.(*ReassignTrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
@@ -0,0 +1,11 @@
+package train
+
+// AmenitiesSQL is the SQL expression for every amenity a train offers: its
+// own plus those of its coaches, so a dining car or an accessible coach shows
+// up on the train. It reads the train from the alias t; train-service lists
+// trains with it and schedule-service lists schedules with it.
+const AmenitiesSQL = `ARRAY(
+		SELECT unnest(t.amenities)
+		UNION
+		SELECT unnest(c.amenities) FROM coaches c WHERE c.train_id = t.id AND c.deleted_at IS NULL
+		ORDER BY 1)`
@@ -17,11 +17,12 @@ type Train struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id       int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Type     string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
-	Capacity int32  `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
-	Status   string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Id        int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type      string   `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Capacity  int32    `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Status    string   `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Amenities []string `protobuf:"bytes,6,rep,name=amenities,proto3" json:"amenities,omitempty"`
 }
 
 func (x *Train) Reset() {
@@ -73,6 +74,13 @@ func (x *Train) GetStatus() string {
 	return ""
 }
 
+func (x *Train) GetAmenities() []string {
+	if x != nil {
+		return x.Amenities
+	}
+	return nil
+}
+
 // GetTrainRequest represents get train request
 type GetTrainRequest struct {
 	state         protoimpl.MessageState
@@ -139,8 +147,12 @@ type ListTrainsRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Page  int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Page      int32    `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit     int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Query     string   `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	Type      string   `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Status    string   `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Amenities []string `protobuf:"bytes,6,rep,name=amenities,proto3" json:"amenities,omitempty"`
 }
 
 func (x *ListTrainsRequest) Reset() {
@@ -171,6 +183,34 @@ func (x *ListTrainsRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *ListTrainsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListTrainsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListTrainsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListTrainsRequest) GetAmenities() []string {
+	if x != nil {
+		return x.Amenities
+	}
+	return nil
+}
+
 // ListTrainsResponse represents list trains response
 type ListTrainsResponse struct {
 	state         protoimpl.MessageState
@@ -209,15 +249,18 @@ func (x *ListTrainsResponse) GetTotal() int32 {
 	return 0
 }
 
-// CreateTrainRequest represents create train request
+// CreateTrainRequest represents create train request. When coaches are
+// given the capacity is derived from them and Capacity is ignored.
 type CreateTrainRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Type     string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Capacity int32  `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Name      string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type      string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Capacity  int32    `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Coaches   []*Coach `protobuf:"bytes,4,rep,name=coaches,proto3" json:"coaches,omitempty"`
+	Amenities []string `protobuf:"bytes,5,rep,name=amenities,proto3" json:"amenities,omitempty"`
 }
 
 func (x *CreateTrainRequest) Reset() {
@@ -255,6 +298,20 @@ func (x *CreateTrainRequest) GetCapacity() int32 {
 	return 0
 }
 
+func (x *CreateTrainRequest) GetCoaches() []*Coach {
+	if x != nil {
+		return x.Coaches
+	}
+	return nil
+}
+
+func (x *CreateTrainRequest) GetAmenities() []string {
+	if x != nil {
+		return x.Amenities
+	}
+	return nil
+}
+
 // CreateTrainResponse represents create train response
 type CreateTrainResponse struct {
 	state         protoimpl.MessageState
@@ -285,7 +342,9 @@ func (x *CreateTrainResponse) GetTrain() *Train {
 	return nil
 }
 
-// UpdateTrainRequest represents update train request
+// UpdateTrainRequest represents update train request. A zero capacity keeps
+// the current one; a train with coaches takes its capacity from them and
+// rejects a non-zero one.
 type UpdateTrainRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -437,212 +496,1756 @@ func (x *DeleteTrainResponse) GetSuccess() bool {
 	return false
 }
 
-// TrainServiceClient is the client API for TrainService service.
-type TrainServiceClient interface {
-	GetTrain(ctx context.Context, in *GetTrainRequest, opts ...grpc.CallOption) (*GetTrainResponse, error)
-	ListTrains(ctx context.Context, in *ListTrainsRequest, opts ...grpc.CallOption) (*ListTrainsResponse, error)
-	CreateTrain(ctx context.Context, in *CreateTrainRequest, opts ...grpc.CallOption) (*CreateTrainResponse, error)
-	UpdateTrain(ctx context.Context, in *UpdateTrainRequest, opts ...grpc.CallOption) (*UpdateTrainResponse, error)
-	DeleteTrain(ctx context.Context, in *DeleteTrainRequest, opts ...grpc.CallOption) (*DeleteTrainResponse, error)
+// Coach is one carriage of a train and the grid its seats sit on
+type Coach struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TrainId         int64    `protobuf:"varint,2,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
+	CoachNumber     int32    `protobuf:"varint,3,opt,name=coach_number,json=coachNumber,proto3" json:"coach_number,omitempty"`
+	Class           string   `protobuf:"bytes,4,opt,name=class,proto3" json:"class,omitempty"`
+	Rows            int32    `protobuf:"varint,5,opt,name=rows,proto3" json:"rows,omitempty"`
+	Columns         int32    `protobuf:"varint,6,opt,name=columns,proto3" json:"columns,omitempty"`
+	AisleAfter      int32    `protobuf:"varint,7,opt,name=aisle_after,json=aisleAfter,proto3" json:"aisle_after,omitempty"`
+	BlockedSeats    []string `protobuf:"bytes,8,rep,name=blocked_seats,json=blockedSeats,proto3" json:"blocked_seats,omitempty"`
+	AccessibleSeats []string `protobuf:"bytes,9,rep,name=accessible_seats,json=accessibleSeats,proto3" json:"accessible_seats,omitempty"`
+	SeatCount       int32    `protobuf:"varint,10,opt,name=seat_count,json=seatCount,proto3" json:"seat_count,omitempty"`
+	Amenities       []string `protobuf:"bytes,11,rep,name=amenities,proto3" json:"amenities,omitempty"`
 }
 
-type trainServiceClient struct {
-	cc grpc.ClientConnInterface
+func (x *Coach) Reset() {
+	*x = Coach{}
 }
 
-func NewTrainServiceClient(cc grpc.ClientConnInterface) TrainServiceClient {
-	return &trainServiceClient{cc}
+func (x *Coach) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (c *trainServiceClient) GetTrain(ctx context.Context, in *GetTrainRequest, opts ...grpc.CallOption) (*GetTrainResponse, error) {
-	out := new(GetTrainResponse)
-	err := c.cc.Invoke(ctx, "/train.TrainService/GetTrain", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (*Coach) ProtoMessage() {}
+
+func (x *Coach) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *Coach) GetId() int64 {
+	if x != nil {
+		return x.Id
 	}
-	return out, nil
+	return 0
 }
 
-func (c *trainServiceClient) ListTrains(ctx context.Context, in *ListTrainsRequest, opts ...grpc.CallOption) (*ListTrainsResponse, error) {
-	out := new(ListTrainsResponse)
-	err := c.cc.Invoke(ctx, "/train.TrainService/ListTrains", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *Coach) GetTrainId() int64 {
+	if x != nil {
+		return x.TrainId
 	}
-	return out, nil
+	return 0
 }
 
-func (c *trainServiceClient) CreateTrain(ctx context.Context, in *CreateTrainRequest, opts ...grpc.CallOption) (*CreateTrainResponse, error) {
-	out := new(CreateTrainResponse)
-	err := c.cc.Invoke(ctx, "/train.TrainService/CreateTrain", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *Coach) GetCoachNumber() int32 {
+	if x != nil {
+		return x.CoachNumber
 	}
-	return out, nil
+	return 0
 }
 
-func (c *trainServiceClient) UpdateTrain(ctx context.Context, in *UpdateTrainRequest, opts ...grpc.CallOption) (*UpdateTrainResponse, error) {
-	out := new(UpdateTrainResponse)
-	err := c.cc.Invoke(ctx, "/train.TrainService/UpdateTrain", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *Coach) GetClass() string {
+	if x != nil {
+		return x.Class
 	}
-	return out, nil
+	return ""
 }
 
-func (c *trainServiceClient) DeleteTrain(ctx context.Context, in *DeleteTrainRequest, opts ...grpc.CallOption) (*DeleteTrainResponse, error) {
-	out := new(DeleteTrainResponse)
-	err := c.cc.Invoke(ctx, "/train.TrainService/DeleteTrain", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *Coach) GetRows() int32 {
+	if x != nil {
+		return x.Rows
 	}
-	return out, nil
+	return 0
 }
 
-// TrainServiceServer is the server API for TrainService service.
-type TrainServiceServer interface {
-	GetTrain(context.Context, *GetTrainRequest) (*GetTrainResponse, error)
-	ListTrains(context.Context, *ListTrainsRequest) (*ListTrainsResponse, error)
-	CreateTrain(context.Context, *CreateTrainRequest) (*CreateTrainResponse, error)
-	UpdateTrain(context.Context, *UpdateTrainRequest) (*UpdateTrainResponse, error)
-	DeleteTrain(context.Context, *DeleteTrainRequest) (*DeleteTrainResponse, error)
+func (x *Coach) GetColumns() int32 {
+	if x != nil {
+		return x.Columns
+	}
+	return 0
 }
 
-// UnimplementedTrainServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedTrainServiceServer struct {
+func (x *Coach) GetAisleAfter() int32 {
+	if x != nil {
+		return x.AisleAfter
+	}
+	return 0
 }
 
-func (*UnimplementedTrainServiceServer) GetTrain(context.Context, *GetTrainRequest) (*GetTrainResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTrain not implemented")
+func (x *Coach) GetBlockedSeats() []string {
+	if x != nil {
+		return x.BlockedSeats
+	}
+	return nil
 }
 
-func (*UnimplementedTrainServiceServer) ListTrains(context.Context, *ListTrainsRequest) (*ListTrainsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListTrains not implemented")
+func (x *Coach) GetAccessibleSeats() []string {
+	if x != nil {
+		return x.AccessibleSeats
+	}
+	return nil
 }
 
-func (*UnimplementedTrainServiceServer) CreateTrain(context.Context, *CreateTrainRequest) (*CreateTrainResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateTrain not implemented")
+func (x *Coach) GetSeatCount() int32 {
+	if x != nil {
+		return x.SeatCount
+	}
+	return 0
 }
 
-func (*UnimplementedTrainServiceServer) UpdateTrain(context.Context, *UpdateTrainRequest) (*UpdateTrainResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateTrain not implemented")
+func (x *Coach) GetAmenities() []string {
+	if x != nil {
+		return x.Amenities
+	}
+	return nil
 }
 
-func (*UnimplementedTrainServiceServer) DeleteTrain(context.Context, *DeleteTrainRequest) (*DeleteTrainResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteTrain not implemented")
+// Seat is one bookable seat generated from a coach layout
+type Seat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number     string `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	Row        int32  `protobuf:"varint,2,opt,name=row,proto3" json:"row,omitempty"`
+	Column     int32  `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"`
+	Window     bool   `protobuf:"varint,4,opt,name=window,proto3" json:"window,omitempty"`
+	Aisle      bool   `protobuf:"varint,5,opt,name=aisle,proto3" json:"aisle,omitempty"`
+	Accessible bool   `protobuf:"varint,6,opt,name=accessible,proto3" json:"accessible,omitempty"`
 }
 
-func RegisterTrainServiceServer(s *grpc.Server, srv TrainServiceServer) {
-	s.RegisterService(&_TrainService_serviceDesc, srv)
+func (x *Seat) Reset() {
+	*x = Seat{}
 }
 
-var _TrainService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "train.TrainService",
-	HandlerType: (*TrainServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetTrain",
-			Handler:    _TrainService_GetTrain_Handler,
-		},
-		{
-			MethodName: "ListTrains",
-			Handler:    _TrainService_ListTrains_Handler,
-		},
-		{
-			MethodName: "CreateTrain",
-			Handler:    _TrainService_CreateTrain_Handler,
-		},
-		{
-			MethodName: "UpdateTrain",
-			Handler:    _TrainService_UpdateTrain_Handler,
-		},
-		{
-			MethodName: "DeleteTrain",
-			Handler:    _TrainService_DeleteTrain_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "train.proto",
+func (x *Seat) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func _TrainService_GetTrain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTrainRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(TrainServiceServer).GetTrain(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/train.TrainService/GetTrain",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TrainServiceServer).GetTrain(ctx, req.(*GetTrainRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func (*Seat) ProtoMessage() {}
+
+func (x *Seat) ProtoReflect() protoreflect.Message {
+	return nil
 }
 
-func _TrainService_ListTrains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListTrainsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(TrainServiceServer).ListTrains(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/train.TrainService/ListTrains",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TrainServiceServer).ListTrains(ctx, req.(*ListTrainsRequest))
+func (x *Seat) GetNumber() string {
+	if x != nil {
+		return x.Number
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _TrainService_CreateTrain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateTrainRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(TrainServiceServer).CreateTrain(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/train.TrainService/CreateTrain",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TrainServiceServer).CreateTrain(ctx, req.(*CreateTrainRequest))
+func (x *Seat) GetRow() int32 {
+	if x != nil {
+		return x.Row
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _TrainService_UpdateTrain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateTrainRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(TrainServiceServer).UpdateTrain(ctx, in)
+func (x *Seat) GetColumn() int32 {
+	if x != nil {
+		return x.Column
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/train.TrainService/UpdateTrain",
+	return 0
+}
+
+func (x *Seat) GetWindow() bool {
+	if x != nil {
+		return x.Window
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TrainServiceServer).UpdateTrain(ctx, req.(*UpdateTrainRequest))
+	return false
+}
+
+func (x *Seat) GetAisle() bool {
+	if x != nil {
+		return x.Aisle
 	}
-	return interceptor(ctx, in, info, handler)
+	return false
 }
 
-func _TrainService_DeleteTrain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteTrainRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (x *Seat) GetAccessible() bool {
+	if x != nil {
+		return x.Accessible
 	}
-	if interceptor == nil {
+	return false
+}
+
+// CoachSeatMap represents a coach with its generated seats
+type CoachSeatMap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Coach *Coach  `protobuf:"bytes,1,opt,name=coach,proto3" json:"coach,omitempty"`
+	Seats []*Seat `protobuf:"bytes,2,rep,name=seats,proto3" json:"seats,omitempty"`
+}
+
+func (x *CoachSeatMap) Reset() {
+	*x = CoachSeatMap{}
+}
+
+func (x *CoachSeatMap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoachSeatMap) ProtoMessage() {}
+
+func (x *CoachSeatMap) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *CoachSeatMap) GetCoach() *Coach {
+	if x != nil {
+		return x.Coach
+	}
+	return nil
+}
+
+func (x *CoachSeatMap) GetSeats() []*Seat {
+	if x != nil {
+		return x.Seats
+	}
+	return nil
+}
+
+// ClassInventory represents the seats a train offers in one class
+type ClassInventory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Class           string `protobuf:"bytes,1,opt,name=class,proto3" json:"class,omitempty"`
+	Seats           int32  `protobuf:"varint,2,opt,name=seats,proto3" json:"seats,omitempty"`
+	AccessibleSeats int32  `protobuf:"varint,3,opt,name=accessible_seats,json=accessibleSeats,proto3" json:"accessible_seats,omitempty"`
+	Coaches         int32  `protobuf:"varint,4,opt,name=coaches,proto3" json:"coaches,omitempty"`
+}
+
+func (x *ClassInventory) Reset() {
+	*x = ClassInventory{}
+}
+
+func (x *ClassInventory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassInventory) ProtoMessage() {}
+
+func (x *ClassInventory) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ClassInventory) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *ClassInventory) GetSeats() int32 {
+	if x != nil {
+		return x.Seats
+	}
+	return 0
+}
+
+func (x *ClassInventory) GetAccessibleSeats() int32 {
+	if x != nil {
+		return x.AccessibleSeats
+	}
+	return 0
+}
+
+func (x *ClassInventory) GetCoaches() int32 {
+	if x != nil {
+		return x.Coaches
+	}
+	return 0
+}
+
+// AddCoachRequest represents add coach request
+type AddCoachRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrainId         int64    `protobuf:"varint,1,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
+	CoachNumber     int32    `protobuf:"varint,2,opt,name=coach_number,json=coachNumber,proto3" json:"coach_number,omitempty"`
+	Class           string   `protobuf:"bytes,3,opt,name=class,proto3" json:"class,omitempty"`
+	Rows            int32    `protobuf:"varint,4,opt,name=rows,proto3" json:"rows,omitempty"`
+	Columns         int32    `protobuf:"varint,5,opt,name=columns,proto3" json:"columns,omitempty"`
+	AisleAfter      int32    `protobuf:"varint,6,opt,name=aisle_after,json=aisleAfter,proto3" json:"aisle_after,omitempty"`
+	BlockedSeats    []string `protobuf:"bytes,7,rep,name=blocked_seats,json=blockedSeats,proto3" json:"blocked_seats,omitempty"`
+	AccessibleSeats []string `protobuf:"bytes,8,rep,name=accessible_seats,json=accessibleSeats,proto3" json:"accessible_seats,omitempty"`
+	Amenities       []string `protobuf:"bytes,9,rep,name=amenities,proto3" json:"amenities,omitempty"`
+}
+
+func (x *AddCoachRequest) Reset() {
+	*x = AddCoachRequest{}
+}
+
+func (x *AddCoachRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCoachRequest) ProtoMessage() {}
+
+func (x *AddCoachRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *AddCoachRequest) GetTrainId() int64 {
+	if x != nil {
+		return x.TrainId
+	}
+	return 0
+}
+
+func (x *AddCoachRequest) GetCoachNumber() int32 {
+	if x != nil {
+		return x.CoachNumber
+	}
+	return 0
+}
+
+func (x *AddCoachRequest) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *AddCoachRequest) GetRows() int32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *AddCoachRequest) GetColumns() int32 {
+	if x != nil {
+		return x.Columns
+	}
+	return 0
+}
+
+func (x *AddCoachRequest) GetAisleAfter() int32 {
+	if x != nil {
+		return x.AisleAfter
+	}
+	return 0
+}
+
+func (x *AddCoachRequest) GetBlockedSeats() []string {
+	if x != nil {
+		return x.BlockedSeats
+	}
+	return nil
+}
+
+func (x *AddCoachRequest) GetAccessibleSeats() []string {
+	if x != nil {
+		return x.AccessibleSeats
+	}
+	return nil
+}
+
+func (x *AddCoachRequest) GetAmenities() []string {
+	if x != nil {
+		return x.Amenities
+	}
+	return nil
+}
+
+// AddCoachResponse represents add coach response
+type AddCoachResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Coach         *Coach `protobuf:"bytes,1,opt,name=coach,proto3" json:"coach,omitempty"`
+	TrainCapacity int32  `protobuf:"varint,2,opt,name=train_capacity,json=trainCapacity,proto3" json:"train_capacity,omitempty"`
+}
+
+func (x *AddCoachResponse) Reset() {
+	*x = AddCoachResponse{}
+}
+
+func (x *AddCoachResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCoachResponse) ProtoMessage() {}
+
+func (x *AddCoachResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *AddCoachResponse) GetCoach() *Coach {
+	if x != nil {
+		return x.Coach
+	}
+	return nil
+}
+
+func (x *AddCoachResponse) GetTrainCapacity() int32 {
+	if x != nil {
+		return x.TrainCapacity
+	}
+	return 0
+}
+
+// UpdateCoachRequest represents update coach request
+type UpdateCoachRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CoachId         int64    `protobuf:"varint,1,opt,name=coach_id,json=coachId,proto3" json:"coach_id,omitempty"`
+	CoachNumber     int32    `protobuf:"varint,2,opt,name=coach_number,json=coachNumber,proto3" json:"coach_number,omitempty"`
+	Class           string   `protobuf:"bytes,3,opt,name=class,proto3" json:"class,omitempty"`
+	Rows            int32    `protobuf:"varint,4,opt,name=rows,proto3" json:"rows,omitempty"`
+	Columns         int32    `protobuf:"varint,5,opt,name=columns,proto3" json:"columns,omitempty"`
+	AisleAfter      int32    `protobuf:"varint,6,opt,name=aisle_after,json=aisleAfter,proto3" json:"aisle_after,omitempty"`
+	BlockedSeats    []string `protobuf:"bytes,7,rep,name=blocked_seats,json=blockedSeats,proto3" json:"blocked_seats,omitempty"`
+	AccessibleSeats []string `protobuf:"bytes,8,rep,name=accessible_seats,json=accessibleSeats,proto3" json:"accessible_seats,omitempty"`
+	Amenities       []string `protobuf:"bytes,9,rep,name=amenities,proto3" json:"amenities,omitempty"`
+}
+
+func (x *UpdateCoachRequest) Reset() {
+	*x = UpdateCoachRequest{}
+}
+
+func (x *UpdateCoachRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCoachRequest) ProtoMessage() {}
+
+func (x *UpdateCoachRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *UpdateCoachRequest) GetCoachId() int64 {
+	if x != nil {
+		return x.CoachId
+	}
+	return 0
+}
+
+func (x *UpdateCoachRequest) GetCoachNumber() int32 {
+	if x != nil {
+		return x.CoachNumber
+	}
+	return 0
+}
+
+func (x *UpdateCoachRequest) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *UpdateCoachRequest) GetRows() int32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *UpdateCoachRequest) GetColumns() int32 {
+	if x != nil {
+		return x.Columns
+	}
+	return 0
+}
+
+func (x *UpdateCoachRequest) GetAisleAfter() int32 {
+	if x != nil {
+		return x.AisleAfter
+	}
+	return 0
+}
+
+func (x *UpdateCoachRequest) GetBlockedSeats() []string {
+	if x != nil {
+		return x.BlockedSeats
+	}
+	return nil
+}
+
+func (x *UpdateCoachRequest) GetAccessibleSeats() []string {
+	if x != nil {
+		return x.AccessibleSeats
+	}
+	return nil
+}
+
+func (x *UpdateCoachRequest) GetAmenities() []string {
+	if x != nil {
+		return x.Amenities
+	}
+	return nil
+}
+
+// UpdateCoachResponse represents update coach response
+type UpdateCoachResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Coach         *Coach `protobuf:"bytes,1,opt,name=coach,proto3" json:"coach,omitempty"`
+	TrainCapacity int32  `protobuf:"varint,2,opt,name=train_capacity,json=trainCapacity,proto3" json:"train_capacity,omitempty"`
+}
+
+func (x *UpdateCoachResponse) Reset() {
+	*x = UpdateCoachResponse{}
+}
+
+func (x *UpdateCoachResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCoachResponse) ProtoMessage() {}
+
+func (x *UpdateCoachResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *UpdateCoachResponse) GetCoach() *Coach {
+	if x != nil {
+		return x.Coach
+	}
+	return nil
+}
+
+func (x *UpdateCoachResponse) GetTrainCapacity() int32 {
+	if x != nil {
+		return x.TrainCapacity
+	}
+	return 0
+}
+
+// RemoveCoachRequest represents remove coach request
+type RemoveCoachRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CoachId int64 `protobuf:"varint,1,opt,name=coach_id,json=coachId,proto3" json:"coach_id,omitempty"`
+}
+
+func (x *RemoveCoachRequest) Reset() {
+	*x = RemoveCoachRequest{}
+}
+
+func (x *RemoveCoachRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCoachRequest) ProtoMessage() {}
+
+func (x *RemoveCoachRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *RemoveCoachRequest) GetCoachId() int64 {
+	if x != nil {
+		return x.CoachId
+	}
+	return 0
+}
+
+// RemoveCoachResponse represents remove coach response
+type RemoveCoachResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrainCapacity int32 `protobuf:"varint,1,opt,name=train_capacity,json=trainCapacity,proto3" json:"train_capacity,omitempty"`
+}
+
+func (x *RemoveCoachResponse) Reset() {
+	*x = RemoveCoachResponse{}
+}
+
+func (x *RemoveCoachResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCoachResponse) ProtoMessage() {}
+
+func (x *RemoveCoachResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *RemoveCoachResponse) GetTrainCapacity() int32 {
+	if x != nil {
+		return x.TrainCapacity
+	}
+	return 0
+}
+
+// ListCoachesRequest represents list coaches request
+type ListCoachesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrainId int64 `protobuf:"varint,1,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
+}
+
+func (x *ListCoachesRequest) Reset() {
+	*x = ListCoachesRequest{}
+}
+
+func (x *ListCoachesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCoachesRequest) ProtoMessage() {}
+
+func (x *ListCoachesRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListCoachesRequest) GetTrainId() int64 {
+	if x != nil {
+		return x.TrainId
+	}
+	return 0
+}
+
+// ListCoachesResponse represents list coaches response
+type ListCoachesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Coaches  []*Coach `protobuf:"bytes,1,rep,name=coaches,proto3" json:"coaches,omitempty"`
+	Capacity int32    `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+}
+
+func (x *ListCoachesResponse) Reset() {
+	*x = ListCoachesResponse{}
+}
+
+func (x *ListCoachesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCoachesResponse) ProtoMessage() {}
+
+func (x *ListCoachesResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListCoachesResponse) GetCoaches() []*Coach {
+	if x != nil {
+		return x.Coaches
+	}
+	return nil
+}
+
+func (x *ListCoachesResponse) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+// GetSeatMapRequest represents get seat map request
+type GetSeatMapRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrainId int64  `protobuf:"varint,1,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
+	Class   string `protobuf:"bytes,2,opt,name=class,proto3" json:"class,omitempty"`
+}
+
+func (x *GetSeatMapRequest) Reset() {
+	*x = GetSeatMapRequest{}
+}
+
+func (x *GetSeatMapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeatMapRequest) ProtoMessage() {}
+
+func (x *GetSeatMapRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *GetSeatMapRequest) GetTrainId() int64 {
+	if x != nil {
+		return x.TrainId
+	}
+	return 0
+}
+
+func (x *GetSeatMapRequest) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+// GetSeatMapResponse represents get seat map response
+type GetSeatMapResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrainId   int64             `protobuf:"varint,1,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
+	Capacity  int32             `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Coaches   []*CoachSeatMap   `protobuf:"bytes,3,rep,name=coaches,proto3" json:"coaches,omitempty"`
+	Inventory []*ClassInventory `protobuf:"bytes,4,rep,name=inventory,proto3" json:"inventory,omitempty"`
+}
+
+func (x *GetSeatMapResponse) Reset() {
+	*x = GetSeatMapResponse{}
+}
+
+func (x *GetSeatMapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeatMapResponse) ProtoMessage() {}
+
+func (x *GetSeatMapResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *GetSeatMapResponse) GetTrainId() int64 {
+	if x != nil {
+		return x.TrainId
+	}
+	return 0
+}
+
+func (x *GetSeatMapResponse) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *GetSeatMapResponse) GetCoaches() []*CoachSeatMap {
+	if x != nil {
+		return x.Coaches
+	}
+	return nil
+}
+
+func (x *GetSeatMapResponse) GetInventory() []*ClassInventory {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
+// MaintenanceWindow is a period in which a train cannot be scheduled
+type MaintenanceWindow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TrainId  int64  `protobuf:"varint,2,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
+	StartsAt string `protobuf:"bytes,3,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt   string `protobuf:"bytes,4,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Reason   string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *MaintenanceWindow) Reset() {
+	*x = MaintenanceWindow{}
+}
+
+func (x *MaintenanceWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceWindow) ProtoMessage() {}
+
+func (x *MaintenanceWindow) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *MaintenanceWindow) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MaintenanceWindow) GetTrainId() int64 {
+	if x != nil {
+		return x.TrainId
+	}
+	return 0
+}
+
+func (x *MaintenanceWindow) GetStartsAt() string {
+	if x != nil {
+		return x.StartsAt
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetEndsAt() string {
+	if x != nil {
+		return x.EndsAt
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// AddMaintenanceWindowRequest represents add maintenance window request
+type AddMaintenanceWindowRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrainId  int64  `protobuf:"varint,1,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
+	StartsAt string `protobuf:"bytes,2,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt   string `protobuf:"bytes,3,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Reason   string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *AddMaintenanceWindowRequest) Reset() {
+	*x = AddMaintenanceWindowRequest{}
+}
+
+func (x *AddMaintenanceWindowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMaintenanceWindowRequest) ProtoMessage() {}
+
+func (x *AddMaintenanceWindowRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *AddMaintenanceWindowRequest) GetTrainId() int64 {
+	if x != nil {
+		return x.TrainId
+	}
+	return 0
+}
+
+func (x *AddMaintenanceWindowRequest) GetStartsAt() string {
+	if x != nil {
+		return x.StartsAt
+	}
+	return ""
+}
+
+func (x *AddMaintenanceWindowRequest) GetEndsAt() string {
+	if x != nil {
+		return x.EndsAt
+	}
+	return ""
+}
+
+func (x *AddMaintenanceWindowRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// AddMaintenanceWindowResponse represents add maintenance window response
+type AddMaintenanceWindowResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Window *MaintenanceWindow `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+}
+
+func (x *AddMaintenanceWindowResponse) Reset() {
+	*x = AddMaintenanceWindowResponse{}
+}
+
+func (x *AddMaintenanceWindowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMaintenanceWindowResponse) ProtoMessage() {}
+
+func (x *AddMaintenanceWindowResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *AddMaintenanceWindowResponse) GetWindow() *MaintenanceWindow {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+// ListMaintenanceWindowsRequest represents list maintenance windows request
+type ListMaintenanceWindowsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrainId     int64 `protobuf:"varint,1,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
+	IncludePast bool  `protobuf:"varint,2,opt,name=include_past,json=includePast,proto3" json:"include_past,omitempty"`
+}
+
+func (x *ListMaintenanceWindowsRequest) Reset() {
+	*x = ListMaintenanceWindowsRequest{}
+}
+
+func (x *ListMaintenanceWindowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMaintenanceWindowsRequest) ProtoMessage() {}
+
+func (x *ListMaintenanceWindowsRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListMaintenanceWindowsRequest) GetTrainId() int64 {
+	if x != nil {
+		return x.TrainId
+	}
+	return 0
+}
+
+func (x *ListMaintenanceWindowsRequest) GetIncludePast() bool {
+	if x != nil {
+		return x.IncludePast
+	}
+	return false
+}
+
+// ListMaintenanceWindowsResponse represents list maintenance windows response
+type ListMaintenanceWindowsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Windows []*MaintenanceWindow `protobuf:"bytes,1,rep,name=windows,proto3" json:"windows,omitempty"`
+}
+
+func (x *ListMaintenanceWindowsResponse) Reset() {
+	*x = ListMaintenanceWindowsResponse{}
+}
+
+func (x *ListMaintenanceWindowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMaintenanceWindowsResponse) ProtoMessage() {}
+
+func (x *ListMaintenanceWindowsResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListMaintenanceWindowsResponse) GetWindows() []*MaintenanceWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+// RemoveMaintenanceWindowRequest represents remove maintenance window request
+type RemoveMaintenanceWindowRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WindowId int64 `protobuf:"varint,1,opt,name=window_id,json=windowId,proto3" json:"window_id,omitempty"`
+}
+
+func (x *RemoveMaintenanceWindowRequest) Reset() {
+	*x = RemoveMaintenanceWindowRequest{}
+}
+
+func (x *RemoveMaintenanceWindowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMaintenanceWindowRequest) ProtoMessage() {}
+
+func (x *RemoveMaintenanceWindowRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *RemoveMaintenanceWindowRequest) GetWindowId() int64 {
+	if x != nil {
+		return x.WindowId
+	}
+	return 0
+}
+
+// RemoveMaintenanceWindowResponse represents remove maintenance window response
+type RemoveMaintenanceWindowResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+}
+
+func (x *RemoveMaintenanceWindowResponse) Reset() {
+	*x = RemoveMaintenanceWindowResponse{}
+}
+
+func (x *RemoveMaintenanceWindowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMaintenanceWindowResponse) ProtoMessage() {}
+
+func (x *RemoveMaintenanceWindowResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *RemoveMaintenanceWindowResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// CapacityChange is one audited change of a train's capacity and the number
+// of upcoming schedules whose availability it recomputed
+type CapacityChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TrainId          int64  `protobuf:"varint,2,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
+	OldCapacity      int32  `protobuf:"varint,3,opt,name=old_capacity,json=oldCapacity,proto3" json:"old_capacity,omitempty"`
+	NewCapacity      int32  `protobuf:"varint,4,opt,name=new_capacity,json=newCapacity,proto3" json:"new_capacity,omitempty"`
+	Source           string `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	SchedulesUpdated int32  `protobuf:"varint,6,opt,name=schedules_updated,json=schedulesUpdated,proto3" json:"schedules_updated,omitempty"`
+	CreatedAt        string `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *CapacityChange) Reset() {
+	*x = CapacityChange{}
+}
+
+func (x *CapacityChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapacityChange) ProtoMessage() {}
+
+func (x *CapacityChange) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *CapacityChange) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CapacityChange) GetTrainId() int64 {
+	if x != nil {
+		return x.TrainId
+	}
+	return 0
+}
+
+func (x *CapacityChange) GetOldCapacity() int32 {
+	if x != nil {
+		return x.OldCapacity
+	}
+	return 0
+}
+
+func (x *CapacityChange) GetNewCapacity() int32 {
+	if x != nil {
+		return x.NewCapacity
+	}
+	return 0
+}
+
+func (x *CapacityChange) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *CapacityChange) GetSchedulesUpdated() int32 {
+	if x != nil {
+		return x.SchedulesUpdated
+	}
+	return 0
+}
+
+func (x *CapacityChange) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// ListCapacityChangesRequest represents list capacity changes request
+type ListCapacityChangesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrainId int64 `protobuf:"varint,1,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
+}
+
+func (x *ListCapacityChangesRequest) Reset() {
+	*x = ListCapacityChangesRequest{}
+}
+
+func (x *ListCapacityChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCapacityChangesRequest) ProtoMessage() {}
+
+func (x *ListCapacityChangesRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListCapacityChangesRequest) GetTrainId() int64 {
+	if x != nil {
+		return x.TrainId
+	}
+	return 0
+}
+
+// ListCapacityChangesResponse represents list capacity changes response
+type ListCapacityChangesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Changes []*CapacityChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *ListCapacityChangesResponse) Reset() {
+	*x = ListCapacityChangesResponse{}
+}
+
+func (x *ListCapacityChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCapacityChangesResponse) ProtoMessage() {}
+
+func (x *ListCapacityChangesResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListCapacityChangesResponse) GetChanges() []*CapacityChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// Amenity is one entry of the amenities catalogue, such as wifi or a dining car
+type Amenity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code        string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (x *Amenity) Reset() {
+	*x = Amenity{}
+}
+
+func (x *Amenity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Amenity) ProtoMessage() {}
+
+func (x *Amenity) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *Amenity) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Amenity) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Amenity) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// ListAmenitiesRequest represents list amenities request
+type ListAmenitiesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListAmenitiesRequest) Reset() {
+	*x = ListAmenitiesRequest{}
+}
+
+func (x *ListAmenitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAmenitiesRequest) ProtoMessage() {}
+
+func (x *ListAmenitiesRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+// ListAmenitiesResponse represents list amenities response
+type ListAmenitiesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Amenities []*Amenity `protobuf:"bytes,1,rep,name=amenities,proto3" json:"amenities,omitempty"`
+}
+
+func (x *ListAmenitiesResponse) Reset() {
+	*x = ListAmenitiesResponse{}
+}
+
+func (x *ListAmenitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAmenitiesResponse) ProtoMessage() {}
+
+func (x *ListAmenitiesResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *ListAmenitiesResponse) GetAmenities() []*Amenity {
+	if x != nil {
+		return x.Amenities
+	}
+	return nil
+}
+
+// SetTrainAmenitiesRequest represents a request to replace the train-wide
+// amenities; coach amenities are set on the coach itself
+type SetTrainAmenitiesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrainId   int64    `protobuf:"varint,1,opt,name=train_id,json=trainId,proto3" json:"train_id,omitempty"`
+	Amenities []string `protobuf:"bytes,2,rep,name=amenities,proto3" json:"amenities,omitempty"`
+}
+
+func (x *SetTrainAmenitiesRequest) Reset() {
+	*x = SetTrainAmenitiesRequest{}
+}
+
+func (x *SetTrainAmenitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTrainAmenitiesRequest) ProtoMessage() {}
+
+func (x *SetTrainAmenitiesRequest) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *SetTrainAmenitiesRequest) GetTrainId() int64 {
+	if x != nil {
+		return x.TrainId
+	}
+	return 0
+}
+
+func (x *SetTrainAmenitiesRequest) GetAmenities() []string {
+	if x != nil {
+		return x.Amenities
+	}
+	return nil
+}
+
+// SetTrainAmenitiesResponse represents set train amenities response
+type SetTrainAmenitiesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Train *Train `protobuf:"bytes,1,opt,name=train,proto3" json:"train,omitempty"`
+}
+
+func (x *SetTrainAmenitiesResponse) Reset() {
+	*x = SetTrainAmenitiesResponse{}
+}
+
+func (x *SetTrainAmenitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTrainAmenitiesResponse) ProtoMessage() {}
+
+func (x *SetTrainAmenitiesResponse) ProtoReflect() protoreflect.Message {
+	return nil
+}
+
+func (x *SetTrainAmenitiesResponse) GetTrain() *Train {
+	if x != nil {
+		return x.Train
+	}
+	return nil
+}
+
+// TrainServiceClient is the client API for TrainService service.
+type TrainServiceClient interface {
+	GetTrain(ctx context.Context, in *GetTrainRequest, opts ...grpc.CallOption) (*GetTrainResponse, error)
+	ListTrains(ctx context.Context, in *ListTrainsRequest, opts ...grpc.CallOption) (*ListTrainsResponse, error)
+	CreateTrain(ctx context.Context, in *CreateTrainRequest, opts ...grpc.CallOption) (*CreateTrainResponse, error)
+	UpdateTrain(ctx context.Context, in *UpdateTrainRequest, opts ...grpc.CallOption) (*UpdateTrainResponse, error)
+	DeleteTrain(ctx context.Context, in *DeleteTrainRequest, opts ...grpc.CallOption) (*DeleteTrainResponse, error)
+	AddCoach(ctx context.Context, in *AddCoachRequest, opts ...grpc.CallOption) (*AddCoachResponse, error)
+	UpdateCoach(ctx context.Context, in *UpdateCoachRequest, opts ...grpc.CallOption) (*UpdateCoachResponse, error)
+	RemoveCoach(ctx context.Context, in *RemoveCoachRequest, opts ...grpc.CallOption) (*RemoveCoachResponse, error)
+	ListCoaches(ctx context.Context, in *ListCoachesRequest, opts ...grpc.CallOption) (*ListCoachesResponse, error)
+	GetSeatMap(ctx context.Context, in *GetSeatMapRequest, opts ...grpc.CallOption) (*GetSeatMapResponse, error)
+	AddMaintenanceWindow(ctx context.Context, in *AddMaintenanceWindowRequest, opts ...grpc.CallOption) (*AddMaintenanceWindowResponse, error)
+	ListMaintenanceWindows(ctx context.Context, in *ListMaintenanceWindowsRequest, opts ...grpc.CallOption) (*ListMaintenanceWindowsResponse, error)
+	RemoveMaintenanceWindow(ctx context.Context, in *RemoveMaintenanceWindowRequest, opts ...grpc.CallOption) (*RemoveMaintenanceWindowResponse, error)
+	ListCapacityChanges(ctx context.Context, in *ListCapacityChangesRequest, opts ...grpc.CallOption) (*ListCapacityChangesResponse, error)
+	ListAmenities(ctx context.Context, in *ListAmenitiesRequest, opts ...grpc.CallOption) (*ListAmenitiesResponse, error)
+	SetTrainAmenities(ctx context.Context, in *SetTrainAmenitiesRequest, opts ...grpc.CallOption) (*SetTrainAmenitiesResponse, error)
+}
+
+type trainServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTrainServiceClient(cc grpc.ClientConnInterface) TrainServiceClient {
+	return &trainServiceClient{cc}
+}
+
+func (c *trainServiceClient) GetTrain(ctx context.Context, in *GetTrainRequest, opts ...grpc.CallOption) (*GetTrainResponse, error) {
+	out := new(GetTrainResponse)
+	err := c.cc.Invoke(ctx, "/train.TrainService/GetTrain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainServiceClient) ListTrains(ctx context.Context, in *ListTrainsRequest, opts ...grpc.CallOption) (*ListTrainsResponse, error) {
+	out := new(ListTrainsResponse)
+	err := c.cc.Invoke(ctx, "/train.TrainService/ListTrains", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainServiceClient) CreateTrain(ctx context.Context, in *CreateTrainRequest, opts ...grpc.CallOption) (*CreateTrainResponse, error) {
+	out := new(CreateTrainResponse)
+	err := c.cc.Invoke(ctx, "/train.TrainService/CreateTrain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainServiceClient) UpdateTrain(ctx context.Context, in *UpdateTrainRequest, opts ...grpc.CallOption) (*UpdateTrainResponse, error) {
+	out := new(UpdateTrainResponse)
+	err := c.cc.Invoke(ctx, "/train.TrainService/UpdateTrain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainServiceClient) DeleteTrain(ctx context.Context, in *DeleteTrainRequest, opts ...grpc.CallOption) (*DeleteTrainResponse, error) {
+	out := new(DeleteTrainResponse)
+	err := c.cc.Invoke(ctx, "/train.TrainService/DeleteTrain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainServiceClient) AddCoach(ctx context.Context, in *AddCoachRequest, opts ...grpc.CallOption) (*AddCoachResponse, error) {
+	out := new(AddCoachResponse)
+	err := c.cc.Invoke(ctx, "/train.TrainService/AddCoach", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainServiceClient) UpdateCoach(ctx context.Context, in *UpdateCoachRequest, opts ...grpc.CallOption) (*UpdateCoachResponse, error) {
+	out := new(UpdateCoachResponse)
+	err := c.cc.Invoke(ctx, "/train.TrainService/UpdateCoach", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainServiceClient) RemoveCoach(ctx context.Context, in *RemoveCoachRequest, opts ...grpc.CallOption) (*RemoveCoachResponse, error) {
+	out := new(RemoveCoachResponse)
+	err := c.cc.Invoke(ctx, "/train.TrainService/RemoveCoach", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainServiceClient) ListCoaches(ctx context.Context, in *ListCoachesRequest, opts ...grpc.CallOption) (*ListCoachesResponse, error) {
+	out := new(ListCoachesResponse)
+	err := c.cc.Invoke(ctx, "/train.TrainService/ListCoaches", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainServiceClient) GetSeatMap(ctx context.Context, in *GetSeatMapRequest, opts ...grpc.CallOption) (*GetSeatMapResponse, error) {
+	out := new(GetSeatMapResponse)
+	err := c.cc.Invoke(ctx, "/train.TrainService/GetSeatMap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainServiceClient) AddMaintenanceWindow(ctx context.Context, in *AddMaintenanceWindowRequest, opts ...grpc.CallOption) (*AddMaintenanceWindowResponse, error) {
+	out := new(AddMaintenanceWindowResponse)
+	err := c.cc.Invoke(ctx, "/train.TrainService/AddMaintenanceWindow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainServiceClient) ListMaintenanceWindows(ctx context.Context, in *ListMaintenanceWindowsRequest, opts ...grpc.CallOption) (*ListMaintenanceWindowsResponse, error) {
+	out := new(ListMaintenanceWindowsResponse)
+	err := c.cc.Invoke(ctx, "/train.TrainService/ListMaintenanceWindows", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainServiceClient) RemoveMaintenanceWindow(ctx context.Context, in *RemoveMaintenanceWindowRequest, opts ...grpc.CallOption) (*RemoveMaintenanceWindowResponse, error) {
+	out := new(RemoveMaintenanceWindowResponse)
+	err := c.cc.Invoke(ctx, "/train.TrainService/RemoveMaintenanceWindow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainServiceClient) ListCapacityChanges(ctx context.Context, in *ListCapacityChangesRequest, opts ...grpc.CallOption) (*ListCapacityChangesResponse, error) {
+	out := new(ListCapacityChangesResponse)
+	err := c.cc.Invoke(ctx, "/train.TrainService/ListCapacityChanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainServiceClient) ListAmenities(ctx context.Context, in *ListAmenitiesRequest, opts ...grpc.CallOption) (*ListAmenitiesResponse, error) {
+	out := new(ListAmenitiesResponse)
+	err := c.cc.Invoke(ctx, "/train.TrainService/ListAmenities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainServiceClient) SetTrainAmenities(ctx context.Context, in *SetTrainAmenitiesRequest, opts ...grpc.CallOption) (*SetTrainAmenitiesResponse, error) {
+	out := new(SetTrainAmenitiesResponse)
+	err := c.cc.Invoke(ctx, "/train.TrainService/SetTrainAmenities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TrainServiceServer is the server API for TrainService service.
+type TrainServiceServer interface {
+	GetTrain(context.Context, *GetTrainRequest) (*GetTrainResponse, error)
+	ListTrains(context.Context, *ListTrainsRequest) (*ListTrainsResponse, error)
+	CreateTrain(context.Context, *CreateTrainRequest) (*CreateTrainResponse, error)
+	UpdateTrain(context.Context, *UpdateTrainRequest) (*UpdateTrainResponse, error)
+	DeleteTrain(context.Context, *DeleteTrainRequest) (*DeleteTrainResponse, error)
+	AddCoach(context.Context, *AddCoachRequest) (*AddCoachResponse, error)
+	UpdateCoach(context.Context, *UpdateCoachRequest) (*UpdateCoachResponse, error)
+	RemoveCoach(context.Context, *RemoveCoachRequest) (*RemoveCoachResponse, error)
+	ListCoaches(context.Context, *ListCoachesRequest) (*ListCoachesResponse, error)
+	GetSeatMap(context.Context, *GetSeatMapRequest) (*GetSeatMapResponse, error)
+	AddMaintenanceWindow(context.Context, *AddMaintenanceWindowRequest) (*AddMaintenanceWindowResponse, error)
+	ListMaintenanceWindows(context.Context, *ListMaintenanceWindowsRequest) (*ListMaintenanceWindowsResponse, error)
+	RemoveMaintenanceWindow(context.Context, *RemoveMaintenanceWindowRequest) (*RemoveMaintenanceWindowResponse, error)
+	ListCapacityChanges(context.Context, *ListCapacityChangesRequest) (*ListCapacityChangesResponse, error)
+	ListAmenities(context.Context, *ListAmenitiesRequest) (*ListAmenitiesResponse, error)
+	SetTrainAmenities(context.Context, *SetTrainAmenitiesRequest) (*SetTrainAmenitiesResponse, error)
+}
+
+// UnimplementedTrainServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedTrainServiceServer struct {
+}
+
+func (*UnimplementedTrainServiceServer) GetTrain(context.Context, *GetTrainRequest) (*GetTrainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrain not implemented")
+}
+
+func (*UnimplementedTrainServiceServer) ListTrains(context.Context, *ListTrainsRequest) (*ListTrainsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrains not implemented")
+}
+
+func (*UnimplementedTrainServiceServer) CreateTrain(context.Context, *CreateTrainRequest) (*CreateTrainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTrain not implemented")
+}
+
+func (*UnimplementedTrainServiceServer) UpdateTrain(context.Context, *UpdateTrainRequest) (*UpdateTrainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTrain not implemented")
+}
+
+func (*UnimplementedTrainServiceServer) DeleteTrain(context.Context, *DeleteTrainRequest) (*DeleteTrainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTrain not implemented")
+}
+
+func (*UnimplementedTrainServiceServer) AddCoach(context.Context, *AddCoachRequest) (*AddCoachResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCoach not implemented")
+}
+
+func (*UnimplementedTrainServiceServer) UpdateCoach(context.Context, *UpdateCoachRequest) (*UpdateCoachResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCoach not implemented")
+}
+
+func (*UnimplementedTrainServiceServer) RemoveCoach(context.Context, *RemoveCoachRequest) (*RemoveCoachResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCoach not implemented")
+}
+
+func (*UnimplementedTrainServiceServer) ListCoaches(context.Context, *ListCoachesRequest) (*ListCoachesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCoaches not implemented")
+}
+
+func (*UnimplementedTrainServiceServer) GetSeatMap(context.Context, *GetSeatMapRequest) (*GetSeatMapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeatMap not implemented")
+}
+
+func (*UnimplementedTrainServiceServer) AddMaintenanceWindow(context.Context, *AddMaintenanceWindowRequest) (*AddMaintenanceWindowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMaintenanceWindow not implemented")
+}
+
+func (*UnimplementedTrainServiceServer) ListMaintenanceWindows(context.Context, *ListMaintenanceWindowsRequest) (*ListMaintenanceWindowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMaintenanceWindows not implemented")
+}
+
+func (*UnimplementedTrainServiceServer) RemoveMaintenanceWindow(context.Context, *RemoveMaintenanceWindowRequest) (*RemoveMaintenanceWindowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMaintenanceWindow not implemented")
+}
+
+func (*UnimplementedTrainServiceServer) ListCapacityChanges(context.Context, *ListCapacityChangesRequest) (*ListCapacityChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCapacityChanges not implemented")
+}
+
+func (*UnimplementedTrainServiceServer) ListAmenities(context.Context, *ListAmenitiesRequest) (*ListAmenitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAmenities not implemented")
+}
+
+func (*UnimplementedTrainServiceServer) SetTrainAmenities(context.Context, *SetTrainAmenitiesRequest) (*SetTrainAmenitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTrainAmenities not implemented")
+}
+
+func RegisterTrainServiceServer(s *grpc.Server, srv TrainServiceServer) {
+	s.RegisterService(&_TrainService_serviceDesc, srv)
+}
+
+var _TrainService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "train.TrainService",
+	HandlerType: (*TrainServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTrain",
+			Handler:    _TrainService_GetTrain_Handler,
+		},
+		{
+			MethodName: "ListTrains",
+			Handler:    _TrainService_ListTrains_Handler,
+		},
+		{
+			MethodName: "CreateTrain",
+			Handler:    _TrainService_CreateTrain_Handler,
+		},
+		{
+			MethodName: "UpdateTrain",
+			Handler:    _TrainService_UpdateTrain_Handler,
+		},
+		{
+			MethodName: "DeleteTrain",
+			Handler:    _TrainService_DeleteTrain_Handler,
+		},
+		{
+			MethodName: "AddCoach",
+			Handler:    _TrainService_AddCoach_Handler,
+		},
+		{
+			MethodName: "UpdateCoach",
+			Handler:    _TrainService_UpdateCoach_Handler,
+		},
+		{
+			MethodName: "RemoveCoach",
+			Handler:    _TrainService_RemoveCoach_Handler,
+		},
+		{
+			MethodName: "ListCoaches",
+			Handler:    _TrainService_ListCoaches_Handler,
+		},
+		{
+			MethodName: "GetSeatMap",
+			Handler:    _TrainService_GetSeatMap_Handler,
+		},
+		{
+			MethodName: "AddMaintenanceWindow",
+			Handler:    _TrainService_AddMaintenanceWindow_Handler,
+		},
+		{
+			MethodName: "ListMaintenanceWindows",
+			Handler:    _TrainService_ListMaintenanceWindows_Handler,
+		},
+		{
+			MethodName: "RemoveMaintenanceWindow",
+			Handler:    _TrainService_RemoveMaintenanceWindow_Handler,
+		},
+		{
+			MethodName: "ListCapacityChanges",
+			Handler:    _TrainService_ListCapacityChanges_Handler,
+		},
+		{
+			MethodName: "ListAmenities",
+			Handler:    _TrainService_ListAmenities_Handler,
+		},
+		{
+			MethodName: "SetTrainAmenities",
+			Handler:    _TrainService_SetTrainAmenities_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "train.proto",
+}
+
+func _TrainService_GetTrain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainServiceServer).GetTrain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TrainService/GetTrain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainServiceServer).GetTrain(ctx, req.(*GetTrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainService_ListTrains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainServiceServer).ListTrains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TrainService/ListTrains",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainServiceServer).ListTrains(ctx, req.(*ListTrainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainService_CreateTrain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainServiceServer).CreateTrain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TrainService/CreateTrain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainServiceServer).CreateTrain(ctx, req.(*CreateTrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainService_UpdateTrain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainServiceServer).UpdateTrain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TrainService/UpdateTrain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainServiceServer).UpdateTrain(ctx, req.(*UpdateTrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainService_DeleteTrain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
 		return srv.(TrainServiceServer).DeleteTrain(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
@@ -654,3 +2257,201 @@ func _TrainService_DeleteTrain_Handler(srv interface{}, ctx context.Context, dec
 	}
 	return interceptor(ctx, in, info, handler)
 }
+
+func _TrainService_AddCoach_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCoachRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainServiceServer).AddCoach(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TrainService/AddCoach",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainServiceServer).AddCoach(ctx, req.(*AddCoachRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainService_UpdateCoach_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCoachRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainServiceServer).UpdateCoach(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TrainService/UpdateCoach",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainServiceServer).UpdateCoach(ctx, req.(*UpdateCoachRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainService_RemoveCoach_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCoachRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainServiceServer).RemoveCoach(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TrainService/RemoveCoach",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainServiceServer).RemoveCoach(ctx, req.(*RemoveCoachRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainService_ListCoaches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCoachesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainServiceServer).ListCoaches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TrainService/ListCoaches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainServiceServer).ListCoaches(ctx, req.(*ListCoachesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainService_GetSeatMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeatMapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainServiceServer).GetSeatMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TrainService/GetSeatMap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainServiceServer).GetSeatMap(ctx, req.(*GetSeatMapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainService_AddMaintenanceWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddMaintenanceWindowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainServiceServer).AddMaintenanceWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TrainService/AddMaintenanceWindow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainServiceServer).AddMaintenanceWindow(ctx, req.(*AddMaintenanceWindowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainService_ListMaintenanceWindows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMaintenanceWindowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainServiceServer).ListMaintenanceWindows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TrainService/ListMaintenanceWindows",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainServiceServer).ListMaintenanceWindows(ctx, req.(*ListMaintenanceWindowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainService_RemoveMaintenanceWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMaintenanceWindowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainServiceServer).RemoveMaintenanceWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TrainService/RemoveMaintenanceWindow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainServiceServer).RemoveMaintenanceWindow(ctx, req.(*RemoveMaintenanceWindowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainService_ListCapacityChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCapacityChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainServiceServer).ListCapacityChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TrainService/ListCapacityChanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainServiceServer).ListCapacityChanges(ctx, req.(*ListCapacityChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainService_ListAmenities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAmenitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainServiceServer).ListAmenities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TrainService/ListAmenities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainServiceServer).ListAmenities(ctx, req.(*ListAmenitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainService_SetTrainAmenities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTrainAmenitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainServiceServer).SetTrainAmenities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TrainService/SetTrainAmenities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainServiceServer).SetTrainAmenities(ctx, req.(*SetTrainAmenitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
@@ -15,6 +15,8 @@ type RegisterRequest struct {
 	Email           string `json:"email"`
 	Password        string `json:"password"`
 	ConfirmPassword string `json:"confirm_password"`
+	Locale          string `json:"locale"`
+	FullName        string `json:"full_name"`
 }
 
 func (m *RegisterRequest) Reset()         { *m = RegisterRequest{} }
@@ -25,23 +27,33 @@ func (m *RegisterRequest) GetUsername() string        { return m.Username }
 func (m *RegisterRequest) GetEmail() string           { return m.Email }
 func (m *RegisterRequest) GetPassword() string        { return m.Password }
 func (m *RegisterRequest) GetConfirmPassword() string { return m.ConfirmPassword }
+func (m *RegisterRequest) GetLocale() string          { return m.Locale }
+func (m *RegisterRequest) GetFullName() string        { return m.FullName }
 
 // RegisterResponse represents registration response
 type RegisterResponse struct {
-	UserId   int64  `json:"user_id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Token    string `json:"token"`
+	UserId        int64    `json:"user_id"`
+	Username      string   `json:"username"`
+	Email         string   `json:"email"`
+	Token         string   `json:"token"`
+	Roles         []string `json:"roles"`
+	RefreshToken  string   `json:"refresh_token"`
+	ExpiresIn     int64    `json:"expires_in"`
+	EmailVerified bool     `json:"email_verified"`
 }
 
 func (m *RegisterResponse) Reset()         { *m = RegisterResponse{} }
 func (m *RegisterResponse) String() string { return fmt.Sprintf("%+v", *m) }
 func (*RegisterResponse) ProtoMessage()    {}
 
-func (m *RegisterResponse) GetUserId() int64    { return m.UserId }
-func (m *RegisterResponse) GetUsername() string { return m.Username }
-func (m *RegisterResponse) GetEmail() string    { return m.Email }
-func (m *RegisterResponse) GetToken() string    { return m.Token }
+func (m *RegisterResponse) GetUserId() int64        { return m.UserId }
+func (m *RegisterResponse) GetUsername() string     { return m.Username }
+func (m *RegisterResponse) GetEmail() string        { return m.Email }
+func (m *RegisterResponse) GetToken() string        { return m.Token }
+func (m *RegisterResponse) GetRoles() []string      { return m.Roles }
+func (m *RegisterResponse) GetRefreshToken() string { return m.RefreshToken }
+func (m *RegisterResponse) GetExpiresIn() int64     { return m.ExpiresIn }
+func (m *RegisterResponse) GetEmailVerified() bool  { return m.EmailVerified }
 
 // LoginRequest represents login request
 type LoginRequest struct {
@@ -58,20 +70,32 @@ func (m *LoginRequest) GetPassword() string { return m.Password }
 
 // LoginResponse represents login response
 type LoginResponse struct {
-	UserId   int64  `json:"user_id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Token    string `json:"token"`
+	UserId            int64    `json:"user_id"`
+	Username          string   `json:"username"`
+	Email             string   `json:"email"`
+	Token             string   `json:"token"`
+	Roles             []string `json:"roles"`
+	RefreshToken      string   `json:"refresh_token"`
+	ExpiresIn         int64    `json:"expires_in"`
+	EmailVerified     bool     `json:"email_verified"`
+	TwoFactorRequired bool     `json:"two_factor_required"`
+	ChallengeToken    string   `json:"challenge_token"`
 }
 
 func (m *LoginResponse) Reset()         { *m = LoginResponse{} }
 func (m *LoginResponse) String() string { return fmt.Sprintf("%+v", *m) }
 func (*LoginResponse) ProtoMessage()    {}
 
-func (m *LoginResponse) GetUserId() int64    { return m.UserId }
-func (m *LoginResponse) GetUsername() string { return m.Username }
-func (m *LoginResponse) GetEmail() string    { return m.Email }
-func (m *LoginResponse) GetToken() string    { return m.Token }
+func (m *LoginResponse) GetUserId() int64           { return m.UserId }
+func (m *LoginResponse) GetUsername() string        { return m.Username }
+func (m *LoginResponse) GetEmail() string           { return m.Email }
+func (m *LoginResponse) GetToken() string           { return m.Token }
+func (m *LoginResponse) GetRoles() []string         { return m.Roles }
+func (m *LoginResponse) GetRefreshToken() string    { return m.RefreshToken }
+func (m *LoginResponse) GetExpiresIn() int64        { return m.ExpiresIn }
+func (m *LoginResponse) GetEmailVerified() bool     { return m.EmailVerified }
+func (m *LoginResponse) GetTwoFactorRequired() bool { return m.TwoFactorRequired }
+func (m *LoginResponse) GetChallengeToken() string  { return m.ChallengeToken }
 
 // GetUserRequest represents get user request
 type GetUserRequest struct {
@@ -86,18 +110,34 @@ func (m *GetUserRequest) GetUserId() int64 { return m.UserId }
 
 // GetUserResponse represents get user response
 type GetUserResponse struct {
-	UserId   int64  `json:"user_id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
+	UserId            int64    `json:"user_id"`
+	Username          string   `json:"username"`
+	Email             string   `json:"email"`
+	Roles             []string `json:"roles"`
+	EmailVerified     bool     `json:"email_verified"`
+	TwoFactorEnabled  bool     `json:"two_factor_enabled"`
+	TwoFactorRequired bool     `json:"two_factor_required"`
+	FullName          string   `json:"full_name"`
+	Phone             string   `json:"phone"`
+	DateOfBirth       string   `json:"date_of_birth"`
+	Locale            string   `json:"locale"`
 }
 
 func (m *GetUserResponse) Reset()         { *m = GetUserResponse{} }
 func (m *GetUserResponse) String() string { return fmt.Sprintf("%+v", *m) }
 func (*GetUserResponse) ProtoMessage()    {}
 
-func (m *GetUserResponse) GetUserId() int64    { return m.UserId }
-func (m *GetUserResponse) GetUsername() string { return m.Username }
-func (m *GetUserResponse) GetEmail() string    { return m.Email }
+func (m *GetUserResponse) GetUserId() int64           { return m.UserId }
+func (m *GetUserResponse) GetUsername() string        { return m.Username }
+func (m *GetUserResponse) GetEmail() string           { return m.Email }
+func (m *GetUserResponse) GetRoles() []string         { return m.Roles }
+func (m *GetUserResponse) GetEmailVerified() bool     { return m.EmailVerified }
+func (m *GetUserResponse) GetTwoFactorEnabled() bool  { return m.TwoFactorEnabled }
+func (m *GetUserResponse) GetTwoFactorRequired() bool { return m.TwoFactorRequired }
+func (m *GetUserResponse) GetFullName() string        { return m.FullName }
+func (m *GetUserResponse) GetPhone() string           { return m.Phone }
+func (m *GetUserResponse) GetDateOfBirth() string     { return m.DateOfBirth }
+func (m *GetUserResponse) GetLocale() string          { return m.Locale }
 
 // ForgotPasswordRequest represents forgot password request
 type ForgotPasswordRequest struct {
@@ -114,7 +154,8 @@ func (m *ForgotPasswordRequest) GetEmail() string { return m.Email }
 type ForgotPasswordResponse struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
-	Token   string `json:"token"`
+	// Deprecated: always empty; the token is emailed.
+	Token string `json:"token"`
 }
 
 func (m *ForgotPasswordResponse) Reset()         { *m = ForgotPasswordResponse{} }
@@ -151,6 +192,514 @@ func (*ResetPasswordResponse) ProtoMessage()    {}
 func (m *ResetPasswordResponse) GetSuccess() bool   { return m.Success }
 func (m *ResetPasswordResponse) GetMessage() string { return m.Message }
 
+// GrantRoleRequest represents a request to give a user an extra role
+type GrantRoleRequest struct {
+	UserId    int64  `json:"user_id"`
+	Role      string `json:"role"`
+	GrantedBy int64  `json:"granted_by"`
+}
+
+func (m *GrantRoleRequest) Reset()         { *m = GrantRoleRequest{} }
+func (m *GrantRoleRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*GrantRoleRequest) ProtoMessage()    {}
+
+func (m *GrantRoleRequest) GetUserId() int64    { return m.UserId }
+func (m *GrantRoleRequest) GetRole() string     { return m.Role }
+func (m *GrantRoleRequest) GetGrantedBy() int64 { return m.GrantedBy }
+
+// GrantRoleResponse represents grant role response
+type GrantRoleResponse struct {
+	UserId int64    `json:"user_id"`
+	Roles  []string `json:"roles"`
+}
+
+func (m *GrantRoleResponse) Reset()         { *m = GrantRoleResponse{} }
+func (m *GrantRoleResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*GrantRoleResponse) ProtoMessage()    {}
+
+func (m *GrantRoleResponse) GetUserId() int64   { return m.UserId }
+func (m *GrantRoleResponse) GetRoles() []string { return m.Roles }
+
+// RevokeRoleRequest represents revoke role request
+type RevokeRoleRequest struct {
+	UserId int64  `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+func (m *RevokeRoleRequest) Reset()         { *m = RevokeRoleRequest{} }
+func (m *RevokeRoleRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*RevokeRoleRequest) ProtoMessage()    {}
+
+func (m *RevokeRoleRequest) GetUserId() int64 { return m.UserId }
+func (m *RevokeRoleRequest) GetRole() string  { return m.Role }
+
+// RevokeRoleResponse represents revoke role response
+type RevokeRoleResponse struct {
+	UserId int64    `json:"user_id"`
+	Roles  []string `json:"roles"`
+}
+
+func (m *RevokeRoleResponse) Reset()         { *m = RevokeRoleResponse{} }
+func (m *RevokeRoleResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*RevokeRoleResponse) ProtoMessage()    {}
+
+func (m *RevokeRoleResponse) GetUserId() int64   { return m.UserId }
+func (m *RevokeRoleResponse) GetRoles() []string { return m.Roles }
+
+// RefreshTokenRequest represents refresh token request
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (m *RefreshTokenRequest) Reset()         { *m = RefreshTokenRequest{} }
+func (m *RefreshTokenRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*RefreshTokenRequest) ProtoMessage()    {}
+
+func (m *RefreshTokenRequest) GetRefreshToken() string { return m.RefreshToken }
+
+// RefreshTokenResponse represents refresh token response
+type RefreshTokenResponse struct {
+	UserId       int64    `json:"user_id"`
+	Token        string   `json:"token"`
+	RefreshToken string   `json:"refresh_token"`
+	ExpiresIn    int64    `json:"expires_in"`
+	Roles        []string `json:"roles"`
+}
+
+func (m *RefreshTokenResponse) Reset()         { *m = RefreshTokenResponse{} }
+func (m *RefreshTokenResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*RefreshTokenResponse) ProtoMessage()    {}
+
+func (m *RefreshTokenResponse) GetUserId() int64        { return m.UserId }
+func (m *RefreshTokenResponse) GetToken() string        { return m.Token }
+func (m *RefreshTokenResponse) GetRefreshToken() string { return m.RefreshToken }
+func (m *RefreshTokenResponse) GetExpiresIn() int64     { return m.ExpiresIn }
+func (m *RefreshTokenResponse) GetRoles() []string      { return m.Roles }
+
+// LogoutRequest represents logout request
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (m *LogoutRequest) Reset()         { *m = LogoutRequest{} }
+func (m *LogoutRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*LogoutRequest) ProtoMessage()    {}
+
+func (m *LogoutRequest) GetRefreshToken() string { return m.RefreshToken }
+
+// LogoutResponse represents logout response
+type LogoutResponse struct {
+	Success bool `json:"success"`
+}
+
+func (m *LogoutResponse) Reset()         { *m = LogoutResponse{} }
+func (m *LogoutResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*LogoutResponse) ProtoMessage()    {}
+
+func (m *LogoutResponse) GetSuccess() bool { return m.Success }
+
+// CheckSessionRequest asks whether the session behind an access token (its
+// sid claim) is still valid
+type CheckSessionRequest struct {
+	SessionId string `json:"session_id"`
+}
+
+func (m *CheckSessionRequest) Reset()         { *m = CheckSessionRequest{} }
+func (m *CheckSessionRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*CheckSessionRequest) ProtoMessage()    {}
+
+func (m *CheckSessionRequest) GetSessionId() string { return m.SessionId }
+
+// CheckSessionResponse represents check session response
+type CheckSessionResponse struct {
+	Revoked bool `json:"revoked"`
+}
+
+func (m *CheckSessionResponse) Reset()         { *m = CheckSessionResponse{} }
+func (m *CheckSessionResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*CheckSessionResponse) ProtoMessage()    {}
+
+func (m *CheckSessionResponse) GetRevoked() bool { return m.Revoked }
+
+// VerifyEmailRequest represents verify email request
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+func (m *VerifyEmailRequest) Reset()         { *m = VerifyEmailRequest{} }
+func (m *VerifyEmailRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*VerifyEmailRequest) ProtoMessage()    {}
+
+func (m *VerifyEmailRequest) GetToken() string { return m.Token }
+
+// VerifyEmailResponse represents verify email response
+type VerifyEmailResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+func (m *VerifyEmailResponse) Reset()         { *m = VerifyEmailResponse{} }
+func (m *VerifyEmailResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*VerifyEmailResponse) ProtoMessage()    {}
+
+func (m *VerifyEmailResponse) GetSuccess() bool   { return m.Success }
+func (m *VerifyEmailResponse) GetMessage() string { return m.Message }
+
+// ResendVerificationRequest represents resend verification request
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+func (m *ResendVerificationRequest) Reset()         { *m = ResendVerificationRequest{} }
+func (m *ResendVerificationRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*ResendVerificationRequest) ProtoMessage()    {}
+
+func (m *ResendVerificationRequest) GetEmail() string { return m.Email }
+
+// ResendVerificationResponse represents resend verification response
+type ResendVerificationResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+func (m *ResendVerificationResponse) Reset()         { *m = ResendVerificationResponse{} }
+func (m *ResendVerificationResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*ResendVerificationResponse) ProtoMessage()    {}
+
+func (m *ResendVerificationResponse) GetSuccess() bool   { return m.Success }
+func (m *ResendVerificationResponse) GetMessage() string { return m.Message }
+
+// UnlockUserRequest represents a request to lift a login lockout
+type UnlockUserRequest struct {
+	UserId     int64 `json:"user_id"`
+	UnlockedBy int64 `json:"unlocked_by"`
+}
+
+func (m *UnlockUserRequest) Reset()         { *m = UnlockUserRequest{} }
+func (m *UnlockUserRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*UnlockUserRequest) ProtoMessage()    {}
+
+func (m *UnlockUserRequest) GetUserId() int64     { return m.UserId }
+func (m *UnlockUserRequest) GetUnlockedBy() int64 { return m.UnlockedBy }
+
+// UnlockUserResponse represents unlock user response
+type UnlockUserResponse struct {
+	UserId    int64 `json:"user_id"`
+	WasLocked bool  `json:"was_locked"`
+}
+
+func (m *UnlockUserResponse) Reset()         { *m = UnlockUserResponse{} }
+func (m *UnlockUserResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*UnlockUserResponse) ProtoMessage()    {}
+
+func (m *UnlockUserResponse) GetUserId() int64   { return m.UserId }
+func (m *UnlockUserResponse) GetWasLocked() bool { return m.WasLocked }
+
+// LoginTwoFactorRequest represents the second step of a login; Code is a TOTP or recovery code
+type LoginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+func (m *LoginTwoFactorRequest) Reset()         { *m = LoginTwoFactorRequest{} }
+func (m *LoginTwoFactorRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*LoginTwoFactorRequest) ProtoMessage()    {}
+
+func (m *LoginTwoFactorRequest) GetChallengeToken() string { return m.ChallengeToken }
+func (m *LoginTwoFactorRequest) GetCode() string           { return m.Code }
+
+// SetupTwoFactorRequest represents a request to start a TOTP enrollment for the caller
+type SetupTwoFactorRequest struct {
+}
+
+func (m *SetupTwoFactorRequest) Reset()         { *m = SetupTwoFactorRequest{} }
+func (m *SetupTwoFactorRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*SetupTwoFactorRequest) ProtoMessage()    {}
+
+// SetupTwoFactorResponse represents setup two-factor response
+type SetupTwoFactorResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningUri string `json:"provisioning_uri"`
+}
+
+func (m *SetupTwoFactorResponse) Reset()         { *m = SetupTwoFactorResponse{} }
+func (m *SetupTwoFactorResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*SetupTwoFactorResponse) ProtoMessage()    {}
+
+func (m *SetupTwoFactorResponse) GetSecret() string          { return m.Secret }
+func (m *SetupTwoFactorResponse) GetProvisioningUri() string { return m.ProvisioningUri }
+
+// EnableTwoFactorRequest represents a request to confirm the caller's TOTP enrollment
+type EnableTwoFactorRequest struct {
+	Code string `json:"code"`
+}
+
+func (m *EnableTwoFactorRequest) Reset()         { *m = EnableTwoFactorRequest{} }
+func (m *EnableTwoFactorRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*EnableTwoFactorRequest) ProtoMessage()    {}
+
+func (m *EnableTwoFactorRequest) GetCode() string { return m.Code }
+
+// EnableTwoFactorResponse represents enable two-factor response
+type EnableTwoFactorResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (m *EnableTwoFactorResponse) Reset()         { *m = EnableTwoFactorResponse{} }
+func (m *EnableTwoFactorResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*EnableTwoFactorResponse) ProtoMessage()    {}
+
+func (m *EnableTwoFactorResponse) GetRecoveryCodes() []string { return m.RecoveryCodes }
+
+// DisableTwoFactorRequest represents a request to turn off the caller's two-factor authentication
+type DisableTwoFactorRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+func (m *DisableTwoFactorRequest) Reset()         { *m = DisableTwoFactorRequest{} }
+func (m *DisableTwoFactorRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*DisableTwoFactorRequest) ProtoMessage()    {}
+
+func (m *DisableTwoFactorRequest) GetPassword() string { return m.Password }
+func (m *DisableTwoFactorRequest) GetCode() string     { return m.Code }
+
+// DisableTwoFactorResponse represents disable two-factor response
+type DisableTwoFactorResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+func (m *DisableTwoFactorResponse) Reset()         { *m = DisableTwoFactorResponse{} }
+func (m *DisableTwoFactorResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*DisableTwoFactorResponse) ProtoMessage()    {}
+
+func (m *DisableTwoFactorResponse) GetSuccess() bool   { return m.Success }
+func (m *DisableTwoFactorResponse) GetMessage() string { return m.Message }
+
+// RequireTwoFactorRequest represents a request to make two-factor authentication mandatory for a user
+type RequireTwoFactorRequest struct {
+	UserId    int64 `json:"user_id"`
+	Required  bool  `json:"required"`
+	ChangedBy int64 `json:"changed_by"`
+}
+
+func (m *RequireTwoFactorRequest) Reset()         { *m = RequireTwoFactorRequest{} }
+func (m *RequireTwoFactorRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*RequireTwoFactorRequest) ProtoMessage()    {}
+
+func (m *RequireTwoFactorRequest) GetUserId() int64    { return m.UserId }
+func (m *RequireTwoFactorRequest) GetRequired() bool   { return m.Required }
+func (m *RequireTwoFactorRequest) GetChangedBy() int64 { return m.ChangedBy }
+
+// RequireTwoFactorResponse represents require two-factor response
+type RequireTwoFactorResponse struct {
+	UserId   int64 `json:"user_id"`
+	Required bool  `json:"required"`
+}
+
+func (m *RequireTwoFactorResponse) Reset()         { *m = RequireTwoFactorResponse{} }
+func (m *RequireTwoFactorResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*RequireTwoFactorResponse) ProtoMessage()    {}
+
+func (m *RequireTwoFactorResponse) GetUserId() int64  { return m.UserId }
+func (m *RequireTwoFactorResponse) GetRequired() bool { return m.Required }
+
+// UpdateProfileRequest represents a request to replace the caller's profile
+type UpdateProfileRequest struct {
+	FullName    string `json:"full_name"`
+	Phone       string `json:"phone"`
+	DateOfBirth string `json:"date_of_birth"`
+	Locale      string `json:"locale"`
+}
+
+func (m *UpdateProfileRequest) Reset()         { *m = UpdateProfileRequest{} }
+func (m *UpdateProfileRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*UpdateProfileRequest) ProtoMessage()    {}
+
+func (m *UpdateProfileRequest) GetFullName() string    { return m.FullName }
+func (m *UpdateProfileRequest) GetPhone() string       { return m.Phone }
+func (m *UpdateProfileRequest) GetDateOfBirth() string { return m.DateOfBirth }
+func (m *UpdateProfileRequest) GetLocale() string      { return m.Locale }
+
+// ChangeEmailRequest represents a request to move the caller's account to a new email address
+type ChangeEmailRequest struct {
+	Password string `json:"password"`
+	NewEmail string `json:"new_email"`
+}
+
+func (m *ChangeEmailRequest) Reset()         { *m = ChangeEmailRequest{} }
+func (m *ChangeEmailRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*ChangeEmailRequest) ProtoMessage()    {}
+
+func (m *ChangeEmailRequest) GetPassword() string { return m.Password }
+func (m *ChangeEmailRequest) GetNewEmail() string { return m.NewEmail }
+
+// ChangeEmailResponse represents change email response
+type ChangeEmailResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+func (m *ChangeEmailResponse) Reset()         { *m = ChangeEmailResponse{} }
+func (m *ChangeEmailResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*ChangeEmailResponse) ProtoMessage()    {}
+
+func (m *ChangeEmailResponse) GetSuccess() bool   { return m.Success }
+func (m *ChangeEmailResponse) GetMessage() string { return m.Message }
+
+// ChangePasswordRequest represents a request to change the caller's password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+func (m *ChangePasswordRequest) Reset()         { *m = ChangePasswordRequest{} }
+func (m *ChangePasswordRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*ChangePasswordRequest) ProtoMessage()    {}
+
+func (m *ChangePasswordRequest) GetCurrentPassword() string { return m.CurrentPassword }
+func (m *ChangePasswordRequest) GetNewPassword() string     { return m.NewPassword }
+
+// ChangePasswordResponse represents change password response with a new session
+type ChangePasswordResponse struct {
+	UserId       int64    `json:"user_id"`
+	Token        string   `json:"token"`
+	RefreshToken string   `json:"refresh_token"`
+	ExpiresIn    int64    `json:"expires_in"`
+	Roles        []string `json:"roles"`
+}
+
+func (m *ChangePasswordResponse) Reset()         { *m = ChangePasswordResponse{} }
+func (m *ChangePasswordResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*ChangePasswordResponse) ProtoMessage()    {}
+
+func (m *ChangePasswordResponse) GetUserId() int64        { return m.UserId }
+func (m *ChangePasswordResponse) GetToken() string        { return m.Token }
+func (m *ChangePasswordResponse) GetRefreshToken() string { return m.RefreshToken }
+func (m *ChangePasswordResponse) GetExpiresIn() int64     { return m.ExpiresIn }
+func (m *ChangePasswordResponse) GetRoles() []string      { return m.Roles }
+
+// DeleteAccountRequest represents a request to delete the caller's account
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+func (m *DeleteAccountRequest) Reset()         { *m = DeleteAccountRequest{} }
+func (m *DeleteAccountRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*DeleteAccountRequest) ProtoMessage()    {}
+
+func (m *DeleteAccountRequest) GetPassword() string { return m.Password }
+func (m *DeleteAccountRequest) GetCode() string     { return m.Code }
+
+// DeleteAccountResponse represents delete account response
+type DeleteAccountResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+func (m *DeleteAccountResponse) Reset()         { *m = DeleteAccountResponse{} }
+func (m *DeleteAccountResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*DeleteAccountResponse) ProtoMessage()    {}
+
+func (m *DeleteAccountResponse) GetSuccess() bool   { return m.Success }
+func (m *DeleteAccountResponse) GetMessage() string { return m.Message }
+
+// Traveller represents a co-traveller saved by the caller
+type Traveller struct {
+	Id            int64  `json:"id"`
+	FullName      string `json:"full_name"`
+	IdNumberHint  string `json:"id_number_hint"`
+	PassengerType string `json:"passenger_type"`
+	CreatedAt     string `json:"created_at"`
+}
+
+func (m *Traveller) Reset()         { *m = Traveller{} }
+func (m *Traveller) String() string { return fmt.Sprintf("%+v", *m) }
+func (*Traveller) ProtoMessage()    {}
+
+func (m *Traveller) GetId() int64             { return m.Id }
+func (m *Traveller) GetFullName() string      { return m.FullName }
+func (m *Traveller) GetIdNumberHint() string  { return m.IdNumberHint }
+func (m *Traveller) GetPassengerType() string { return m.PassengerType }
+func (m *Traveller) GetCreatedAt() string     { return m.CreatedAt }
+
+// ListTravellersRequest represents a request for the caller's saved travellers
+type ListTravellersRequest struct {
+}
+
+func (m *ListTravellersRequest) Reset()         { *m = ListTravellersRequest{} }
+func (m *ListTravellersRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*ListTravellersRequest) ProtoMessage()    {}
+
+// ListTravellersResponse represents list travellers response
+type ListTravellersResponse struct {
+	Travellers []*Traveller `json:"travellers"`
+}
+
+func (m *ListTravellersResponse) Reset()         { *m = ListTravellersResponse{} }
+func (m *ListTravellersResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*ListTravellersResponse) ProtoMessage()    {}
+
+func (m *ListTravellersResponse) GetTravellers() []*Traveller { return m.Travellers }
+
+// CreateTravellerRequest represents a request to save a traveller
+type CreateTravellerRequest struct {
+	FullName      string `json:"full_name"`
+	IdNumber      string `json:"id_number"`
+	PassengerType string `json:"passenger_type"`
+}
+
+func (m *CreateTravellerRequest) Reset()         { *m = CreateTravellerRequest{} }
+func (m *CreateTravellerRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*CreateTravellerRequest) ProtoMessage()    {}
+
+func (m *CreateTravellerRequest) GetFullName() string      { return m.FullName }
+func (m *CreateTravellerRequest) GetIdNumber() string      { return m.IdNumber }
+func (m *CreateTravellerRequest) GetPassengerType() string { return m.PassengerType }
+
+// UpdateTravellerRequest represents a request to change a saved traveller
+type UpdateTravellerRequest struct {
+	TravellerId   int64  `json:"traveller_id"`
+	FullName      string `json:"full_name"`
+	IdNumber      string `json:"id_number"`
+	PassengerType string `json:"passenger_type"`
+}
+
+func (m *UpdateTravellerRequest) Reset()         { *m = UpdateTravellerRequest{} }
+func (m *UpdateTravellerRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*UpdateTravellerRequest) ProtoMessage()    {}
+
+func (m *UpdateTravellerRequest) GetTravellerId() int64    { return m.TravellerId }
+func (m *UpdateTravellerRequest) GetFullName() string      { return m.FullName }
+func (m *UpdateTravellerRequest) GetIdNumber() string      { return m.IdNumber }
+func (m *UpdateTravellerRequest) GetPassengerType() string { return m.PassengerType }
+
+// DeleteTravellerRequest represents a request to remove a saved traveller
+type DeleteTravellerRequest struct {
+	TravellerId int64 `json:"traveller_id"`
+}
+
+func (m *DeleteTravellerRequest) Reset()         { *m = DeleteTravellerRequest{} }
+func (m *DeleteTravellerRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*DeleteTravellerRequest) ProtoMessage()    {}
+
+func (m *DeleteTravellerRequest) GetTravellerId() int64 { return m.TravellerId }
+
+// DeleteTravellerResponse represents delete traveller response
+type DeleteTravellerResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+func (m *DeleteTravellerResponse) Reset()         { *m = DeleteTravellerResponse{} }
+func (m *DeleteTravellerResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*DeleteTravellerResponse) ProtoMessage()    {}
+
+func (m *DeleteTravellerResponse) GetSuccess() bool   { return m.Success }
+func (m *DeleteTravellerResponse) GetMessage() string { return m.Message }
+
 // UserServiceClient is the client API for UserService service.
 type UserServiceClient interface {
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
@@ -158,6 +707,27 @@ type UserServiceClient interface {
 	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
 	ForgotPassword(ctx context.Context, in *ForgotPasswordRequest, opts ...grpc.CallOption) (*ForgotPasswordResponse, error)
 	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error)
+	GrantRole(ctx context.Context, in *GrantRoleRequest, opts ...grpc.CallOption) (*GrantRoleResponse, error)
+	RevokeRole(ctx context.Context, in *RevokeRoleRequest, opts ...grpc.CallOption) (*RevokeRoleResponse, error)
+	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
+	CheckSession(ctx context.Context, in *CheckSessionRequest, opts ...grpc.CallOption) (*CheckSessionResponse, error)
+	VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailResponse, error)
+	ResendVerification(ctx context.Context, in *ResendVerificationRequest, opts ...grpc.CallOption) (*ResendVerificationResponse, error)
+	UnlockUser(ctx context.Context, in *UnlockUserRequest, opts ...grpc.CallOption) (*UnlockUserResponse, error)
+	LoginTwoFactor(ctx context.Context, in *LoginTwoFactorRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	SetupTwoFactor(ctx context.Context, in *SetupTwoFactorRequest, opts ...grpc.CallOption) (*SetupTwoFactorResponse, error)
+	EnableTwoFactor(ctx context.Context, in *EnableTwoFactorRequest, opts ...grpc.CallOption) (*EnableTwoFactorResponse, error)
+	DisableTwoFactor(ctx context.Context, in *DisableTwoFactorRequest, opts ...grpc.CallOption) (*DisableTwoFactorResponse, error)
+	RequireTwoFactor(ctx context.Context, in *RequireTwoFactorRequest, opts ...grpc.CallOption) (*RequireTwoFactorResponse, error)
+	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
+	ChangeEmail(ctx context.Context, in *ChangeEmailRequest, opts ...grpc.CallOption) (*ChangeEmailResponse, error)
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error)
+	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error)
+	ListTravellers(ctx context.Context, in *ListTravellersRequest, opts ...grpc.CallOption) (*ListTravellersResponse, error)
+	CreateTraveller(ctx context.Context, in *CreateTravellerRequest, opts ...grpc.CallOption) (*Traveller, error)
+	UpdateTraveller(ctx context.Context, in *UpdateTravellerRequest, opts ...grpc.CallOption) (*Traveller, error)
+	DeleteTraveller(ctx context.Context, in *DeleteTravellerRequest, opts ...grpc.CallOption) (*DeleteTravellerResponse, error)
 }
 
 type userServiceClient struct {
@@ -213,83 +783,356 @@ func (c *userServiceClient) ResetPassword(ctx context.Context, in *ResetPassword
 	return out, nil
 }
 
-// UserServiceServer is the server API for UserService service.
-type UserServiceServer interface {
-	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
-	Login(context.Context, *LoginRequest) (*LoginResponse, error)
-	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
-	ForgotPassword(context.Context, *ForgotPasswordRequest) (*ForgotPasswordResponse, error)
-	ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error)
-	mustEmbedUnimplementedUserServiceServer()
+func (c *userServiceClient) GrantRole(ctx context.Context, in *GrantRoleRequest, opts ...grpc.CallOption) (*GrantRoleResponse, error) {
+	out := new(GrantRoleResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/GrantRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedUserServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedUserServiceServer struct {
+func (c *userServiceClient) RevokeRole(ctx context.Context, in *RevokeRoleRequest, opts ...grpc.CallOption) (*RevokeRoleResponse, error) {
+	out := new(RevokeRoleResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/RevokeRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (UnimplementedUserServiceServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
-}
-func (UnimplementedUserServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
-}
-func (UnimplementedUserServiceServer) GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
-}
-func (UnimplementedUserServiceServer) ForgotPassword(context.Context, *ForgotPasswordRequest) (*ForgotPasswordResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ForgotPassword not implemented")
-}
-func (UnimplementedUserServiceServer) ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+func (c *userServiceClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error) {
+	out := new(RefreshTokenResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/RefreshToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
-func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
-	s.RegisterService(&UserService_ServiceDesc, srv)
-}
-func _UserService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterRequest)
-	if err := dec(in); err != nil {
+func (c *userServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error) {
+	out := new(LogoutResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/Logout", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(UserServiceServer).Register(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/user.UserService/Register",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).Register(ctx, req.(*RegisterRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _UserService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoginRequest)
-	if err := dec(in); err != nil {
+func (c *userServiceClient) CheckSession(ctx context.Context, in *CheckSessionRequest, opts ...grpc.CallOption) (*CheckSessionResponse, error) {
+	out := new(CheckSessionResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/CheckSession", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(UserServiceServer).Login(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/user.UserService/Login",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).Login(ctx, req.(*LoginRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserRequest)
-	if err := dec(in); err != nil {
+func (c *userServiceClient) VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailResponse, error) {
+	out := new(VerifyEmailResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/VerifyEmail", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(UserServiceServer).GetUser(ctx, in)
+	return out, nil
+}
+
+func (c *userServiceClient) ResendVerification(ctx context.Context, in *ResendVerificationRequest, opts ...grpc.CallOption) (*ResendVerificationResponse, error) {
+	out := new(ResendVerificationResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/ResendVerification", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UnlockUser(ctx context.Context, in *UnlockUserRequest, opts ...grpc.CallOption) (*UnlockUserResponse, error) {
+	out := new(UnlockUserResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/UnlockUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) LoginTwoFactor(ctx context.Context, in *LoginTwoFactorRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/LoginTwoFactor", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetupTwoFactor(ctx context.Context, in *SetupTwoFactorRequest, opts ...grpc.CallOption) (*SetupTwoFactorResponse, error) {
+	out := new(SetupTwoFactorResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/SetupTwoFactor", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) EnableTwoFactor(ctx context.Context, in *EnableTwoFactorRequest, opts ...grpc.CallOption) (*EnableTwoFactorResponse, error) {
+	out := new(EnableTwoFactorResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/EnableTwoFactor", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DisableTwoFactor(ctx context.Context, in *DisableTwoFactorRequest, opts ...grpc.CallOption) (*DisableTwoFactorResponse, error) {
+	out := new(DisableTwoFactorResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/DisableTwoFactor", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RequireTwoFactor(ctx context.Context, in *RequireTwoFactorRequest, opts ...grpc.CallOption) (*RequireTwoFactorResponse, error) {
+	out := new(RequireTwoFactorResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/RequireTwoFactor", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*GetUserResponse, error) {
+	out := new(GetUserResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/UpdateProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ChangeEmail(ctx context.Context, in *ChangeEmailRequest, opts ...grpc.CallOption) (*ChangeEmailResponse, error) {
+	out := new(ChangeEmailResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/ChangeEmail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error) {
+	out := new(ChangePasswordResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/ChangePassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error) {
+	out := new(DeleteAccountResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/DeleteAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListTravellers(ctx context.Context, in *ListTravellersRequest, opts ...grpc.CallOption) (*ListTravellersResponse, error) {
+	out := new(ListTravellersResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/ListTravellers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateTraveller(ctx context.Context, in *CreateTravellerRequest, opts ...grpc.CallOption) (*Traveller, error) {
+	out := new(Traveller)
+	err := c.cc.Invoke(ctx, "/user.UserService/CreateTraveller", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateTraveller(ctx context.Context, in *UpdateTravellerRequest, opts ...grpc.CallOption) (*Traveller, error) {
+	out := new(Traveller)
+	err := c.cc.Invoke(ctx, "/user.UserService/UpdateTraveller", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteTraveller(ctx context.Context, in *DeleteTravellerRequest, opts ...grpc.CallOption) (*DeleteTravellerResponse, error) {
+	out := new(DeleteTravellerResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/DeleteTraveller", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+type UserServiceServer interface {
+	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
+	ForgotPassword(context.Context, *ForgotPasswordRequest) (*ForgotPasswordResponse, error)
+	ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error)
+	GrantRole(context.Context, *GrantRoleRequest) (*GrantRoleResponse, error)
+	RevokeRole(context.Context, *RevokeRoleRequest) (*RevokeRoleResponse, error)
+	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
+	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	CheckSession(context.Context, *CheckSessionRequest) (*CheckSessionResponse, error)
+	VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error)
+	ResendVerification(context.Context, *ResendVerificationRequest) (*ResendVerificationResponse, error)
+	UnlockUser(context.Context, *UnlockUserRequest) (*UnlockUserResponse, error)
+	LoginTwoFactor(context.Context, *LoginTwoFactorRequest) (*LoginResponse, error)
+	SetupTwoFactor(context.Context, *SetupTwoFactorRequest) (*SetupTwoFactorResponse, error)
+	EnableTwoFactor(context.Context, *EnableTwoFactorRequest) (*EnableTwoFactorResponse, error)
+	DisableTwoFactor(context.Context, *DisableTwoFactorRequest) (*DisableTwoFactorResponse, error)
+	RequireTwoFactor(context.Context, *RequireTwoFactorRequest) (*RequireTwoFactorResponse, error)
+	UpdateProfile(context.Context, *UpdateProfileRequest) (*GetUserResponse, error)
+	ChangeEmail(context.Context, *ChangeEmailRequest) (*ChangeEmailResponse, error)
+	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
+	DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error)
+	ListTravellers(context.Context, *ListTravellersRequest) (*ListTravellersResponse, error)
+	CreateTraveller(context.Context, *CreateTravellerRequest) (*Traveller, error)
+	UpdateTraveller(context.Context, *UpdateTravellerRequest) (*Traveller, error)
+	DeleteTraveller(context.Context, *DeleteTravellerRequest) (*DeleteTravellerResponse, error)
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedUserServiceServer struct {
+}
+
+func (UnimplementedUserServiceServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedUserServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedUserServiceServer) GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedUserServiceServer) ForgotPassword(context.Context, *ForgotPasswordRequest) (*ForgotPasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForgotPassword not implemented")
+}
+func (UnimplementedUserServiceServer) ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
+func (UnimplementedUserServiceServer) GrantRole(context.Context, *GrantRoleRequest) (*GrantRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantRole not implemented")
+}
+func (UnimplementedUserServiceServer) RevokeRole(context.Context, *RevokeRoleRequest) (*RevokeRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeRole not implemented")
+}
+func (UnimplementedUserServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedUserServiceServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedUserServiceServer) CheckSession(context.Context, *CheckSessionRequest) (*CheckSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckSession not implemented")
+}
+func (UnimplementedUserServiceServer) VerifyEmail(context.Context, *VerifyEmailRequest) (*VerifyEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
+}
+func (UnimplementedUserServiceServer) ResendVerification(context.Context, *ResendVerificationRequest) (*ResendVerificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResendVerification not implemented")
+}
+func (UnimplementedUserServiceServer) UnlockUser(context.Context, *UnlockUserRequest) (*UnlockUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockUser not implemented")
+}
+func (UnimplementedUserServiceServer) LoginTwoFactor(context.Context, *LoginTwoFactorRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginTwoFactor not implemented")
+}
+func (UnimplementedUserServiceServer) SetupTwoFactor(context.Context, *SetupTwoFactorRequest) (*SetupTwoFactorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetupTwoFactor not implemented")
+}
+func (UnimplementedUserServiceServer) EnableTwoFactor(context.Context, *EnableTwoFactorRequest) (*EnableTwoFactorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableTwoFactor not implemented")
+}
+func (UnimplementedUserServiceServer) DisableTwoFactor(context.Context, *DisableTwoFactorRequest) (*DisableTwoFactorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableTwoFactor not implemented")
+}
+func (UnimplementedUserServiceServer) RequireTwoFactor(context.Context, *RequireTwoFactorRequest) (*RequireTwoFactorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequireTwoFactor not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateProfile(context.Context, *UpdateProfileRequest) (*GetUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProfile not implemented")
+}
+func (UnimplementedUserServiceServer) ChangeEmail(context.Context, *ChangeEmailRequest) (*ChangeEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeEmail not implemented")
+}
+func (UnimplementedUserServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
+func (UnimplementedUserServiceServer) ListTravellers(context.Context, *ListTravellersRequest) (*ListTravellersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTravellers not implemented")
+}
+func (UnimplementedUserServiceServer) CreateTraveller(context.Context, *CreateTravellerRequest) (*Traveller, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTraveller not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateTraveller(context.Context, *UpdateTravellerRequest) (*Traveller, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTraveller not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteTraveller(context.Context, *DeleteTravellerRequest) (*DeleteTravellerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTraveller not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+func _UserService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/Register",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/Login",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
@@ -336,6 +1179,384 @@ func _UserService_ResetPassword_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GrantRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GrantRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/GrantRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GrantRole(ctx, req.(*GrantRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RevokeRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokeRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/RevokeRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokeRole(ctx, req.(*RevokeRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/RefreshToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/Logout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CheckSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CheckSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/CheckSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CheckSession(ctx, req.(*CheckSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).VerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/VerifyEmail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).VerifyEmail(ctx, req.(*VerifyEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ResendVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResendVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ResendVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/ResendVerification",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ResendVerification(ctx, req.(*ResendVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UnlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UnlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/UnlockUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UnlockUser(ctx, req.(*UnlockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_LoginTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).LoginTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/LoginTwoFactor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).LoginTwoFactor(ctx, req.(*LoginTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetupTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetupTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetupTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/SetupTwoFactor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetupTwoFactor(ctx, req.(*SetupTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_EnableTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).EnableTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/EnableTwoFactor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).EnableTwoFactor(ctx, req.(*EnableTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DisableTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DisableTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/DisableTwoFactor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DisableTwoFactor(ctx, req.(*DisableTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RequireTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequireTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RequireTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/RequireTwoFactor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RequireTwoFactor(ctx, req.(*RequireTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/UpdateProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateProfile(ctx, req.(*UpdateProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ChangeEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ChangeEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/ChangeEmail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ChangeEmail(ctx, req.(*ChangeEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/ChangePassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/DeleteAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListTravellers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTravellersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListTravellers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/ListTravellers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListTravellers(ctx, req.(*ListTravellersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateTraveller_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTravellerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateTraveller(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/CreateTraveller",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateTraveller(ctx, req.(*CreateTravellerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateTraveller_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTravellerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateTraveller(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/UpdateTraveller",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateTraveller(ctx, req.(*UpdateTravellerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteTraveller_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTravellerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteTraveller(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/DeleteTraveller",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteTraveller(ctx, req.(*DeleteTravellerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 var UserService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "user.UserService",
@@ -361,6 +1582,90 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ResetPassword",
 			Handler:    _UserService_ResetPassword_Handler,
 		},
+		{
+			MethodName: "GrantRole",
+			Handler:    _UserService_GrantRole_Handler,
+		},
+		{
+			MethodName: "RevokeRole",
+			Handler:    _UserService_RevokeRole_Handler,
+		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _UserService_RefreshToken_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _UserService_Logout_Handler,
+		},
+		{
+			MethodName: "CheckSession",
+			Handler:    _UserService_CheckSession_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _UserService_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "ResendVerification",
+			Handler:    _UserService_ResendVerification_Handler,
+		},
+		{
+			MethodName: "UnlockUser",
+			Handler:    _UserService_UnlockUser_Handler,
+		},
+		{
+			MethodName: "LoginTwoFactor",
+			Handler:    _UserService_LoginTwoFactor_Handler,
+		},
+		{
+			MethodName: "SetupTwoFactor",
+			Handler:    _UserService_SetupTwoFactor_Handler,
+		},
+		{
+			MethodName: "EnableTwoFactor",
+			Handler:    _UserService_EnableTwoFactor_Handler,
+		},
+		{
+			MethodName: "DisableTwoFactor",
+			Handler:    _UserService_DisableTwoFactor_Handler,
+		},
+		{
+			MethodName: "RequireTwoFactor",
+			Handler:    _UserService_RequireTwoFactor_Handler,
+		},
+		{
+			MethodName: "UpdateProfile",
+			Handler:    _UserService_UpdateProfile_Handler,
+		},
+		{
+			MethodName: "ChangeEmail",
+			Handler:    _UserService_ChangeEmail_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _UserService_ChangePassword_Handler,
+		},
+		{
+			MethodName: "DeleteAccount",
+			Handler:    _UserService_DeleteAccount_Handler,
+		},
+		{
+			MethodName: "ListTravellers",
+			Handler:    _UserService_ListTravellers_Handler,
+		},
+		{
+			MethodName: "CreateTraveller",
+			Handler:    _UserService_CreateTraveller_Handler,
+		},
+		{
+			MethodName: "UpdateTraveller",
+			Handler:    _UserService_UpdateTraveller_Handler,
+		},
+		{
+			MethodName: "DeleteTraveller",
+			Handler:    _UserService_DeleteTraveller_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "user.proto",
@@ -18,6 +18,11 @@ type Config struct {
 	DBUser      string
 	DBPassword  string
 	DBSSLMode   string
+	AgencyName  string
+	AgencyURL   string
+
+	// MinTurnaroundMinutes is the least gap a train needs between two runs.
+	MinTurnaroundMinutes int
 }
 
 func LoadEnv(prefix string) (*Config, error) {
@@ -33,6 +38,13 @@ func LoadEnv(prefix string) (*Config, error) {
 		return "", fmt.Errorf("missing env %s or %s", prefix+k, k)
 	}
 
+	getOpt := func(k, def string) string {
+		if v, err := getReq(k); err == nil {
+			return v
+		}
+		return def
+	}
+
 	name, err := getReq("SERVICE_NAME")
 	if err != nil {
 		return nil, err
@@ -74,6 +86,11 @@ func LoadEnv(prefix string) (*Config, error) {
 		return nil, err
 	}
 
+	minTurnaround, err := strconv.Atoi(getOpt("MIN_TURNAROUND_MINUTES", "30"))
+	if err != nil || minTurnaround < 0 {
+		return nil, fmt.Errorf("invalid MIN_TURNAROUND_MINUTES: %q", getOpt("MIN_TURNAROUND_MINUTES", ""))
+	}
+
 	return &Config{
 		ServiceName: name,
 		Port:        port,
@@ -84,6 +101,10 @@ func LoadEnv(prefix string) (*Config, error) {
 		DBUser:      dbUser,
 		DBPassword:  dbPass,
 		DBSSLMode:   sslMode,
+		AgencyName:  getOpt("GTFS_AGENCY_NAME", "Ticket Booking"),
+		AgencyURL:   getOpt("GTFS_AGENCY_URL", "http://localhost"),
+
+		MinTurnaroundMinutes: minTurnaround,
 	}, nil
 }
 
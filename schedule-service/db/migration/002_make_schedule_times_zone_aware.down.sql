@@ -0,0 +1,7 @@
+ALTER TABLE schedules
+    ALTER COLUMN departure_time TYPE TIMESTAMP USING departure_time AT TIME ZONE 'Asia/Jakarta',
+    ALTER COLUMN arrival_time TYPE TIMESTAMP USING arrival_time AT TIME ZONE 'Asia/Jakarta';
+
+ALTER TABLE schedules
+    DROP COLUMN IF EXISTS origin_timezone,
+    DROP COLUMN IF EXISTS destination_timezone;
\ No newline at end of file
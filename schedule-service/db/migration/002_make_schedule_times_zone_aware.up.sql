@@ -0,0 +1,8 @@
+ALTER TABLE schedules
+    ADD COLUMN origin_timezone VARCHAR(40) NOT NULL DEFAULT 'Asia/Jakarta',
+    ADD COLUMN destination_timezone VARCHAR(40) NOT NULL DEFAULT 'Asia/Jakarta';
+
+-- Existing rows hold WIB wall-clock time.
+ALTER TABLE schedules
+    ALTER COLUMN departure_time TYPE TIMESTAMPTZ USING departure_time AT TIME ZONE 'Asia/Jakarta',
+    ALTER COLUMN arrival_time TYPE TIMESTAMPTZ USING arrival_time AT TIME ZONE 'Asia/Jakarta';
\ No newline at end of file
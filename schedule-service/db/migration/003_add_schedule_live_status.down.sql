@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS schedule_status_events;
+
+ALTER TABLE schedules
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS delay_minutes,
+    DROP COLUMN IF EXISTS platform,
+    DROP COLUMN IF EXISTS status_updated_at;
\ No newline at end of file
@@ -0,0 +1,18 @@
+ALTER TABLE schedules
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'on_time',
+    ADD COLUMN delay_minutes INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN platform VARCHAR(20) NULL,
+    ADD COLUMN status_updated_at TIMESTAMPTZ NULL;
+
+CREATE TABLE IF NOT EXISTS schedule_status_events (
+    id BIGSERIAL PRIMARY KEY,
+    schedule_id BIGINT NOT NULL REFERENCES schedules(id),
+    status VARCHAR(20) NOT NULL,
+    delay_minutes INTEGER NOT NULL DEFAULT 0,
+    platform VARCHAR(20) NULL,
+    reason TEXT NULL,
+    updated_by BIGINT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_schedule_status_events_schedule_id ON schedule_status_events(schedule_id);
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_schedules_external_ref;
+
+ALTER TABLE schedules DROP COLUMN IF EXISTS external_ref;
+
+DROP TABLE IF EXISTS stations;
\ No newline at end of file
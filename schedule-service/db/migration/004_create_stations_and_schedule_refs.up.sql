@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS stations (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    timezone VARCHAR(40) NOT NULL DEFAULT 'Asia/Jakarta',
+    latitude DOUBLE PRECISION NULL,
+    longitude DOUBLE PRECISION NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_stations_name ON stations(name);
+
+-- Identifies schedules created from a GTFS trip so re-imports update them in place.
+ALTER TABLE schedules ADD COLUMN external_ref VARCHAR(150) NULL;
+
+CREATE UNIQUE INDEX idx_schedules_external_ref ON schedules(external_ref);
\ No newline at end of file
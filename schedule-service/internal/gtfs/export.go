@@ -0,0 +1,167 @@
+package gtfs
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Agency struct {
+	Name     string
+	URL      string
+	Timezone string
+}
+
+type ExportStop struct {
+	ID       string
+	Name     string
+	Timezone string
+	Lat      float64
+	Lon      float64
+}
+
+// ExportTrip is one schedule, written as a single dated trip with two stops.
+type ExportTrip struct {
+	ID                string
+	RouteID           string
+	RouteName         string
+	TrainName         string
+	OriginStopID      string
+	DestinationStopID string
+	Departure         time.Time
+	Arrival           time.Time
+}
+
+// ScheduleTripID is the trip id exported for a schedule that did not come
+// from a feed: its id and its service day, dated like imported refs so
+// Departures keeps it unchanged and ParseScheduleTripID finds the schedule.
+func ScheduleTripID(scheduleID int64, departure time.Time, loc *time.Location) string {
+	return fmt.Sprintf("S%d@%s", scheduleID, departure.In(loc).Format("2006-01-02"))
+}
+
+// ParseScheduleTripID returns the schedule id of a trip id written by
+// ScheduleTripID.
+func ParseScheduleTripID(ref string) (int64, bool) {
+	id, date, ok := strings.Cut(ref, "@")
+	if !ok || !strings.HasPrefix(id, "S") {
+		return 0, false
+	}
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(id[1:], 10, 64)
+	if err != nil || n <= 0 || id != fmt.Sprintf("S%d", n) {
+		return 0, false
+	}
+	return n, true
+}
+
+// Write builds a GTFS zip. Service days are emitted through calendar_dates.txt,
+// one service per date, since schedules are individually dated.
+func Write(agency Agency, stops []*ExportStop, trips []*ExportTrip) ([]byte, error) {
+	loc, err := time.LoadLocation(agency.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	write := func(name string, rows [][]string) error {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		cw := csv.NewWriter(w)
+		if err := cw.WriteAll(rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	}
+
+	if err := write("agency.txt", [][]string{
+		{"agency_id", "agency_name", "agency_url", "agency_timezone"},
+		{"1", agency.Name, agency.URL, agency.Timezone},
+	}); err != nil {
+		return nil, err
+	}
+
+	stopRows := [][]string{{"stop_id", "stop_name", "stop_lat", "stop_lon", "stop_timezone"}}
+	for _, s := range stops {
+		stopRows = append(stopRows, []string{
+			s.ID, s.Name,
+			strconv.FormatFloat(s.Lat, 'f', 6, 64),
+			strconv.FormatFloat(s.Lon, 'f', 6, 64),
+			s.Timezone,
+		})
+	}
+	if err := write("stops.txt", stopRows); err != nil {
+		return nil, err
+	}
+
+	routes := make(map[string]string)
+	services := make(map[string]bool)
+	tripRows := [][]string{{"route_id", "service_id", "trip_id", "trip_short_name"}}
+	timeRows := [][]string{{"trip_id", "arrival_time", "departure_time", "stop_id", "stop_sequence"}}
+	for _, t := range trips {
+		routes[t.RouteID] = t.RouteName
+
+		// Times are relative to midnight of the service day in the agency zone.
+		local := t.Departure.In(loc)
+		day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+		serviceID := day.Format("20060102")
+		services[serviceID] = true
+
+		dep := formatTime(t.Departure.Sub(day))
+		arr := formatTime(t.Arrival.Sub(day))
+		tripRows = append(tripRows, []string{t.RouteID, serviceID, t.ID, t.TrainName})
+		timeRows = append(timeRows,
+			[]string{t.ID, dep, dep, t.OriginStopID, "1"},
+			[]string{t.ID, arr, arr, t.DestinationStopID, "2"},
+		)
+	}
+
+	routeIDs := make([]string, 0, len(routes))
+	for id := range routes {
+		routeIDs = append(routeIDs, id)
+	}
+	sort.Strings(routeIDs)
+	routeRows := [][]string{{"route_id", "agency_id", "route_short_name", "route_long_name", "route_type"}}
+	for _, id := range routeIDs {
+		// route_type 2 is intercity rail
+		routeRows = append(routeRows, []string{id, "1", id, routes[id], "2"})
+	}
+
+	serviceIDs := make([]string, 0, len(services))
+	for id := range services {
+		serviceIDs = append(serviceIDs, id)
+	}
+	sort.Strings(serviceIDs)
+	dateRows := [][]string{{"service_id", "date", "exception_type"}}
+	for _, id := range serviceIDs {
+		dateRows = append(dateRows, []string{id, id, "1"})
+	}
+
+	if err := write("routes.txt", routeRows); err != nil {
+		return nil, err
+	}
+	if err := write("trips.txt", tripRows); err != nil {
+		return nil, err
+	}
+	if err := write("stop_times.txt", timeRows); err != nil {
+		return nil, err
+	}
+	if err := write("calendar_dates.txt", dateRows); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
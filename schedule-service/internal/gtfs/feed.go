@@ -0,0 +1,386 @@
+// Package gtfs reads and writes the subset of GTFS static feeds the timetable
+// uses: stops, trips, stop_times and service calendars.
+package gtfs
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTimezone applies when a feed carries no agency timezone.
+const DefaultTimezone = "Asia/Jakarta"
+
+// maxFileSize caps how much of one file in the archive is read, so a small
+// zip that inflates to gigabytes cannot exhaust memory.
+const maxFileSize = 64 << 20
+
+type Stop struct {
+	ID       string
+	Name     string
+	Timezone string
+	Lat      float64
+	Lon      float64
+}
+
+type Trip struct {
+	ID        string
+	RouteID   string
+	ServiceID string
+	ShortName string
+}
+
+type StopTime struct {
+	TripID    string
+	Sequence  int
+	StopID    string
+	Arrival   time.Duration
+	Departure time.Duration
+}
+
+type Service struct {
+	ID      string
+	Days    [7]bool // indexed by time.Weekday
+	Start   time.Time
+	End     time.Time
+	Added   map[string]bool // YYYYMMDD dates from calendar_dates.txt
+	Removed map[string]bool
+}
+
+func (s *Service) runsOn(day time.Time) bool {
+	date := day.Format("20060102")
+	if s.Added[date] {
+		return true
+	}
+	if s.Removed[date] {
+		return false
+	}
+	return !day.Before(s.Start) && !day.After(s.End) && s.Days[day.Weekday()]
+}
+
+type Feed struct {
+	AgencyTimezone string
+	Stops          map[string]*Stop
+	Trips          []*Trip
+	StopTimes      map[string][]*StopTime // by trip, ordered by stop_sequence
+	Services       map[string]*Service
+}
+
+// Parse reads a GTFS zip archive. agency.txt is optional and only consulted
+// for the timezone stop_times are expressed in.
+func Parse(archive []byte) (*Feed, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, errors.New("feed is not a valid zip archive")
+	}
+
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		// Some exporters nest the feed in a folder.
+		files[f.Name[strings.LastIndex(f.Name, "/")+1:]] = f
+	}
+
+	feed := &Feed{
+		AgencyTimezone: DefaultTimezone,
+		Stops:          make(map[string]*Stop),
+		StopTimes:      make(map[string][]*StopTime),
+		Services:       make(map[string]*Service),
+	}
+
+	if f, ok := files["agency.txt"]; ok {
+		err := readCSV(f, func(row record) error {
+			if tz := row.get("agency_timezone"); tz != "" {
+				feed.AgencyTimezone = tz
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range []string{"stops.txt", "trips.txt", "stop_times.txt"} {
+		if _, ok := files[name]; !ok {
+			return nil, fmt.Errorf("feed is missing %s", name)
+		}
+	}
+	_, hasCalendar := files["calendar.txt"]
+	_, hasCalendarDates := files["calendar_dates.txt"]
+	if !hasCalendar && !hasCalendarDates {
+		return nil, errors.New("feed is missing calendar.txt")
+	}
+
+	err = readCSV(files["stops.txt"], func(row record) error {
+		stop := &Stop{
+			ID:       row.get("stop_id"),
+			Name:     row.get("stop_name"),
+			Timezone: row.get("stop_timezone"),
+		}
+		if stop.ID == "" || stop.Name == "" {
+			return errors.New("stop_id and stop_name are required")
+		}
+		stop.Lat, _ = strconv.ParseFloat(row.get("stop_lat"), 64)
+		stop.Lon, _ = strconv.ParseFloat(row.get("stop_lon"), 64)
+		feed.Stops[stop.ID] = stop
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readCSV(files["trips.txt"], func(row record) error {
+		trip := &Trip{
+			ID:        row.get("trip_id"),
+			RouteID:   row.get("route_id"),
+			ServiceID: row.get("service_id"),
+			ShortName: row.get("trip_short_name"),
+		}
+		if trip.ID == "" || trip.ServiceID == "" {
+			return errors.New("trip_id and service_id are required")
+		}
+		feed.Trips = append(feed.Trips, trip)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readCSV(files["stop_times.txt"], func(row record) error {
+		st := &StopTime{TripID: row.get("trip_id"), StopID: row.get("stop_id")}
+		seq, err := strconv.Atoi(row.get("stop_sequence"))
+		if err != nil {
+			return errors.New("invalid stop_sequence")
+		}
+		st.Sequence = seq
+		if st.Arrival, err = parseTime(row.get("arrival_time")); err != nil {
+			return err
+		}
+		if st.Departure, err = parseTime(row.get("departure_time")); err != nil {
+			return err
+		}
+		feed.StopTimes[st.TripID] = append(feed.StopTimes[st.TripID], st)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, times := range feed.StopTimes {
+		sort.Slice(times, func(i, j int) bool { return times[i].Sequence < times[j].Sequence })
+	}
+
+	service := func(id string) *Service {
+		svc, ok := feed.Services[id]
+		if !ok {
+			svc = &Service{ID: id, Added: make(map[string]bool), Removed: make(map[string]bool)}
+			feed.Services[id] = svc
+		}
+		return svc
+	}
+
+	if hasCalendar {
+		err = readCSV(files["calendar.txt"], func(row record) error {
+			svc := service(row.get("service_id"))
+			days := []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+			for i, day := range days {
+				svc.Days[i] = row.get(day) == "1"
+			}
+			var err error
+			if svc.Start, err = time.Parse("20060102", row.get("start_date")); err != nil {
+				return errors.New("invalid start_date")
+			}
+			if svc.End, err = time.Parse("20060102", row.get("end_date")); err != nil {
+				return errors.New("invalid end_date")
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// calendar_dates.txt adds (1) or removes (2) single days from a service.
+	if hasCalendarDates {
+		err = readCSV(files["calendar_dates.txt"], func(row record) error {
+			svc := service(row.get("service_id"))
+			date := row.get("date")
+			if _, err := time.Parse("20060102", date); err != nil {
+				return errors.New("invalid date")
+			}
+			switch row.get("exception_type") {
+			case "1":
+				svc.Added[date] = true
+			case "2":
+				svc.Removed[date] = true
+			default:
+				return errors.New("invalid exception_type")
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return feed, nil
+}
+
+// Departure is one dated run of a trip, reduced to its first and last stop.
+type Departure struct {
+	Ref             string // trip_id@YYYY-MM-DD, stable across re-imports and exports
+	TripID          string
+	TrainName       string
+	OriginStop      *Stop
+	DestinationStop *Stop
+	Departure       time.Time
+	Arrival         time.Time
+}
+
+// Departures expands every trip over its service calendar between from and to
+// (inclusive dates). Trips that cannot be expanded are reported as warnings.
+func (f *Feed) Departures(from, to time.Time) ([]*Departure, []string, error) {
+	loc, err := time.LoadLocation(f.AgencyTimezone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unknown agency timezone %s", f.AgencyTimezone)
+	}
+
+	var departures []*Departure
+	var warnings []string
+	for _, trip := range f.Trips {
+		svc, ok := f.Services[trip.ServiceID]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("trip %s: unknown service %s", trip.ID, trip.ServiceID))
+			continue
+		}
+		times := f.StopTimes[trip.ID]
+		if len(times) < 2 {
+			warnings = append(warnings, fmt.Sprintf("trip %s: needs at least two stop times", trip.ID))
+			continue
+		}
+		first, last := times[0], times[len(times)-1]
+		origin, destination := f.Stops[first.StopID], f.Stops[last.StopID]
+		if origin == nil || destination == nil {
+			warnings = append(warnings, fmt.Sprintf("trip %s: references an unknown stop", trip.ID))
+			continue
+		}
+		if len(times) > 2 {
+			warnings = append(warnings, fmt.Sprintf("trip %s: %d intermediate stops are not imported", trip.ID, len(times)-2))
+		}
+
+		trainName := trip.ShortName
+		if trainName == "" {
+			trainName = trip.ID
+		}
+
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			if !svc.runsOn(day) {
+				continue
+			}
+			// GTFS times count from midnight of the service day in the agency zone.
+			midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+
+			// Feeds we exported already carry dated trip ids; keeping them
+			// as they are lets an exported feed import onto the same schedules.
+			ref := trip.ID
+			if suffix := "@" + day.Format("2006-01-02"); !strings.HasSuffix(ref, suffix) {
+				ref += suffix
+			}
+			departures = append(departures, &Departure{
+				Ref:             ref,
+				TripID:          trip.ID,
+				TrainName:       trainName,
+				OriginStop:      origin,
+				DestinationStop: destination,
+				Departure:       midnight.Add(first.Departure),
+				Arrival:         midnight.Add(last.Arrival),
+			})
+		}
+	}
+
+	return departures, warnings, nil
+}
+
+// parseTime reads HH:MM:SS, where hours may run past 24 for trips that
+// continue after midnight.
+func parseTime(value string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid GTFS time %q", value)
+	}
+	var total time.Duration
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid GTFS time %q", value)
+		}
+		total += time.Duration(n) * unit
+	}
+	return total, nil
+}
+
+func formatTime(d time.Duration) string {
+	secs := int(d / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", secs/3600, secs/60%60, secs%60)
+}
+
+type record struct {
+	header map[string]int
+	values []string
+}
+
+func (r record) get(column string) string {
+	i, ok := r.header[column]
+	if !ok || i >= len(r.values) {
+		return ""
+	}
+	return strings.TrimSpace(r.values[i])
+}
+
+func readCSV(f *zip.File, fn func(record) error) error {
+	if f.UncompressedSize64 > maxFileSize {
+		return fmt.Errorf("%s: file is larger than %d MB", f.Name, maxFileSize>>20)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	// The reader is capped as well, so the limit does not rest on archive/zip
+	// holding the file to its header size. One byte past the cap tells an
+	// oversized file from one that fits exactly.
+	limited := &io.LimitedReader{R: rc, N: maxFileSize + 1}
+	r := csv.NewReader(limited)
+	r.FieldsPerRecord = -1
+
+	head, err := r.Read()
+	if err != nil {
+		return fmt.Errorf("%s: %v", f.Name, err)
+	}
+	header := make(map[string]int, len(head))
+	for i, name := range head {
+		header[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+
+	for line := 2; ; line++ {
+		values, err := r.Read()
+		if limited.N <= 0 {
+			return fmt.Errorf("%s: file is larger than %d MB", f.Name, maxFileSize>>20)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", f.Name, err)
+		}
+		if err := fn(record{header: header, values: values}); err != nil {
+			return fmt.Errorf("%s line %d: %v", f.Name, line, err)
+		}
+	}
+}
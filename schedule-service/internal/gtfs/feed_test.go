@@ -0,0 +1,154 @@
+package gtfs
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	loc, err := time.LoadLocation(DefaultTimezone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(value string) time.Time {
+		ts, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+
+	tests := []struct {
+		name      string
+		id        string
+		departure time.Time
+		arrival   time.Time
+		wantRef   string
+	}{
+		{
+			name:      "imported trip keeps its ref",
+			id:        "ARGO-1@2026-11-02",
+			departure: at("2026-11-02 08:00"),
+			arrival:   at("2026-11-02 11:15"),
+			wantRef:   "ARGO-1@2026-11-02",
+		},
+		{
+			name:      "schedule exported by id",
+			id:        ScheduleTripID(42, at("2026-11-02 21:30"), loc),
+			departure: at("2026-11-02 21:30"),
+			arrival:   at("2026-11-03 04:10"),
+			wantRef:   "S42@2026-11-02",
+		},
+		{
+			name:      "departure just after midnight",
+			id:        ScheduleTripID(7, at("2026-11-03 00:05"), loc),
+			departure: at("2026-11-03 00:05"),
+			arrival:   at("2026-11-03 02:00"),
+			wantRef:   "S7@2026-11-03",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive, err := Write(Agency{Name: "KAI", URL: "https://example.com", Timezone: DefaultTimezone},
+				[]*ExportStop{
+					{ID: "GMR", Name: "Gambir", Timezone: DefaultTimezone},
+					{ID: "BD", Name: "Bandung", Timezone: DefaultTimezone},
+				},
+				[]*ExportTrip{{
+					ID: tt.id, RouteID: "T1", RouteName: "Argo", TrainName: "Argo",
+					OriginStopID: "GMR", DestinationStopID: "BD",
+					Departure: tt.departure, Arrival: tt.arrival,
+				}})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			feed, err := Parse(archive)
+			if err != nil {
+				t.Fatal(err)
+			}
+			departures, warnings, err := feed.Departures(at("2026-11-01 00:00"), at("2026-11-05 00:00"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(warnings) > 0 {
+				t.Errorf("warnings: %v", warnings)
+			}
+			if len(departures) != 1 {
+				t.Fatalf("got %d departures, want 1", len(departures))
+			}
+
+			d := departures[0]
+			if d.Ref != tt.wantRef {
+				t.Errorf("ref = %q, want %q", d.Ref, tt.wantRef)
+			}
+			if !d.Departure.Equal(tt.departure) || !d.Arrival.Equal(tt.arrival) {
+				t.Errorf("times = %s - %s, want %s - %s", d.Departure, d.Arrival, tt.departure, tt.arrival)
+			}
+			if d.OriginStop.ID != "GMR" || d.DestinationStop.ID != "BD" {
+				t.Errorf("stops = %s - %s, want GMR - BD", d.OriginStop.ID, d.DestinationStop.ID)
+			}
+		})
+	}
+}
+
+func TestParseScheduleTripID(t *testing.T) {
+	tests := []struct {
+		ref    string
+		want   int64
+		wantOK bool
+	}{
+		{"S42@2026-11-02", 42, true},
+		{"S42", 0, false},
+		{"S+42@2026-11-02", 0, false},
+		{"S042@2026-11-02", 0, false},
+		{"S0@2026-11-02", 0, false},
+		{"S42@tomorrow", 0, false},
+		{"ARGO-1@2026-11-02", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseScheduleTripID(tt.ref)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseScheduleTripID(%q) = %d, %v, want %d, %v", tt.ref, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestParseRejectsOversizedFiles(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"stops.txt":          "stop_id,stop_name\nGMR,Gambir\n",
+		"trips.txt":          "route_id,service_id,trip_id\nT1,S1,1\n",
+		"calendar_dates.txt": "service_id,date,exception_type\nS1,20261102,1\n",
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+
+	w, err := zw.Create("stop_times.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("trip_id,arrival_time,departure_time,stop_id,stop_sequence\n"))
+	row := []byte("1,08:00:00,08:00:00,GMR,1\n")
+	for n := 0; n <= maxFileSize/len(row); n++ {
+		w.Write(row)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Parse(buf.Bytes())
+	if err == nil || !strings.Contains(err.Error(), "larger than") {
+		t.Fatalf("Parse() error = %v, want a file size error", err)
+	}
+}
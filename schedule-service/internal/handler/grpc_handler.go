@@ -8,11 +8,15 @@ import (
 
 type GrpcServer struct {
 	pb.UnimplementedScheduleServiceServer
-	scheduleService service.ScheduleService
+	scheduleService  service.ScheduleService
+	timetableService service.TimetableService
 }
 
-func NewGrpcServer(scheduleService service.ScheduleService) *GrpcServer {
-	return &GrpcServer{scheduleService: scheduleService}
+func NewGrpcServer(scheduleService service.ScheduleService, timetableService service.TimetableService) *GrpcServer {
+	return &GrpcServer{
+		scheduleService:  scheduleService,
+		timetableService: timetableService,
+	}
 }
 
 func (s *GrpcServer) CreateSchedule(ctx context.Context, req *pb.CreateScheduleRequest) (*pb.CreateScheduleResponse, error) {
@@ -24,6 +28,19 @@ func (s *GrpcServer) CreateSchedule(ctx context.Context, req *pb.CreateScheduleR
 	return &pb.CreateScheduleResponse{Schedule: schedule}, nil
 }
 
+func (s *GrpcServer) UpdateSchedule(ctx context.Context, req *pb.UpdateScheduleRequest) (*pb.UpdateScheduleResponse, error) {
+	schedule, err := s.scheduleService.UpdateSchedule(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateScheduleResponse{Schedule: schedule}, nil
+}
+
+func (s *GrpcServer) ReassignTrain(ctx context.Context, req *pb.ReassignTrainRequest) (*pb.ReassignTrainResponse, error) {
+	return s.scheduleService.ReassignTrain(ctx, req)
+}
+
 func (s *GrpcServer) GetSchedule(ctx context.Context, req *pb.GetScheduleRequest) (*pb.GetScheduleResponse, error) {
 	schedule, err := s.scheduleService.GetSchedule(ctx, req.ScheduleId)
 	if err != nil {
@@ -34,7 +51,7 @@ func (s *GrpcServer) GetSchedule(ctx context.Context, req *pb.GetScheduleRequest
 }
 
 func (s *GrpcServer) ListSchedules(ctx context.Context, req *pb.ListSchedulesRequest) (*pb.ListSchedulesResponse, error) {
-	schedules, total, err := s.scheduleService.ListSchedules(ctx, req.Origin, req.Destination, req.DepartureDate, req.Page, req.Limit)
+	schedules, total, err := s.scheduleService.ListSchedules(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -44,3 +61,42 @@ func (s *GrpcServer) ListSchedules(ctx context.Context, req *pb.ListSchedulesReq
 		Total:     total,
 	}, nil
 }
+
+func (s *GrpcServer) SearchCalendar(ctx context.Context, req *pb.SearchCalendarRequest) (*pb.SearchCalendarResponse, error) {
+	days, err := s.scheduleService.SearchCalendar(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SearchCalendarResponse{Days: days}, nil
+}
+
+func (s *GrpcServer) UpdateScheduleStatus(ctx context.Context, req *pb.UpdateScheduleStatusRequest) (*pb.UpdateScheduleStatusResponse, error) {
+	schedule, err := s.scheduleService.UpdateScheduleStatus(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateScheduleStatusResponse{Schedule: schedule}, nil
+}
+
+func (s *GrpcServer) WatchSchedule(req *pb.WatchScheduleRequest, stream pb.ScheduleService_WatchScheduleServer) error {
+	return s.scheduleService.WatchSchedule(stream.Context(), req.ScheduleId, stream.Send)
+}
+
+func (s *GrpcServer) ImportGTFS(ctx context.Context, req *pb.ImportGTFSRequest) (*pb.ImportGTFSResponse, error) {
+	return s.timetableService.ImportGTFS(ctx, req)
+}
+
+func (s *GrpcServer) ExportGTFS(ctx context.Context, req *pb.ExportGTFSRequest) (*pb.ExportGTFSResponse, error) {
+	content, err := s.timetableService.ExportGTFS(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ExportGTFSResponse{
+		FileName:    "gtfs.zip",
+		ContentType: "application/zip",
+		Content:     content,
+	}, nil
+}
@@ -0,0 +1,12 @@
+package handler
+
+import "ticket-booking/proto/auth"
+
+// Permissions lists the RPCs that change the timetable; reading it stays open.
+var Permissions = auth.Rules{
+	"/schedule.ScheduleService/CreateSchedule":       {auth.RoleOperator},
+	"/schedule.ScheduleService/UpdateSchedule":       {auth.RoleOperator},
+	"/schedule.ScheduleService/UpdateScheduleStatus": {auth.RoleOperator},
+	"/schedule.ScheduleService/ReassignTrain":        {auth.RoleOperator},
+	"/schedule.ScheduleService/ImportGTFS":           {auth.RoleOperator},
+}
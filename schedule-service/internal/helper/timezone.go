@@ -0,0 +1,72 @@
+package helper
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	// Embedded so zone lookups do not depend on the container having tzdata.
+	_ "time/tzdata"
+)
+
+// DefaultTimezone is used for stations that do not specify a zone and matches
+// the WIB wall-clock time the schedules table held before it became zone-aware.
+const DefaultTimezone = "Asia/Jakarta"
+
+var zoneAliases = map[string]string{
+	"WIB":  "Asia/Jakarta",
+	"WITA": "Asia/Makassar",
+	"WIT":  "Asia/Jayapura",
+}
+
+var localLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+// NormalizeTimezone resolves WIB/WITA/WIT or an IANA name to the IANA name
+// stored with a schedule.
+func NormalizeTimezone(name string) (string, error) {
+	if name == "" {
+		return DefaultTimezone, nil
+	}
+	if iana, ok := zoneAliases[strings.ToUpper(name)]; ok {
+		return iana, nil
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return "", errors.New("unknown timezone " + name)
+	}
+	return name, nil
+}
+
+// ParseStationTime parses an RFC3339 timestamp as-is, or a timestamp without
+// an offset as wall-clock time at a station in the given zone.
+func ParseStationTime(value, timezone string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errors.New("invalid time " + value + ", expected RFC3339 or YYYY-MM-DDTHH:MM")
+}
+
+// FormatStationTime renders t as RFC3339 in the station's local zone, so the
+// offset on the wire always matches the station's clock.
+func FormatStationTime(t time.Time, timezone string) string {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return t.Format(time.RFC3339)
+	}
+	return t.In(loc).Format(time.RFC3339)
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ticket-booking/schedule-service/internal/helper"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ConflictTrainStatus = "train_status"
+	ConflictOverlap     = "overlap"
+	ConflictTurnaround  = "turnaround"
+	ConflictMaintenance = "maintenance"
+)
+
+// ScheduleSlot is the time a schedule would occupy its train. MinTurnaround
+// is the least gap required between two runs of the same train.
+type ScheduleSlot struct {
+	TrainId          int64
+	ExceptScheduleId int64
+	Departure        time.Time
+	Arrival          time.Time
+	Timezone         string
+	MinTurnaround    time.Duration
+}
+
+// Conflict is one reason a slot cannot be assigned. ScheduleId or WindowId
+// names the schedule or maintenance window that is in the way.
+type Conflict struct {
+	Kind       string
+	ScheduleId int64
+	WindowId   int64
+	Detail     string
+}
+
+// ScheduleConflictError rejects a schedule and lists every conflict found, so
+// an operator can fix them in one go.
+type ScheduleConflictError struct {
+	Conflicts []Conflict
+}
+
+func (e *ScheduleConflictError) Error() string {
+	details := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		details[i] = c.Detail
+	}
+	return "schedule conflicts: " + strings.Join(details, "; ")
+}
+
+// lockTrain locks the train row for the rest of the transaction so two
+// assignments to the same train are checked one after the other.
+func lockTrain(ctx context.Context, tx pgx.Tx, trainId int64) (string, error) {
+	var status string
+	err := tx.QueryRow(ctx, `
+		SELECT COALESCE(status, 'active') FROM trains
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE`, trainId).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrTrainNotFound
+	}
+	return status, err
+}
+
+// checkSlot collects every conflict of the slot: a train that is not active,
+// runs of the same train that overlap or leave too short a turnaround, and
+// maintenance windows. The train must already be locked with lockTrain.
+func checkSlot(ctx context.Context, tx pgx.Tx, slot ScheduleSlot, trainStatus string) error {
+	var conflicts []Conflict
+	at := func(t time.Time) string {
+		return helper.FormatStationTime(t, slot.Timezone)
+	}
+
+	if trainStatus != "active" {
+		conflicts = append(conflicts, Conflict{
+			Kind:   ConflictTrainStatus,
+			Detail: fmt.Sprintf("train %d is %s", slot.TrainId, trainStatus),
+		})
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, origin, destination, departure_time, arrival_time
+		FROM schedules
+		WHERE train_id = $1 AND id <> $2 AND deleted_at IS NULL AND status <> $5
+		  AND departure_time < $4::timestamptz + make_interval(mins => $6)
+		  AND arrival_time > $3::timestamptz - make_interval(mins => $6)
+		ORDER BY departure_time`,
+		slot.TrainId, slot.ExceptScheduleId, slot.Departure, slot.Arrival, StatusCancelled,
+		int(slot.MinTurnaround.Minutes()))
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var id int64
+		var origin, destination string
+		var departure, arrival time.Time
+		if err := rows.Scan(&id, &origin, &destination, &departure, &arrival); err != nil {
+			rows.Close()
+			return err
+		}
+
+		c := Conflict{ScheduleId: id}
+		if departure.Before(slot.Arrival) && arrival.After(slot.Departure) {
+			c.Kind = ConflictOverlap
+			c.Detail = fmt.Sprintf("train %d already runs schedule %d %s - %s from %s to %s",
+				slot.TrainId, id, origin, destination, at(departure), at(arrival))
+		} else {
+			c.Kind = ConflictTurnaround
+			c.Detail = fmt.Sprintf("schedule %d %s - %s (%s to %s) leaves less than the %d minute turnaround",
+				id, origin, destination, at(departure), at(arrival), int(slot.MinTurnaround.Minutes()))
+		}
+		conflicts = append(conflicts, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = tx.Query(ctx, `
+		SELECT id, starts_at, ends_at, COALESCE(reason, '')
+		FROM maintenance_windows
+		WHERE train_id = $1 AND deleted_at IS NULL AND starts_at < $3 AND ends_at > $2
+		ORDER BY starts_at`, slot.TrainId, slot.Departure, slot.Arrival)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var id int64
+		var startsAt, endsAt time.Time
+		var reason string
+		if err := rows.Scan(&id, &startsAt, &endsAt, &reason); err != nil {
+			rows.Close()
+			return err
+		}
+
+		detail := fmt.Sprintf("train %d is in maintenance from %s to %s", slot.TrainId, at(startsAt), at(endsAt))
+		if reason != "" {
+			detail += " (" + reason + ")"
+		}
+		conflicts = append(conflicts, Conflict{Kind: ConflictMaintenance, WindowId: id, Detail: detail})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(conflicts) > 0 {
+		return &ScheduleConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
@@ -2,16 +2,31 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
+
 	pb "ticket-booking/proto/schedule"
+	trainpb "ticket-booking/proto/train"
+	"ticket-booking/schedule-service/internal/helper"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var (
+	ErrScheduleNotFound = errors.New("schedule not found")
+	ErrTrainNotFound    = errors.New("train not found")
+)
+
 type ScheduleRepository interface {
-	Create(ctx context.Context, req *pb.CreateScheduleRequest) (*pb.Schedule, error)
+	Create(ctx context.Context, req *pb.CreateScheduleRequest, slot ScheduleSlot) (*pb.Schedule, error)
+	Update(ctx context.Context, req *pb.UpdateScheduleRequest, slot ScheduleSlot) (*pb.Schedule, error)
 	GetByID(ctx context.Context, id int64) (*pb.Schedule, error)
-	List(ctx context.Context, origin, destination, departureDate string, page, limit int32) ([]*pb.Schedule, int32, error)
+	List(ctx context.Context, search ScheduleSearch) ([]*pb.Schedule, int32, error)
+	Calendar(ctx context.Context, origin, destination string, from, to time.Time) ([]*pb.CalendarDay, error)
+	UpdateStatus(ctx context.Context, req *pb.UpdateScheduleStatusRequest) (*pb.Schedule, error)
+	Reassign(ctx context.Context, scheduleId, trainId int64, minTurnaround time.Duration, dryRun bool) (*pb.ReassignTrainResponse, error)
 }
 
 type scheduleRepository struct {
@@ -22,106 +37,261 @@ func NewScheduleRepository(db *pgxpool.Pool) ScheduleRepository {
 	return &scheduleRepository{db: db}
 }
 
-func (r *scheduleRepository) Create(ctx context.Context, req *pb.CreateScheduleRequest) (*pb.Schedule, error) {
-	var id int64
-	var trainName string
+const (
+	StatusOnTime    = "on_time"
+	StatusDelayed   = "delayed"
+	StatusCancelled = "cancelled"
+)
 
-	// Get train name
-	err := r.db.QueryRow(ctx, `SELECT name FROM trains WHERE id = $1`, req.TrainId).Scan(&trainName)
+// scheduleColumns is the select list read back by scanSchedule.
+const scheduleColumns = `s.id, s.train_id, t.name, s.origin, s.destination,
+		       s.departure_time, s.arrival_time, s.price, s.available_seats,
+		       s.origin_timezone, s.destination_timezone,
+		       s.status, s.delay_minutes, s.platform, ` + trainpb.AmenitiesSQL
+
+// Create inserts the schedule once the slot has been checked against the
+// train's other runs and maintenance, both under the train's row lock.
+func (r *scheduleRepository) Create(ctx context.Context, req *pb.CreateScheduleRequest, slot ScheduleSlot) (*pb.Schedule, error) {
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer tx.Rollback(ctx)
 
-	err = r.db.QueryRow(ctx, `
-		INSERT INTO schedules (train_id, origin, destination, departure_time, arrival_time, price, available_seats, created_at) 
-		VALUES ($1, $2, $3, $4, $5, $6, (SELECT capacity FROM trains WHERE id = $1), NOW()) RETURNING id`,
-		req.TrainId, req.Origin, req.Destination, req.DepartureTime, req.ArrivalTime, req.Price).Scan(&id)
+	trainStatus, err := lockTrain(ctx, tx, req.TrainId)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkSlot(ctx, tx, slot, trainStatus); err != nil {
+		return nil, err
+	}
 
-	// Get available seats from train capacity
-	var availableSeats int32
-	err = r.db.QueryRow(ctx, `SELECT capacity FROM trains WHERE id = $1`, req.TrainId).Scan(&availableSeats)
+	var id int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO schedules (train_id, origin, destination, departure_time, arrival_time, price, available_seats,
+		                       origin_timezone, destination_timezone, created_at) 
+		VALUES ($1, $2, $3, $4, $5, $6, (SELECT capacity FROM trains WHERE id = $1), $7, $8, NOW()) RETURNING id`,
+		req.TrainId, req.Origin, req.Destination, req.DepartureTime, req.ArrivalTime, req.Price,
+		req.OriginTimezone, req.DestinationTimezone).Scan(&id)
 	if err != nil {
-		availableSeats = 0
+		return nil, err
 	}
 
-	return &pb.Schedule{
-		Id:             id,
-		TrainId:        req.TrainId,
-		TrainName:      trainName,
-		Origin:         req.Origin,
-		Destination:    req.Destination,
-		DepartureTime:  req.DepartureTime,
-		ArrivalTime:    req.ArrivalTime,
-		Price:          req.Price,
-		AvailableSeats: availableSeats,
-	}, nil
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
 }
 
-func (r *scheduleRepository) GetByID(ctx context.Context, id int64) (*pb.Schedule, error) {
-	var schedule pb.Schedule
-	var trainName string
+func (r *scheduleRepository) Update(ctx context.Context, req *pb.UpdateScheduleRequest, slot ScheduleSlot) (*pb.Schedule, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
 
-	err := r.db.QueryRow(ctx, `
-		SELECT s.id, s.train_id, t.name, s.origin, s.destination, 
-		       s.departure_time, s.arrival_time, s.price, s.available_seats
-		FROM schedules s
-		LEFT JOIN trains t ON s.train_id = t.id
-		WHERE s.id = $1 AND s.deleted_at IS NULL`, id).Scan(
-		&schedule.Id, &schedule.TrainId, &trainName, &schedule.Origin, &schedule.Destination,
-		&schedule.DepartureTime, &schedule.ArrivalTime, &schedule.Price, &schedule.AvailableSeats)
+	trainStatus, err := lockTrain(ctx, tx, slot.TrainId)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkSlot(ctx, tx, slot, trainStatus); err != nil {
+		return nil, err
+	}
 
-	schedule.TrainName = trainName
-	return &schedule, nil
+	tag, err := tx.Exec(ctx, `
+		UPDATE schedules
+		SET origin = $2, destination = $3, departure_time = $4, arrival_time = $5, price = $6,
+		    origin_timezone = $7, destination_timezone = $8, updated_at = NOW()
+		WHERE id = $1 AND train_id = $9 AND deleted_at IS NULL`,
+		req.ScheduleId, req.Origin, req.Destination, req.DepartureTime, req.ArrivalTime, req.Price,
+		req.OriginTimezone, req.DestinationTimezone, slot.TrainId)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, ErrScheduleNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, req.ScheduleId)
 }
 
-func (r *scheduleRepository) List(ctx context.Context, origin, destination, departureDate string, page, limit int32) ([]*pb.Schedule, int32, error) {
-	offset := (page - 1) * limit
+// Reassign puts another train on a schedule. The new train must be free for
+// the schedule's times and seat every ticket sold; booked seats are then moved
+// onto its layout with mapSeats. Bookings that cannot be re-seated keep their
+// ticket without a seat and are reported. With dryRun nothing is written.
+func (r *scheduleRepository) Reassign(ctx context.Context, scheduleId, trainId int64, minTurnaround time.Duration, dryRun bool) (*pb.ReassignTrainResponse, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
 
-	query := `
-		SELECT s.id, s.train_id, t.name, s.origin, s.destination, 
-		       s.departure_time, s.arrival_time, s.price, s.available_seats
-		FROM schedules s
-		LEFT JOIN trains t ON s.train_id = t.id
-		WHERE s.deleted_at IS NULL`
+	trainStatus, err := lockTrain(ctx, tx, trainId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Locking the schedule also holds off new bookings until the seats moved.
+	var currentTrainId int64
+	var departure, arrival time.Time
+	var timezone, status string
+	err = tx.QueryRow(ctx, `
+		SELECT train_id, departure_time, arrival_time, origin_timezone, status
+		FROM schedules WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE`, scheduleId).Scan(&currentTrainId, &departure, &arrival, &timezone, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if status == StatusCancelled {
+		return nil, errors.New("cannot reassign a cancelled schedule")
+	}
+	if currentTrainId == trainId {
+		return nil, errors.New("schedule already runs on this train")
+	}
+
+	err = checkSlot(ctx, tx, ScheduleSlot{
+		TrainId:          trainId,
+		ExceptScheduleId: scheduleId,
+		Departure:        departure,
+		Arrival:          arrival,
+		Timezone:         timezone,
+		MinTurnaround:    minTurnaround,
+	}, trainStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &pb.ReassignTrainResponse{DryRun: dryRun}
+	err = tx.QueryRow(ctx, `SELECT capacity FROM trains WHERE id = $1`, trainId).Scan(&result.Capacity)
+	if err != nil {
+		return nil, err
+	}
+	err = tx.QueryRow(ctx, `
+		SELECT COALESCE(SUM(seat_count), 0) FROM bookings
+		WHERE schedule_id = $1 AND status IN (1, 2) AND deleted_at IS NULL`, scheduleId).Scan(&result.SoldSeats)
+	if err != nil {
+		return nil, err
+	}
+	if result.Capacity < result.SoldSeats {
+		return nil, fmt.Errorf("train %d has %d seats but %d are already sold", trainId, result.Capacity, result.SoldSeats)
+	}
+
+	booked, err := bookedSeats(ctx, tx, scheduleId, currentTrainId)
+	if err != nil {
+		return nil, err
+	}
+	seats, err := trainSeats(ctx, tx, trainId)
+	if err != nil {
+		return nil, err
+	}
+	result.Moves, result.Unseated = mapSeats(booked, seats)
+
+	if dryRun {
+		result.Schedule, err = r.GetByID(ctx, scheduleId)
+		return result, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE schedules SET train_id = $2, available_seats = $3, updated_at = NOW()
+		WHERE id = $1`, scheduleId, trainId, result.Capacity-result.SoldSeats)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM booking_seats WHERE schedule_id = $1`, scheduleId); err != nil {
+		return nil, err
+	}
+	for _, m := range result.Moves {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO booking_seats (booking_id, schedule_id, coach_number, seat_number, class, created_at)
+			VALUES ($1, $2, $3, $4, $5, NOW())`,
+			m.BookingId, scheduleId, m.ToCoach, m.ToSeat, m.Class)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	args := []interface{}{}
-	argCount := 0
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	result.Schedule, err = r.GetByID(ctx, scheduleId)
+	return result, err
+}
+
+// bookedSeats lists the seats held on a schedule with their position in the
+// old train's layout.
+func bookedSeats(ctx context.Context, tx pgx.Tx, scheduleId, trainId int64) ([]BookedSeat, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT bs.booking_id, b.booking_code, bs.class, bs.coach_number, bs.seat_number,
+		       COALESCE(cs.seat_row, 0), COALESCE(cs.seat_column, 0)
+		FROM booking_seats bs
+		JOIN bookings b ON b.id = bs.booking_id
+		LEFT JOIN coach_seats cs ON cs.train_id = $2
+		     AND cs.coach_number = bs.coach_number AND cs.seat_number = bs.seat_number
+		WHERE bs.schedule_id = $1
+		ORDER BY bs.booking_id, bs.id`, scheduleId, trainId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	if origin != "" {
-		argCount++
-		query += fmt.Sprintf(" AND s.origin ILIKE $%d", argCount)
-		args = append(args, "%"+origin+"%")
+	var seats []BookedSeat
+	for rows.Next() {
+		var s BookedSeat
+		if err := rows.Scan(&s.BookingId, &s.BookingCode, &s.Class, &s.CoachNumber, &s.SeatNumber, &s.Row, &s.Column); err != nil {
+			return nil, err
+		}
+		seats = append(seats, s)
 	}
+	return seats, rows.Err()
+}
 
-	if destination != "" {
-		argCount++
-		query += fmt.Sprintf(" AND s.destination ILIKE $%d", argCount)
-		args = append(args, "%"+destination+"%")
+func trainSeats(ctx context.Context, tx pgx.Tx, trainId int64) ([]TrainSeat, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT coach_number, class, seat_number, seat_row, seat_column
+		FROM coach_seats WHERE train_id = $1
+		ORDER BY coach_number, seat_row, seat_column`, trainId)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	if departureDate != "" {
-		argCount++
-		query += fmt.Sprintf(" AND DATE(s.departure_time) = $%d", argCount)
-		args = append(args, departureDate)
+	var seats []TrainSeat
+	for rows.Next() {
+		var s TrainSeat
+		if err := rows.Scan(&s.CoachNumber, &s.Class, &s.SeatNumber, &s.Row, &s.Column); err != nil {
+			return nil, err
+		}
+		seats = append(seats, s)
 	}
+	return seats, rows.Err()
+}
 
-	query += " ORDER BY s.departure_time ASC"
+func (r *scheduleRepository) GetByID(ctx context.Context, id int64) (*pb.Schedule, error) {
+	row := r.db.QueryRow(ctx, `
+		SELECT `+scheduleColumns+`
+		FROM schedules s
+		LEFT JOIN trains t ON s.train_id = t.id
+		WHERE s.id = $1 AND s.deleted_at IS NULL`, id)
 
-	argCount++
-	query += fmt.Sprintf(" LIMIT $%d", argCount)
-	args = append(args, limit)
+	return scanSchedule(row)
+}
+
+func (r *scheduleRepository) List(ctx context.Context, search ScheduleSearch) ([]*pb.Schedule, int32, error) {
+	where, args := search.where()
+
+	query := `
+		SELECT ` + scheduleColumns + scheduleSearchFrom + where + search.orderBy()
 
-	argCount++
-	query += fmt.Sprintf(" OFFSET $%d", argCount)
-	args = append(args, offset)
+	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	pageArgs := append(append([]interface{}{}, args...), search.Limit, (search.Page-1)*search.Limit)
 
-	rows, err := r.db.Query(ctx, query, args...)
+	rows, err := r.db.Query(ctx, query, pageArgs...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -129,47 +299,131 @@ func (r *scheduleRepository) List(ctx context.Context, origin, destination, depa
 
 	var schedules []*pb.Schedule
 	for rows.Next() {
-		var schedule pb.Schedule
-		var trainName string
-
-		err := rows.Scan(&schedule.Id, &schedule.TrainId, &trainName, &schedule.Origin, &schedule.Destination,
-			&schedule.DepartureTime, &schedule.ArrivalTime, &schedule.Price, &schedule.AvailableSeats)
+		schedule, err := scanSchedule(rows)
 		if err != nil {
 			return nil, 0, err
 		}
-
-		schedule.TrainName = trainName
-		schedules = append(schedules, &schedule)
+		schedules = append(schedules, schedule)
 	}
 
 	// Count total
-	countQuery := `SELECT COUNT(*) FROM schedules s WHERE s.deleted_at IS NULL`
-	countArgs := []interface{}{}
-	countArgCount := 0
+	var total int32
+	err = r.db.QueryRow(ctx, "SELECT COUNT(*)"+scheduleSearchFrom+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return schedules, total, nil
+}
 
-	if origin != "" {
-		countArgCount++
-		countQuery += fmt.Sprintf(" AND s.origin ILIKE $%d", countArgCount)
-		countArgs = append(countArgs, "%"+origin+"%")
+func (r *scheduleRepository) UpdateStatus(ctx context.Context, req *pb.UpdateScheduleStatusRequest) (*pb.Schedule, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// An empty platform keeps the one already announced.
+	tag, err := tx.Exec(ctx, `
+		UPDATE schedules
+		SET status = $2, delay_minutes = $3, platform = COALESCE(NULLIF($4, ''), platform),
+		    status_updated_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL`,
+		req.ScheduleId, req.Status, req.DelayMinutes, req.Platform)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, pgx.ErrNoRows
 	}
 
-	if destination != "" {
-		countArgCount++
-		countQuery += fmt.Sprintf(" AND s.destination ILIKE $%d", countArgCount)
-		countArgs = append(countArgs, "%"+destination+"%")
+	_, err = tx.Exec(ctx, `
+		INSERT INTO schedule_status_events (schedule_id, status, delay_minutes, platform, reason, updated_by, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, 0), NOW())`,
+		req.ScheduleId, req.Status, req.DelayMinutes, req.Platform, req.Reason, req.UpdatedBy)
+	if err != nil {
+		return nil, err
 	}
 
-	if departureDate != "" {
-		countArgCount++
-		countQuery += fmt.Sprintf(" AND DATE(s.departure_time) = $%d", countArgCount)
-		countArgs = append(countArgs, departureDate)
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
 	}
 
-	var total int32
-	err = r.db.QueryRow(ctx, countQuery, countArgs...).Scan(&total)
+	return r.GetByID(ctx, req.ScheduleId)
+}
+
+func (r *scheduleRepository) Calendar(ctx context.Context, origin, destination string, from, to time.Time) ([]*pb.CalendarDay, error) {
+	// generate_series keeps days without any departure in the result so the
+	// calendar has no gaps; the cheapest fare only counts schedules with seats left.
+	// Days are the origin station's local days.
+	rows, err := r.db.Query(ctx, `
+		SELECT d::date, MIN(s.price) FILTER (WHERE s.available_seats > 0),
+		       COALESCE(SUM(s.available_seats), 0), COUNT(s.id)
+		FROM generate_series($1::date, $2::date, INTERVAL '1 day') AS d
+		LEFT JOIN schedules s ON DATE(s.departure_time AT TIME ZONE s.origin_timezone) = d::date
+		     AND s.deleted_at IS NULL
+		     AND s.origin ILIKE $3
+		     AND s.destination ILIKE $4
+		GROUP BY d
+		ORDER BY d ASC`,
+		from, to, containsPattern(origin), containsPattern(destination))
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
+	defer rows.Close()
 
-	return schedules, total, nil
+	var days []*pb.CalendarDay
+	for rows.Next() {
+		var day pb.CalendarDay
+		var date time.Time
+		var minPrice *float64
+
+		if err := rows.Scan(&date, &minPrice, &day.AvailableSeats, &day.ScheduleCount); err != nil {
+			return nil, err
+		}
+
+		day.Date = date.Format("2006-01-02")
+		if minPrice != nil {
+			day.MinPrice = *minPrice
+		}
+		days = append(days, &day)
+	}
+
+	return days, rows.Err()
+}
+
+// scanSchedule reads one row of scheduleColumns. Departure and arrival are
+// rendered in their own station's zone; the duration comes from the absolute
+// instants so it stays correct when a trip crosses WIB/WITA/WIT.
+func scanSchedule(row pgx.Row) (*pb.Schedule, error) {
+	var schedule pb.Schedule
+	var trainName, platform *string
+	var departure, arrival time.Time
+
+	err := row.Scan(&schedule.Id, &schedule.TrainId, &trainName, &schedule.Origin, &schedule.Destination,
+		&departure, &arrival, &schedule.Price, &schedule.AvailableSeats,
+		&schedule.OriginTimezone, &schedule.DestinationTimezone,
+		&schedule.Status, &schedule.DelayMinutes, &platform, &schedule.Amenities)
+	if err != nil {
+		return nil, err
+	}
+
+	if trainName != nil {
+		schedule.TrainName = *trainName
+	}
+	if platform != nil {
+		schedule.Platform = *platform
+	}
+	schedule.DepartureTime = helper.FormatStationTime(departure, schedule.OriginTimezone)
+	schedule.ArrivalTime = helper.FormatStationTime(arrival, schedule.DestinationTimezone)
+	schedule.DurationMinutes = int32(arrival.Sub(departure).Minutes())
+
+	// A cancelled train has no estimate; otherwise the delay shifts both ends.
+	if schedule.Status != StatusCancelled {
+		delay := time.Duration(schedule.DelayMinutes) * time.Minute
+		schedule.EstimatedDepartureTime = helper.FormatStationTime(departure.Add(delay), schedule.OriginTimezone)
+		schedule.EstimatedArrivalTime = helper.FormatStationTime(arrival.Add(delay), schedule.DestinationTimezone)
+	}
+
+	return &schedule, nil
 }
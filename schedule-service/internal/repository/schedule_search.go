@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	trainpb "ticket-booking/proto/train"
+)
+
+const (
+	SortByDeparture = "departure"
+	SortByArrival   = "arrival"
+	SortByDuration  = "duration"
+	SortByPrice     = "price"
+)
+
+// scheduleSortColumns whitelists the expressions a search may be ordered by,
+// so the sort key never reaches the SQL text as user input.
+var scheduleSortColumns = map[string]string{
+	SortByDeparture: "s.departure_time",
+	SortByArrival:   "s.arrival_time",
+	SortByDuration:  "(s.arrival_time - s.departure_time)",
+	SortByPrice:     "s.price",
+}
+
+// IsValidScheduleSort reports whether sortBy names a supported sort key.
+func IsValidScheduleSort(sortBy string) bool {
+	_, ok := scheduleSortColumns[sortBy]
+	return ok
+}
+
+// ScheduleSearch is the typed form of a schedule search. Zero values mean
+// "no filter"; the page query and the COUNT query are both built from the
+// same where clause so they cannot drift apart.
+type ScheduleSearch struct {
+	Origin             string
+	Destination        string
+	DepartureDate      string // YYYY-MM-DD, origin station local day
+	DepartureTimeFrom  string // HH:MM origin local time, inclusive
+	DepartureTimeTo    string // HH:MM origin local time, inclusive
+	TrainType          string
+	MaxPrice           float64
+	MinAvailableSeats  int32
+	MaxDurationMinutes int32
+	Amenities          []string // all must be offered by the train or one of its coaches
+	SortBy             string
+	Descending         bool
+	Page               int32
+	Limit              int32
+}
+
+const scheduleSearchFrom = `
+		FROM schedules s
+		LEFT JOIN trains t ON s.train_id = t.id`
+
+func (q *ScheduleSearch) where() (string, []interface{}) {
+	conds := []string{"s.deleted_at IS NULL"}
+	var args []interface{}
+
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if q.Origin != "" {
+		add("s.origin ILIKE $%d", containsPattern(q.Origin))
+	}
+	if q.Destination != "" {
+		add("s.destination ILIKE $%d", containsPattern(q.Destination))
+	}
+	if q.DepartureDate != "" {
+		add("DATE(s.departure_time AT TIME ZONE s.origin_timezone) = $%d", q.DepartureDate)
+	}
+	if q.DepartureTimeFrom != "" {
+		add("(s.departure_time AT TIME ZONE s.origin_timezone)::time >= $%d::time", q.DepartureTimeFrom)
+	}
+	if q.DepartureTimeTo != "" {
+		add("(s.departure_time AT TIME ZONE s.origin_timezone)::time <= $%d::time", q.DepartureTimeTo)
+	}
+	if q.TrainType != "" {
+		add("LOWER(t.type) = LOWER($%d)", q.TrainType)
+	}
+	if q.MaxPrice > 0 {
+		add("s.price <= $%d", q.MaxPrice)
+	}
+	if q.MinAvailableSeats > 0 {
+		add("s.available_seats >= $%d", q.MinAvailableSeats)
+	}
+	if q.MaxDurationMinutes > 0 {
+		add("s.arrival_time - s.departure_time <= make_interval(mins => $%d)", q.MaxDurationMinutes)
+	}
+	if len(q.Amenities) > 0 {
+		add("$%d::text[] <@ "+trainpb.AmenitiesSQL, q.Amenities)
+	}
+
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// likeEscaper escapes the LIKE wildcards, and the backslash that escapes
+// them, so user input only ever matches itself.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern is the ILIKE pattern matching any text that contains s.
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+func (q *ScheduleSearch) orderBy() string {
+	column, ok := scheduleSortColumns[q.SortBy]
+	if !ok {
+		column = scheduleSortColumns[SortByDeparture]
+	}
+
+	direction := "ASC"
+	if q.Descending {
+		direction = "DESC"
+	}
+
+	// s.id breaks ties so paging stays stable between requests.
+	return fmt.Sprintf(" ORDER BY %s %s, s.id ASC", column, direction)
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+
+	pb "ticket-booking/proto/schedule"
+)
+
+// BookedSeat is a seat held by a booking on the train being replaced. Row
+// and Column are zero when the old layout no longer has the seat.
+type BookedSeat struct {
+	BookingId   int64
+	BookingCode string
+	Class       string
+	CoachNumber int32
+	SeatNumber  string
+	Row         int32
+	Column      int32
+}
+
+// TrainSeat is a seat of the replacement train.
+type TrainSeat struct {
+	CoachNumber int32
+	Class       string
+	SeatNumber  string
+	Row         int32
+	Column      int32
+}
+
+// mapSeats moves booked seats onto the new train's layout. Bookings keep
+// their class, and a booking is either re-seated as a whole or not at all,
+// earliest bookings first. Each seat keeps its coach and seat number if the
+// new train has it in the same class, then its seat number in another coach
+// of the class, and otherwise takes the nearest free seat of the class.
+func mapSeats(booked []BookedSeat, seats []TrainSeat) ([]*pb.SeatMove, []*pb.UnseatedBooking) {
+	free := make(map[string][]TrainSeat)
+	for _, s := range seats {
+		free[s.Class] = append(free[s.Class], s)
+	}
+
+	// Group seats per booking, in the order bookings were made.
+	var order []int64
+	byBooking := make(map[int64][]BookedSeat)
+	for _, b := range booked {
+		if _, ok := byBooking[b.BookingId]; !ok {
+			order = append(order, b.BookingId)
+		}
+		byBooking[b.BookingId] = append(byBooking[b.BookingId], b)
+	}
+	sort.Slice(order, func(i, j int) bool { return order[i] < order[j] })
+
+	// Decide up front which bookings fit so the passes below never run out
+	// of seats halfway through a booking.
+	var unseated []*pb.UnseatedBooking
+	left := make(map[string]int)
+	for class, s := range free {
+		left[class] = len(s)
+	}
+	var pending []BookedSeat
+	for _, id := range order {
+		group := byBooking[id]
+		need := make(map[string]int)
+		for _, b := range group {
+			need[b.Class]++
+		}
+		var reason string
+		for class, n := range need {
+			switch {
+			case len(free[class]) == 0:
+				reason = fmt.Sprintf("the new train has no %s seats", class)
+			case left[class] < n:
+				reason = fmt.Sprintf("not enough %s seats left on the new train", class)
+			}
+		}
+		if reason != "" {
+			unseated = append(unseated, &pb.UnseatedBooking{
+				BookingId:   id,
+				BookingCode: group[0].BookingCode,
+				Class:       group[0].Class,
+				SeatCount:   int32(len(group)),
+				Reason:      reason,
+			})
+			continue
+		}
+		for class, n := range need {
+			left[class] -= n
+		}
+		pending = append(pending, group...)
+	}
+
+	taken := make(map[string]bool)
+	key := func(s TrainSeat) string { return fmt.Sprintf("%d-%s", s.CoachNumber, s.SeatNumber) }
+	target := make([]*TrainSeat, len(pending))
+	place := func(match func(b BookedSeat, s TrainSeat) bool) {
+		for i, b := range pending {
+			if target[i] != nil {
+				continue
+			}
+			for j := range free[b.Class] {
+				s := &free[b.Class][j]
+				if !taken[key(*s)] && match(b, *s) {
+					taken[key(*s)] = true
+					target[i] = s
+					break
+				}
+			}
+		}
+	}
+	place(func(b BookedSeat, s TrainSeat) bool {
+		return s.CoachNumber == b.CoachNumber && s.SeatNumber == b.SeatNumber
+	})
+	place(func(b BookedSeat, s TrainSeat) bool {
+		return s.SeatNumber == b.SeatNumber
+	})
+	for i, b := range pending {
+		if target[i] != nil {
+			continue
+		}
+		var best *TrainSeat
+		for j := range free[b.Class] {
+			s := &free[b.Class][j]
+			if !taken[key(*s)] && (best == nil || seatDistance(b, *s) < seatDistance(b, *best)) {
+				best = s
+			}
+		}
+		taken[key(*best)] = true
+		target[i] = best
+	}
+
+	moves := make([]*pb.SeatMove, len(pending))
+	for i, b := range pending {
+		moves[i] = &pb.SeatMove{
+			BookingId:   b.BookingId,
+			BookingCode: b.BookingCode,
+			Class:       b.Class,
+			FromCoach:   b.CoachNumber,
+			FromSeat:    b.SeatNumber,
+			ToCoach:     target[i].CoachNumber,
+			ToSeat:      target[i].SeatNumber,
+		}
+	}
+	return moves, unseated
+}
+
+// seatDistance orders candidate seats by coach, then row, then column, so a
+// passenger stays as close as possible to where they were.
+func seatDistance(b BookedSeat, s TrainSeat) int {
+	abs := func(n int32) int {
+		if n < 0 {
+			return int(-n)
+		}
+		return int(n)
+	}
+	return abs(s.CoachNumber-b.CoachNumber)*10000 + abs(s.Row-b.Row)*100 + abs(s.Column-b.Column)
+}
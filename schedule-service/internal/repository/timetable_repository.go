@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Station struct {
+	ID        int64
+	Code      string
+	Name      string
+	Timezone  string
+	Latitude  float64
+	Longitude float64
+}
+
+type NewTrain struct {
+	Name     string
+	Type     string
+	Capacity int32
+}
+
+// TimetableEntry is a schedule as seen by GTFS import and export. An entry
+// with an ID is an existing schedule; importing it updates that schedule and
+// stamps it with Ref.
+type TimetableEntry struct {
+	ID                  int64
+	Ref                 string
+	TrainID             int64
+	TrainName           string
+	Origin              string
+	Destination         string
+	OriginTimezone      string
+	DestinationTimezone string
+	Departure           time.Time
+	Arrival             time.Time
+	Price               float64
+}
+
+// ImportPlan is everything an import writes. Schedules without an ID are
+// matched on Ref; a zero TrainID is resolved by TrainName, including trains
+// created by the plan.
+type ImportPlan struct {
+	Stations  []*Station
+	Trains    []*NewTrain
+	Schedules []*TimetableEntry
+}
+
+type TimetableRepository interface {
+	Stations(ctx context.Context) ([]*Station, error)
+	TrainIDsByName(ctx context.Context) (map[string]int64, error)
+	EntriesByRef(ctx context.Context, refs []string) (map[string]*TimetableEntry, error)
+	EntriesByID(ctx context.Context, ids []int64) (map[int64]*TimetableEntry, error)
+	Entries(ctx context.Context, from, to time.Time) ([]*TimetableEntry, error)
+	ApplyImport(ctx context.Context, plan *ImportPlan, minTurnaround time.Duration, dryRun bool) error
+}
+
+type timetableRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTimetableRepository(db *pgxpool.Pool) TimetableRepository {
+	return &timetableRepository{db: db}
+}
+
+func (r *timetableRepository) Stations(ctx context.Context) ([]*Station, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, code, name, timezone, COALESCE(latitude, 0), COALESCE(longitude, 0)
+		FROM stations ORDER BY code`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stations []*Station
+	for rows.Next() {
+		var s Station
+		if err := rows.Scan(&s.ID, &s.Code, &s.Name, &s.Timezone, &s.Latitude, &s.Longitude); err != nil {
+			return nil, err
+		}
+		stations = append(stations, &s)
+	}
+
+	return stations, rows.Err()
+}
+
+func (r *timetableRepository) TrainIDsByName(ctx context.Context) (map[string]int64, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, name FROM trains WHERE deleted_at IS NULL`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make(map[string]int64)
+	for rows.Next() {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		ids[name] = id
+	}
+
+	return ids, rows.Err()
+}
+
+func (r *timetableRepository) EntriesByRef(ctx context.Context, refs []string) (map[string]*TimetableEntry, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+timetableColumns+`
+		FROM schedules s
+		LEFT JOIN trains t ON s.train_id = t.id
+		WHERE s.external_ref = ANY($1) AND s.deleted_at IS NULL`, refs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make(map[string]*TimetableEntry)
+	for rows.Next() {
+		e, err := scanTimetableEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries[e.Ref] = e
+	}
+
+	return entries, rows.Err()
+}
+
+// EntriesByID finds the schedules that were not imported from a feed, which
+// an exported feed names by id.
+func (r *timetableRepository) EntriesByID(ctx context.Context, ids []int64) (map[int64]*TimetableEntry, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+timetableColumns+`
+		FROM schedules s
+		LEFT JOIN trains t ON s.train_id = t.id
+		WHERE s.id = ANY($1) AND s.external_ref IS NULL AND s.deleted_at IS NULL`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make(map[int64]*TimetableEntry)
+	for rows.Next() {
+		e, err := scanTimetableEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries[e.ID] = e
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *timetableRepository) Entries(ctx context.Context, from, to time.Time) ([]*TimetableEntry, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+timetableColumns+`
+		FROM schedules s
+		LEFT JOIN trains t ON s.train_id = t.id
+		WHERE s.deleted_at IS NULL AND s.departure_time >= $1 AND s.departure_time < $2
+		ORDER BY s.departure_time, s.id`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*TimetableEntry
+	for rows.Next() {
+		e, err := scanTimetableEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+// ApplyImport writes the plan in one transaction. Every schedule goes through
+// the same train lock and conflict checks as one created by hand, and all
+// conflicts are returned together. With dryRun the checks run but nothing is
+// written.
+func (r *timetableRepository) ApplyImport(ctx context.Context, plan *ImportPlan, minTurnaround time.Duration, dryRun bool) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, s := range plan.Stations {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO stations (code, name, timezone, latitude, longitude, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+			ON CONFLICT (code) DO UPDATE
+			SET name = EXCLUDED.name, timezone = EXCLUDED.timezone,
+			    latitude = EXCLUDED.latitude, longitude = EXCLUDED.longitude, updated_at = NOW()`,
+			s.Code, s.Name, s.Timezone, s.Latitude, s.Longitude)
+		if err != nil {
+			return err
+		}
+	}
+
+	trainIDs := make(map[string]int64)
+	for _, t := range plan.Trains {
+		var id int64
+		err := tx.QueryRow(ctx, `
+			INSERT INTO trains (name, type, capacity, status, created_at)
+			VALUES ($1, $2, $3, 'active', NOW()) RETURNING id`,
+			t.Name, t.Type, t.Capacity).Scan(&id)
+		if err != nil {
+			return err
+		}
+		trainIDs[t.Name] = id
+	}
+
+	var conflicts []Conflict
+	for _, e := range plan.Schedules {
+		trainID := e.TrainID
+		if trainID == 0 {
+			trainID = trainIDs[e.TrainName]
+		}
+
+		trainStatus, err := lockTrain(ctx, tx, trainID)
+		if err != nil {
+			return fmt.Errorf("schedule %s: %w", e.Ref, err)
+		}
+		err = checkSlot(ctx, tx, ScheduleSlot{
+			TrainId:          trainID,
+			ExceptScheduleId: e.ID,
+			Departure:        e.Departure,
+			Arrival:          e.Arrival,
+			Timezone:         e.OriginTimezone,
+			MinTurnaround:    minTurnaround,
+		}, trainStatus)
+		var conflictErr *ScheduleConflictError
+		if errors.As(err, &conflictErr) {
+			for _, c := range conflictErr.Conflicts {
+				c.Detail = fmt.Sprintf("schedule %s: %s", e.Ref, c.Detail)
+				conflicts = append(conflicts, c)
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		// An existing schedule keeps its train, so its seats stay as booked.
+		if e.ID != 0 {
+			_, err := tx.Exec(ctx, `
+				UPDATE schedules
+				SET origin = $2, destination = $3, departure_time = $4, arrival_time = $5,
+				    origin_timezone = $6, destination_timezone = $7, external_ref = $8, updated_at = NOW()
+				WHERE id = $1 AND train_id = $9 AND deleted_at IS NULL`,
+				e.ID, e.Origin, e.Destination, e.Departure, e.Arrival,
+				e.OriginTimezone, e.DestinationTimezone, e.Ref, trainID)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Seats are only set on insert; an update must not undo bookings.
+		_, err = tx.Exec(ctx, `
+			INSERT INTO schedules (train_id, origin, destination, departure_time, arrival_time, price, available_seats,
+			                       origin_timezone, destination_timezone, external_ref, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, (SELECT capacity FROM trains WHERE id = $1), $7, $8, $9, NOW())
+			ON CONFLICT (external_ref) DO UPDATE
+			SET train_id = EXCLUDED.train_id, origin = EXCLUDED.origin, destination = EXCLUDED.destination,
+			    departure_time = EXCLUDED.departure_time, arrival_time = EXCLUDED.arrival_time,
+			    origin_timezone = EXCLUDED.origin_timezone, destination_timezone = EXCLUDED.destination_timezone,
+			    updated_at = NOW()`,
+			trainID, e.Origin, e.Destination, e.Departure, e.Arrival, e.Price,
+			e.OriginTimezone, e.DestinationTimezone, e.Ref)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return &ScheduleConflictError{Conflicts: conflicts}
+	}
+	if dryRun {
+		return nil
+	}
+	return tx.Commit(ctx)
+}
+
+const timetableColumns = `s.id, COALESCE(s.external_ref, ''), s.train_id, COALESCE(t.name, ''),
+		       s.origin, s.destination, s.origin_timezone, s.destination_timezone,
+		       s.departure_time, s.arrival_time, s.price`
+
+func scanTimetableEntry(row pgx.Row) (*TimetableEntry, error) {
+	var e TimetableEntry
+	err := row.Scan(&e.ID, &e.Ref, &e.TrainID, &e.TrainName,
+		&e.Origin, &e.Destination, &e.OriginTimezone, &e.DestinationTimezone,
+		&e.Departure, &e.Arrival, &e.Price)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
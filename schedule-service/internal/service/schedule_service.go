@@ -2,38 +2,325 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
+
 	pb "ticket-booking/proto/schedule"
+	"ticket-booking/schedule-service/internal/helper"
 	"ticket-booking/schedule-service/internal/repository"
 )
 
 type ScheduleService interface {
 	CreateSchedule(ctx context.Context, req *pb.CreateScheduleRequest) (*pb.Schedule, error)
+	UpdateSchedule(ctx context.Context, req *pb.UpdateScheduleRequest) (*pb.Schedule, error)
 	GetSchedule(ctx context.Context, id int64) (*pb.Schedule, error)
-	ListSchedules(ctx context.Context, origin, destination, departureDate string, page, limit int32) ([]*pb.Schedule, int32, error)
+	ListSchedules(ctx context.Context, req *pb.ListSchedulesRequest) ([]*pb.Schedule, int32, error)
+	SearchCalendar(ctx context.Context, req *pb.SearchCalendarRequest) ([]*pb.CalendarDay, error)
+	UpdateScheduleStatus(ctx context.Context, req *pb.UpdateScheduleStatusRequest) (*pb.Schedule, error)
+	WatchSchedule(ctx context.Context, scheduleId int64, send func(*pb.ScheduleStatusUpdate) error) error
+	ReassignTrain(ctx context.Context, req *pb.ReassignTrainRequest) (*pb.ReassignTrainResponse, error)
 }
 
 type scheduleService struct {
-	scheduleRepo repository.ScheduleRepository
+	scheduleRepo  repository.ScheduleRepository
+	statusHub     *statusHub
+	minTurnaround time.Duration
 }
 
-func NewScheduleService(scheduleRepo repository.ScheduleRepository) ScheduleService {
-	return &scheduleService{scheduleRepo: scheduleRepo}
+func NewScheduleService(scheduleRepo repository.ScheduleRepository, minTurnaround time.Duration) ScheduleService {
+	return &scheduleService{
+		scheduleRepo:  scheduleRepo,
+		statusHub:     newStatusHub(),
+		minTurnaround: minTurnaround,
+	}
 }
 
 func (s *scheduleService) CreateSchedule(ctx context.Context, req *pb.CreateScheduleRequest) (*pb.Schedule, error) {
-	return s.scheduleRepo.Create(ctx, req)
+	trip, err := parseTrip(req.DepartureTime, req.ArrivalTime, req.OriginTimezone, req.DestinationTimezone)
+	if err != nil {
+		return nil, err
+	}
+
+	req.OriginTimezone = trip.originTz
+	req.DestinationTimezone = trip.destinationTz
+	req.DepartureTime = trip.departure.Format(time.RFC3339)
+	req.ArrivalTime = trip.arrival.Format(time.RFC3339)
+
+	return s.scheduleRepo.Create(ctx, req, repository.ScheduleSlot{
+		TrainId:       req.TrainId,
+		Departure:     trip.departure,
+		Arrival:       trip.arrival,
+		Timezone:      trip.originTz,
+		MinTurnaround: s.minTurnaround,
+	})
+}
+
+// UpdateSchedule moves or re-prices a schedule on its current train; the new
+// times go through the same conflict checks as a new schedule.
+func (s *scheduleService) UpdateSchedule(ctx context.Context, req *pb.UpdateScheduleRequest) (*pb.Schedule, error) {
+	current, err := s.scheduleRepo.GetByID(ctx, req.ScheduleId)
+	if err != nil {
+		return nil, errors.New("schedule not found")
+	}
+	if req.Origin == "" || req.Destination == "" {
+		return nil, errors.New("origin and destination are required")
+	}
+	if req.Price < 0 {
+		return nil, errors.New("price must not be negative")
+	}
+
+	if req.OriginTimezone == "" {
+		req.OriginTimezone = current.OriginTimezone
+	}
+	if req.DestinationTimezone == "" {
+		req.DestinationTimezone = current.DestinationTimezone
+	}
+	trip, err := parseTrip(req.DepartureTime, req.ArrivalTime, req.OriginTimezone, req.DestinationTimezone)
+	if err != nil {
+		return nil, err
+	}
+
+	req.OriginTimezone = trip.originTz
+	req.DestinationTimezone = trip.destinationTz
+	req.DepartureTime = trip.departure.Format(time.RFC3339)
+	req.ArrivalTime = trip.arrival.Format(time.RFC3339)
+
+	return s.scheduleRepo.Update(ctx, req, repository.ScheduleSlot{
+		TrainId:          current.TrainId,
+		ExceptScheduleId: current.Id,
+		Departure:        trip.departure,
+		Arrival:          trip.arrival,
+		Timezone:         trip.originTz,
+		MinTurnaround:    s.minTurnaround,
+	})
+}
+
+// ReassignTrain swaps the train running a schedule, for instance after a
+// breakdown, and moves booked seats onto the replacement's layout.
+func (s *scheduleService) ReassignTrain(ctx context.Context, req *pb.ReassignTrainRequest) (*pb.ReassignTrainResponse, error) {
+	if req.ScheduleId <= 0 {
+		return nil, errors.New("schedule id is required")
+	}
+	if req.TrainId <= 0 {
+		return nil, errors.New("train id is required")
+	}
+
+	return s.scheduleRepo.Reassign(ctx, req.ScheduleId, req.TrainId, s.minTurnaround, req.DryRun)
 }
 
 func (s *scheduleService) GetSchedule(ctx context.Context, id int64) (*pb.Schedule, error) {
 	return s.scheduleRepo.GetByID(ctx, id)
 }
 
-func (s *scheduleService) ListSchedules(ctx context.Context, origin, destination, departureDate string, page, limit int32) ([]*pb.Schedule, int32, error) {
-	if page <= 0 {
-		page = 1
+func (s *scheduleService) ListSchedules(ctx context.Context, req *pb.ListSchedulesRequest) ([]*pb.Schedule, int32, error) {
+	search := repository.ScheduleSearch{
+		Origin:             req.Origin,
+		Destination:        req.Destination,
+		DepartureDate:      req.DepartureDate,
+		DepartureTimeFrom:  req.DepartureTimeFrom,
+		DepartureTimeTo:    req.DepartureTimeTo,
+		TrainType:          req.TrainType,
+		MaxPrice:           req.MaxPrice,
+		MinAvailableSeats:  req.MinAvailableSeats,
+		MaxDurationMinutes: req.MaxDurationMinutes,
+		SortBy:             req.SortBy,
+		Page:               req.Page,
+		Limit:              req.Limit,
+	}
+
+	if search.Page <= 0 {
+		search.Page = 1
+	}
+	if search.Limit <= 0 {
+		search.Limit = 10
+	}
+	if search.Limit > 100 {
+		search.Limit = 100
+	}
+
+	for _, a := range req.Amenities {
+		if a = strings.ToLower(strings.TrimSpace(a)); a != "" {
+			search.Amenities = append(search.Amenities, a)
+		}
+	}
+
+	if search.DepartureDate != "" {
+		if _, err := time.Parse("2006-01-02", search.DepartureDate); err != nil {
+			return nil, 0, errors.New("departure date must be in YYYY-MM-DD format")
+		}
+	}
+	for _, t := range []string{search.DepartureTimeFrom, search.DepartureTimeTo} {
+		if t == "" {
+			continue
+		}
+		if _, err := time.Parse("15:04", t); err != nil {
+			return nil, 0, errors.New("departure time window must be in HH:MM format")
+		}
+	}
+	if search.MaxPrice < 0 || search.MinAvailableSeats < 0 || search.MaxDurationMinutes < 0 {
+		return nil, 0, errors.New("price, seat and duration filters must not be negative")
+	}
+
+	if search.SortBy == "" {
+		search.SortBy = repository.SortByDeparture
+	}
+	if !repository.IsValidScheduleSort(search.SortBy) {
+		return nil, 0, errors.New("invalid sort_by, expected departure, arrival, duration or price")
+	}
+
+	switch req.SortOrder {
+	case "", "asc":
+	case "desc":
+		search.Descending = true
+	default:
+		return nil, 0, errors.New("invalid sort_order, expected asc or desc")
+	}
+
+	return s.scheduleRepo.List(ctx, search)
+}
+
+func (s *scheduleService) SearchCalendar(ctx context.Context, req *pb.SearchCalendarRequest) ([]*pb.CalendarDay, error) {
+	if req.Origin == "" || req.Destination == "" {
+		return nil, errors.New("origin and destination are required")
+	}
+
+	var from, to time.Time
+	switch {
+	case req.Month != "":
+		month, err := time.Parse("2006-01", req.Month)
+		if err != nil {
+			return nil, errors.New("month must be in YYYY-MM format")
+		}
+		from = month
+		to = month.AddDate(0, 1, -1)
+	case req.DepartureDate != "":
+		date, err := time.Parse("2006-01-02", req.DepartureDate)
+		if err != nil {
+			return nil, errors.New("departure date must be in YYYY-MM-DD format")
+		}
+		window := int(req.WindowDays)
+		if window <= 0 {
+			window = 3
+		}
+		if window > 15 {
+			window = 15
+		}
+		from = date.AddDate(0, 0, -window)
+		to = date.AddDate(0, 0, window)
+	default:
+		return nil, errors.New("either month or departure date is required")
+	}
+
+	return s.scheduleRepo.Calendar(ctx, req.Origin, req.Destination, from, to)
+}
+
+func (s *scheduleService) UpdateScheduleStatus(ctx context.Context, req *pb.UpdateScheduleStatusRequest) (*pb.Schedule, error) {
+	switch req.Status {
+	case repository.StatusDelayed:
+		if req.DelayMinutes <= 0 {
+			return nil, errors.New("a delayed status needs a positive delay in minutes")
+		}
+	case repository.StatusOnTime, repository.StatusCancelled:
+		req.DelayMinutes = 0
+	default:
+		return nil, errors.New("invalid status, expected on_time, delayed or cancelled")
+	}
+	if len(req.Platform) > 20 {
+		return nil, errors.New("platform must be at most 20 characters")
 	}
-	if limit <= 0 {
-		limit = 10
+
+	current, err := s.scheduleRepo.GetByID(ctx, req.ScheduleId)
+	if err != nil {
+		return nil, errors.New("schedule not found")
+	}
+	if current.Status == repository.StatusCancelled {
+		return nil, errors.New("schedule is already cancelled")
+	}
+
+	schedule, err := s.scheduleRepo.UpdateStatus(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.statusHub.publish(statusUpdate(schedule, req.Reason))
+	return schedule, nil
+}
+
+// WatchSchedule sends the current status first and then every change until
+// the caller goes away or the schedule is cancelled.
+func (s *scheduleService) WatchSchedule(ctx context.Context, scheduleId int64, send func(*pb.ScheduleStatusUpdate) error) error {
+	// Subscribe before reading the snapshot so no update falls in between.
+	updates, unsubscribe := s.statusHub.subscribe(scheduleId)
+	defer unsubscribe()
+
+	schedule, err := s.scheduleRepo.GetByID(ctx, scheduleId)
+	if err != nil {
+		return errors.New("schedule not found")
 	}
-	return s.scheduleRepo.List(ctx, origin, destination, departureDate, page, limit)
+	if err := send(statusUpdate(schedule, "")); err != nil {
+		return err
+	}
+	if schedule.Status == repository.StatusCancelled {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update := <-updates:
+			if err := send(update); err != nil {
+				return err
+			}
+			if update.Status == repository.StatusCancelled {
+				return nil
+			}
+		}
+	}
+}
+
+func statusUpdate(schedule *pb.Schedule, reason string) *pb.ScheduleStatusUpdate {
+	return &pb.ScheduleStatusUpdate{
+		ScheduleId:             schedule.Id,
+		Status:                 schedule.Status,
+		DelayMinutes:           schedule.DelayMinutes,
+		Platform:               schedule.Platform,
+		Reason:                 reason,
+		EstimatedDepartureTime: schedule.EstimatedDepartureTime,
+		EstimatedArrivalTime:   schedule.EstimatedArrivalTime,
+		UpdatedAt:              time.Now().Format(time.RFC3339),
+	}
+}
+
+type trip struct {
+	originTz      string
+	destinationTz string
+	departure     time.Time
+	arrival       time.Time
+}
+
+// parseTrip resolves both station zones and reads times without an offset as
+// wall-clock time at their own station.
+func parseTrip(departureTime, arrivalTime, originTz, destinationTz string) (*trip, error) {
+	var t trip
+	var err error
+
+	if t.originTz, err = helper.NormalizeTimezone(originTz); err != nil {
+		return nil, err
+	}
+	if t.destinationTz, err = helper.NormalizeTimezone(destinationTz); err != nil {
+		return nil, err
+	}
+	if t.departure, err = helper.ParseStationTime(departureTime, t.originTz); err != nil {
+		return nil, err
+	}
+	if t.arrival, err = helper.ParseStationTime(arrivalTime, t.destinationTz); err != nil {
+		return nil, err
+	}
+	if !t.arrival.After(t.departure) {
+		return nil, errors.New("arrival time must be after departure time")
+	}
+
+	return &t, nil
 }
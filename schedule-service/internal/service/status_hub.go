@@ -0,0 +1,56 @@
+package service
+
+import (
+	"sync"
+
+	pb "ticket-booking/proto/schedule"
+)
+
+// statusHub fans live status updates out to the WatchSchedule streams open on
+// this instance. Every update is a full snapshot, so a watcher only needs the
+// latest one: an update it has not taken yet is replaced by the next. The last
+// update, such as a cancellation, is therefore never lost.
+type statusHub struct {
+	mu       sync.Mutex
+	watchers map[int64]map[chan *pb.ScheduleStatusUpdate]struct{}
+}
+
+func newStatusHub() *statusHub {
+	return &statusHub{watchers: make(map[int64]map[chan *pb.ScheduleStatusUpdate]struct{})}
+}
+
+func (h *statusHub) subscribe(scheduleId int64) (<-chan *pb.ScheduleStatusUpdate, func()) {
+	ch := make(chan *pb.ScheduleStatusUpdate, 1)
+
+	h.mu.Lock()
+	if h.watchers[scheduleId] == nil {
+		h.watchers[scheduleId] = make(map[chan *pb.ScheduleStatusUpdate]struct{})
+	}
+	h.watchers[scheduleId][ch] = struct{}{}
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.watchers[scheduleId], ch)
+		if len(h.watchers[scheduleId]) == 0 {
+			delete(h.watchers, scheduleId)
+		}
+	}
+	return ch, unsubscribe
+}
+
+func (h *statusHub) publish(update *pb.ScheduleStatusUpdate) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// publish is the only sender and holds the lock, so once the stale
+	// snapshot is taken out the send cannot block.
+	for ch := range h.watchers[update.ScheduleId] {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- update
+	}
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"testing"
+
+	pb "ticket-booking/proto/schedule"
+)
+
+func TestStatusHubKeepsLatest(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []string
+	}{
+		{"single update", []string{"delayed"}},
+		{"slow watcher sees cancellation", []string{"delayed", "delayed", "boarding", "delayed", "cancelled"}},
+		{"more updates than any buffer", []string{"delayed", "delayed", "delayed", "delayed", "delayed", "delayed", "delayed", "delayed", "delayed", "cancelled"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := newStatusHub()
+			updates, unsubscribe := hub.subscribe(1)
+			defer unsubscribe()
+			other, unsubscribeOther := hub.subscribe(2)
+			defer unsubscribeOther()
+
+			for _, status := range tt.statuses {
+				hub.publish(&pb.ScheduleStatusUpdate{ScheduleId: 1, Status: status})
+			}
+
+			got := <-updates
+			if want := tt.statuses[len(tt.statuses)-1]; got.Status != want {
+				t.Errorf("watcher got %q, want %q", got.Status, want)
+			}
+			select {
+			case u := <-updates:
+				t.Errorf("watcher got a stale update %q", u.Status)
+			default:
+			}
+			select {
+			case u := <-other:
+				t.Errorf("watcher of another schedule got %q", u.Status)
+			default:
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "ticket-booking/proto/schedule"
+	"ticket-booking/schedule-service/internal/gtfs"
+	"ticket-booking/schedule-service/internal/helper"
+	"ticket-booking/schedule-service/internal/repository"
+)
+
+const (
+	maxTimetableDays   = 366
+	maxImportSchedules = 20000
+	maxReportedChanges = 500
+	defaultTrainType   = "intercity"
+)
+
+type TimetableService interface {
+	ImportGTFS(ctx context.Context, req *pb.ImportGTFSRequest) (*pb.ImportGTFSResponse, error)
+	ExportGTFS(ctx context.Context, req *pb.ExportGTFSRequest) ([]byte, error)
+}
+
+type timetableService struct {
+	timetableRepo repository.TimetableRepository
+	agency        gtfs.Agency
+	minTurnaround time.Duration
+}
+
+func NewTimetableService(timetableRepo repository.TimetableRepository, agencyName, agencyURL string, minTurnaround time.Duration) TimetableService {
+	return &timetableService{
+		timetableRepo: timetableRepo,
+		minTurnaround: minTurnaround,
+		agency: gtfs.Agency{
+			Name:     agencyName,
+			URL:      agencyURL,
+			Timezone: helper.DefaultTimezone,
+		},
+	}
+}
+
+// ImportGTFS diffs a feed against the timetable and, unless it is a dry run,
+// applies the creates and updates in one transaction. Each trip becomes one
+// schedule per service day, from its first to its last stop. Schedules are
+// checked for conflicts like those created by hand, in dry runs too.
+func (s *timetableService) ImportGTFS(ctx context.Context, req *pb.ImportGTFSRequest) (*pb.ImportGTFSResponse, error) {
+	if len(req.Archive) == 0 {
+		return nil, errors.New("GTFS archive is required")
+	}
+	if req.DefaultPrice < 0 {
+		return nil, errors.New("default price must not be negative")
+	}
+
+	from, to, err := timetableWindow(req.FromDate, req.ToDate, 90)
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := gtfs.Parse(req.Archive)
+	if err != nil {
+		return nil, err
+	}
+	departures, warnings, err := feed.Departures(from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(departures) > maxImportSchedules {
+		return nil, fmt.Errorf("feed expands to %d schedules, narrow the date window to at most %d", len(departures), maxImportSchedules)
+	}
+
+	resp := &pb.ImportGTFSResponse{DryRun: req.DryRun, Warnings: warnings}
+	plan := &repository.ImportPlan{}
+	addChange := func(entity, action, ref, detail string) {
+		if len(resp.Changes) < maxReportedChanges {
+			resp.Changes = append(resp.Changes, &pb.GTFSChange{Entity: entity, Action: action, Ref: ref, Detail: detail})
+		}
+	}
+
+	// Stations
+	existingStations, err := s.timetableRepo.Stations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stationsByCode := make(map[string]*repository.Station, len(existingStations))
+	for _, st := range existingStations {
+		stationsByCode[st.Code] = st
+	}
+
+	stopTimezones := make(map[string]string, len(feed.Stops))
+	for _, stop := range feed.Stops {
+		tz := stop.Timezone
+		if tz == "" {
+			tz = feed.AgencyTimezone
+		}
+		if tz, err = helper.NormalizeTimezone(tz); err != nil {
+			return nil, fmt.Errorf("stop %s: %v", stop.ID, err)
+		}
+		stopTimezones[stop.ID] = tz
+
+		station := &repository.Station{Code: stop.ID, Name: stop.Name, Timezone: tz, Latitude: stop.Lat, Longitude: stop.Lon}
+		current, ok := stationsByCode[stop.ID]
+		switch {
+		case !ok:
+			resp.StationsCreated++
+			addChange("station", "create", stop.ID, stop.Name)
+			plan.Stations = append(plan.Stations, station)
+		case current.Name != station.Name || current.Timezone != station.Timezone ||
+			current.Latitude != station.Latitude || current.Longitude != station.Longitude:
+			resp.StationsUpdated++
+			addChange("station", "update", stop.ID, fmt.Sprintf("%s (%s) -> %s (%s)", current.Name, current.Timezone, station.Name, station.Timezone))
+			plan.Stations = append(plan.Stations, station)
+		}
+	}
+
+	// Schedules are matched on their ref, or on their id for trips of a
+	// feed we exported from schedules that were not imported.
+	refs := make([]string, len(departures))
+	var ids []int64
+	for i, d := range departures {
+		refs[i] = d.Ref
+		if id, ok := gtfs.ParseScheduleTripID(d.Ref); ok {
+			ids = append(ids, id)
+		}
+	}
+	existing, err := s.timetableRepo.EntriesByRef(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > 0 {
+		byID, err := s.timetableRepo.EntriesByID(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range departures {
+			id, _ := gtfs.ParseScheduleTripID(d.Ref)
+			if e, ok := byID[id]; ok {
+				if _, taken := existing[d.Ref]; !taken {
+					existing[d.Ref] = e
+				}
+			}
+		}
+	}
+
+	// Trains
+	trainIDs, err := s.timetableRepo.TrainIDsByName(ctx)
+	if err != nil {
+		return nil, err
+	}
+	trainType := req.TrainType
+	if trainType == "" {
+		trainType = defaultTrainType
+	}
+	newTrains := make(map[string]bool)
+	for _, d := range departures {
+		if _, ok := trainIDs[d.TrainName]; ok || newTrains[d.TrainName] {
+			continue
+		}
+		// Existing schedules keep their train, see below.
+		if _, ok := existing[d.Ref]; ok {
+			continue
+		}
+		if req.DefaultCapacity <= 0 {
+			return nil, fmt.Errorf("train %s does not exist yet, a positive default capacity is required to create it", d.TrainName)
+		}
+		newTrains[d.TrainName] = true
+		resp.TrainsCreated++
+		addChange("train", "create", d.TrainName, fmt.Sprintf("%s, %d seats", trainType, req.DefaultCapacity))
+		plan.Trains = append(plan.Trains, &repository.NewTrain{Name: d.TrainName, Type: trainType, Capacity: req.DefaultCapacity})
+	}
+
+	// Schedules
+	for _, d := range departures {
+		entry := &repository.TimetableEntry{
+			Ref:                 d.Ref,
+			TrainID:             trainIDs[d.TrainName],
+			TrainName:           d.TrainName,
+			Origin:              d.OriginStop.Name,
+			Destination:         d.DestinationStop.Name,
+			OriginTimezone:      stopTimezones[d.OriginStop.ID],
+			DestinationTimezone: stopTimezones[d.DestinationStop.ID],
+			Departure:           d.Departure,
+			Arrival:             d.Arrival,
+			Price:               req.DefaultPrice,
+		}
+
+		current, ok := existing[d.Ref]
+		if ok {
+			entry.ID = current.ID
+			// Moving booked seats to another train is ReassignTrain's job,
+			// which checks the new train can seat them.
+			if current.TrainName != entry.TrainName {
+				resp.Warnings = append(resp.Warnings, fmt.Sprintf("schedule %s: keeps train %s, use reassign to run it with %s", d.Ref, current.TrainName, entry.TrainName))
+			}
+			entry.TrainID = current.TrainID
+			entry.TrainName = current.TrainName
+		}
+		switch {
+		case !ok:
+			resp.SchedulesCreated++
+			addChange("schedule", "create", d.Ref, describeEntry(entry))
+			plan.Schedules = append(plan.Schedules, entry)
+		case timetableEntryChanged(current, entry):
+			resp.SchedulesUpdated++
+			addChange("schedule", "update", d.Ref, describeEntry(current)+" -> "+describeEntry(entry))
+			plan.Schedules = append(plan.Schedules, entry)
+		default:
+			resp.SchedulesUnchanged++
+		}
+	}
+
+	if total := resp.StationsCreated + resp.StationsUpdated + resp.TrainsCreated + resp.SchedulesCreated + resp.SchedulesUpdated; total > maxReportedChanges {
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf("only the first %d of %d changes are listed", maxReportedChanges, total))
+	}
+
+	if err := s.timetableRepo.ApplyImport(ctx, plan, s.minTurnaround, req.DryRun); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *timetableService) ExportGTFS(ctx context.Context, req *pb.ExportGTFSRequest) ([]byte, error) {
+	from, to, err := timetableWindow(req.FromDate, req.ToDate, 30)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.timetableRepo.Entries(ctx, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	stations, err := s.timetableRepo.Stations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Schedules name their stations; stations known by name keep their code,
+	// others get a stop generated from the name.
+	stopsByName := make(map[string]*gtfs.ExportStop)
+	for _, st := range stations {
+		stopsByName[st.Name] = &gtfs.ExportStop{ID: st.Code, Name: st.Name, Timezone: st.Timezone, Lat: st.Latitude, Lon: st.Longitude}
+	}
+	var stops []*gtfs.ExportStop
+	used := make(map[string]bool)
+	stopFor := func(name, timezone string) string {
+		stop, ok := stopsByName[name]
+		if !ok {
+			stop = &gtfs.ExportStop{ID: stopID(name), Name: name, Timezone: timezone}
+			stopsByName[name] = stop
+		}
+		if !used[stop.ID] {
+			used[stop.ID] = true
+			stops = append(stops, stop)
+		}
+		return stop.ID
+	}
+
+	loc, err := time.LoadLocation(s.agency.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	trips := make([]*gtfs.ExportTrip, 0, len(entries))
+	for _, e := range entries {
+		id := e.Ref
+		if id == "" {
+			id = gtfs.ScheduleTripID(e.ID, e.Departure, loc)
+		}
+		trips = append(trips, &gtfs.ExportTrip{
+			ID:                id,
+			RouteID:           fmt.Sprintf("T%d", e.TrainID),
+			RouteName:         e.TrainName,
+			TrainName:         e.TrainName,
+			OriginStopID:      stopFor(e.Origin, e.OriginTimezone),
+			DestinationStopID: stopFor(e.Destination, e.DestinationTimezone),
+			Departure:         e.Departure,
+			Arrival:           e.Arrival,
+		})
+	}
+
+	return gtfs.Write(s.agency, stops, trips)
+}
+
+// timetableWindow parses an inclusive YYYY-MM-DD window, defaulting to today
+// and defaultDays after the start.
+func timetableWindow(fromDate, toDate string, defaultDays int) (time.Time, time.Time, error) {
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	if fromDate != "" {
+		t, err := time.Parse("2006-01-02", fromDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("from date must be in YYYY-MM-DD format")
+		}
+		from = t
+	}
+
+	to := from.AddDate(0, 0, defaultDays)
+	if toDate != "" {
+		t, err := time.Parse("2006-01-02", toDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("to date must be in YYYY-MM-DD format")
+		}
+		to = t
+	}
+
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, errors.New("to date must not be before from date")
+	}
+	if to.Sub(from) > maxTimetableDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("date window must be at most %d days", maxTimetableDays)
+	}
+	return from, to, nil
+}
+
+func timetableEntryChanged(current, next *repository.TimetableEntry) bool {
+	return current.TrainName != next.TrainName ||
+		current.Origin != next.Origin ||
+		current.Destination != next.Destination ||
+		current.OriginTimezone != next.OriginTimezone ||
+		current.DestinationTimezone != next.DestinationTimezone ||
+		!current.Departure.Equal(next.Departure) ||
+		!current.Arrival.Equal(next.Arrival)
+}
+
+func describeEntry(e *repository.TimetableEntry) string {
+	return fmt.Sprintf("%s %s %s -> %s %s",
+		e.TrainName,
+		e.Origin, helper.FormatStationTime(e.Departure, e.OriginTimezone),
+		e.Destination, helper.FormatStationTime(e.Arrival, e.DestinationTimezone))
+}
+
+func stopID(name string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(name), "-"))
+}
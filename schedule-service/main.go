@@ -18,6 +18,8 @@ import (
 	"ticket-booking/schedule-service/internal/service"
 )
 
+const maxMessageSize = 32 << 20
+
 func main() {
 	cfg, err := config.LoadEnv("SCHEDULE_")
 	if err != nil {
@@ -34,9 +36,11 @@ func main() {
 
 	// Initialize repositories
 	scheduleRepo := repository.NewScheduleRepository(pool)
+	timetableRepo := repository.NewTimetableRepository(pool)
 
 	// Initialize services
-	scheduleService := service.NewScheduleService(scheduleRepo)
+	scheduleService := service.NewScheduleService(scheduleRepo, time.Duration(cfg.MinTurnaroundMinutes)*time.Minute)
+	timetableService := service.NewTimetableService(timetableRepo, cfg.AgencyName, cfg.AgencyURL, time.Duration(cfg.MinTurnaroundMinutes)*time.Minute)
 
 	// Start HTTP server for health check
 	go func() {
@@ -55,8 +59,14 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	pb.RegisterScheduleServiceServer(grpcServer, handler.NewGrpcServer(scheduleService))
+	// GTFS archives travel in a single message, so allow more than the 4MB default
+	grpcServer := grpc.NewServer(
+		grpc.MaxRecvMsgSize(maxMessageSize),
+		grpc.MaxSendMsgSize(maxMessageSize),
+		grpc.ChainUnaryInterceptor(handler.Permissions.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(handler.Permissions.StreamServerInterceptor()),
+	)
+	pb.RegisterScheduleServiceServer(grpcServer, handler.NewGrpcServer(scheduleService, timetableService))
 
 	log.Printf("%s gRPC server listening on port %d", cfg.ServiceName, cfg.GRPCPort)
 	if err := grpcServer.Serve(lis); err != nil {
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS coaches;
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS coaches (
+    id BIGSERIAL PRIMARY KEY,
+    train_id BIGINT NOT NULL REFERENCES trains(id),
+    coach_number INTEGER NOT NULL,
+    class VARCHAR(20) NOT NULL,
+    seat_rows INTEGER NOT NULL,
+    seat_columns INTEGER NOT NULL,
+    aisle_after INTEGER NOT NULL DEFAULT 0,
+    blocked_seats TEXT[] NOT NULL DEFAULT '{}',
+    accessible_seats TEXT[] NOT NULL DEFAULT '{}',
+    seat_count INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    deleted_at TIMESTAMP NULL
+);
+
+CREATE UNIQUE INDEX idx_coaches_train_id_number ON coaches(train_id, coach_number) WHERE deleted_at IS NULL;
\ No newline at end of file
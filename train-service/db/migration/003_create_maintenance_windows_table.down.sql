@@ -0,0 +1 @@
+DROP TABLE IF EXISTS maintenance_windows;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS maintenance_windows (
+    id BIGSERIAL PRIMARY KEY,
+    train_id BIGINT NOT NULL REFERENCES trains(id),
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    reason TEXT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    deleted_at TIMESTAMP NULL,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_maintenance_windows_train_id ON maintenance_windows(train_id, starts_at);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS coach_seats;
\ No newline at end of file
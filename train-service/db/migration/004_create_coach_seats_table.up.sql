@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS coach_seats (
+    coach_id BIGINT NOT NULL REFERENCES coaches(id),
+    train_id BIGINT NOT NULL REFERENCES trains(id),
+    coach_number INTEGER NOT NULL,
+    class VARCHAR(20) NOT NULL,
+    seat_number VARCHAR(4) NOT NULL,
+    seat_row INTEGER NOT NULL,
+    seat_column INTEGER NOT NULL,
+    is_window BOOLEAN NOT NULL DEFAULT FALSE,
+    is_aisle BOOLEAN NOT NULL DEFAULT FALSE,
+    is_accessible BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (coach_id, seat_number)
+);
+
+CREATE INDEX idx_coach_seats_train_id ON coach_seats(train_id, coach_number, seat_row, seat_column);
+
+-- Materialise the seats of coaches created before this table existed.
+INSERT INTO coach_seats (coach_id, train_id, coach_number, class, seat_number, seat_row, seat_column,
+                         is_window, is_aisle, is_accessible)
+SELECT c.id, c.train_id, c.coach_number, c.class, r || substr('ABCDEF', col, 1), r, col,
+       col = 1 OR col = c.seat_columns,
+       c.aisle_after > 0 AND (col = c.aisle_after OR col = c.aisle_after + 1),
+       (r || substr('ABCDEF', col, 1)) = ANY(c.accessible_seats)
+FROM coaches c
+CROSS JOIN LATERAL generate_series(1, c.seat_rows) AS r
+CROSS JOIN LATERAL generate_series(1, c.seat_columns) AS col
+WHERE c.deleted_at IS NULL
+  AND NOT (r || substr('ABCDEF', col, 1)) = ANY(c.blocked_seats);
\ No newline at end of file
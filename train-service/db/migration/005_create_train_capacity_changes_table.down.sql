@@ -0,0 +1 @@
+DROP TABLE IF EXISTS train_capacity_changes;
\ No newline at end of file
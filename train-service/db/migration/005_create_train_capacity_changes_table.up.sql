@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS train_capacity_changes (
+    id BIGSERIAL PRIMARY KEY,
+    train_id BIGINT NOT NULL REFERENCES trains(id),
+    old_capacity INTEGER NOT NULL,
+    new_capacity INTEGER NOT NULL,
+    source VARCHAR(30) NOT NULL,
+    schedules_updated INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_train_capacity_changes_train_id ON train_capacity_changes(train_id, created_at);
\ No newline at end of file
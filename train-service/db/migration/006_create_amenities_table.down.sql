@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_trains_amenities;
+ALTER TABLE coaches DROP COLUMN IF EXISTS amenities;
+ALTER TABLE trains DROP COLUMN IF EXISTS amenities;
+DROP TABLE IF EXISTS amenities;
\ No newline at end of file
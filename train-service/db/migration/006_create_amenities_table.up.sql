@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS amenities (
+    code VARCHAR(30) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+INSERT INTO amenities (code, name, description) VALUES
+    ('air_conditioning', 'Air conditioning', 'Climate-controlled coaches'),
+    ('power_outlets', 'Power outlets', 'A socket at every seat'),
+    ('usb_charging', 'USB charging', 'USB ports at the seats'),
+    ('wifi', 'Wi-Fi', 'On-board internet access'),
+    ('dining_car', 'Dining car', 'A restaurant coach serving meals and drinks'),
+    ('snack_service', 'Snack service', 'Snacks and drinks brought to the seat'),
+    ('reclining_seats', 'Reclining seats', 'Seats with adjustable backrests'),
+    ('wheelchair_access', 'Wheelchair access', 'Step-free boarding and wheelchair spaces'),
+    ('accessible_toilet', 'Accessible toilet', 'A toilet usable from a wheelchair'),
+    ('prayer_room', 'Prayer room', 'A quiet room for prayer')
+ON CONFLICT (code) DO NOTHING;
+
+ALTER TABLE trains ADD COLUMN IF NOT EXISTS amenities TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE coaches ADD COLUMN IF NOT EXISTS amenities TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_trains_amenities ON trains USING GIN (amenities);
\ No newline at end of file
@@ -34,7 +34,7 @@ func (s *GrpcServer) GetTrain(ctx context.Context, req *pb.GetTrainRequest) (*pb
 }
 
 func (s *GrpcServer) ListTrains(ctx context.Context, req *pb.ListTrainsRequest) (*pb.ListTrainsResponse, error) {
-	trains, total, err := s.trainService.ListTrains(ctx, req.Page, req.Limit)
+	trains, total, err := s.trainService.ListTrains(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -62,3 +62,97 @@ func (s *GrpcServer) DeleteTrain(ctx context.Context, req *pb.DeleteTrainRequest
 
 	return &pb.DeleteTrainResponse{Success: true}, nil
 }
+
+func (s *GrpcServer) AddCoach(ctx context.Context, req *pb.AddCoachRequest) (*pb.AddCoachResponse, error) {
+	coach, capacity, err := s.trainService.AddCoach(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AddCoachResponse{Coach: coach, TrainCapacity: capacity}, nil
+}
+
+func (s *GrpcServer) UpdateCoach(ctx context.Context, req *pb.UpdateCoachRequest) (*pb.UpdateCoachResponse, error) {
+	coach, capacity, err := s.trainService.UpdateCoach(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateCoachResponse{Coach: coach, TrainCapacity: capacity}, nil
+}
+
+func (s *GrpcServer) RemoveCoach(ctx context.Context, req *pb.RemoveCoachRequest) (*pb.RemoveCoachResponse, error) {
+	capacity, err := s.trainService.RemoveCoach(ctx, req.CoachId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RemoveCoachResponse{TrainCapacity: capacity}, nil
+}
+
+func (s *GrpcServer) ListCoaches(ctx context.Context, req *pb.ListCoachesRequest) (*pb.ListCoachesResponse, error) {
+	coaches, capacity, err := s.trainService.ListCoaches(ctx, req.TrainId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListCoachesResponse{Coaches: coaches, Capacity: capacity}, nil
+}
+
+func (s *GrpcServer) GetSeatMap(ctx context.Context, req *pb.GetSeatMapRequest) (*pb.GetSeatMapResponse, error) {
+	return s.trainService.GetSeatMap(ctx, req.TrainId, req.Class)
+}
+
+func (s *GrpcServer) AddMaintenanceWindow(ctx context.Context, req *pb.AddMaintenanceWindowRequest) (*pb.AddMaintenanceWindowResponse, error) {
+	window, err := s.trainService.AddMaintenanceWindow(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AddMaintenanceWindowResponse{Window: window}, nil
+}
+
+func (s *GrpcServer) ListMaintenanceWindows(ctx context.Context, req *pb.ListMaintenanceWindowsRequest) (*pb.ListMaintenanceWindowsResponse, error) {
+	windows, err := s.trainService.ListMaintenanceWindows(ctx, req.TrainId, req.IncludePast)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListMaintenanceWindowsResponse{Windows: windows}, nil
+}
+
+func (s *GrpcServer) RemoveMaintenanceWindow(ctx context.Context, req *pb.RemoveMaintenanceWindowRequest) (*pb.RemoveMaintenanceWindowResponse, error) {
+	err := s.trainService.RemoveMaintenanceWindow(ctx, req.WindowId)
+	if err != nil {
+		return &pb.RemoveMaintenanceWindowResponse{Success: false}, err
+	}
+
+	return &pb.RemoveMaintenanceWindowResponse{Success: true}, nil
+}
+
+func (s *GrpcServer) ListCapacityChanges(ctx context.Context, req *pb.ListCapacityChangesRequest) (*pb.ListCapacityChangesResponse, error) {
+	changes, err := s.trainService.ListCapacityChanges(ctx, req.TrainId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListCapacityChangesResponse{Changes: changes}, nil
+}
+
+func (s *GrpcServer) ListAmenities(ctx context.Context, req *pb.ListAmenitiesRequest) (*pb.ListAmenitiesResponse, error) {
+	amenities, err := s.trainService.ListAmenities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListAmenitiesResponse{Amenities: amenities}, nil
+}
+
+func (s *GrpcServer) SetTrainAmenities(ctx context.Context, req *pb.SetTrainAmenitiesRequest) (*pb.SetTrainAmenitiesResponse, error) {
+	train, err := s.trainService.SetTrainAmenities(ctx, req.TrainId, req.Amenities)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SetTrainAmenitiesResponse{Train: train}, nil
+}
@@ -0,0 +1,18 @@
+package handler
+
+import "ticket-booking/proto/auth"
+
+// Permissions lists the RPCs that change the fleet or expose its audit;
+// reading trains, coaches and seat maps stays open.
+var Permissions = auth.Rules{
+	"/train.TrainService/CreateTrain":             {auth.RoleOperator},
+	"/train.TrainService/UpdateTrain":             {auth.RoleOperator},
+	"/train.TrainService/DeleteTrain":             {auth.RoleOperator},
+	"/train.TrainService/AddCoach":                {auth.RoleOperator},
+	"/train.TrainService/UpdateCoach":             {auth.RoleOperator},
+	"/train.TrainService/RemoveCoach":             {auth.RoleOperator},
+	"/train.TrainService/AddMaintenanceWindow":    {auth.RoleOperator},
+	"/train.TrainService/RemoveMaintenanceWindow": {auth.RoleOperator},
+	"/train.TrainService/SetTrainAmenities":       {auth.RoleOperator},
+	"/train.TrainService/ListCapacityChanges":     {auth.RoleOperator},
+}
@@ -0,0 +1,176 @@
+// Package layout expands a coach's grid description into its seats. Train
+// service uses it to validate compositions and to materialise coach_seats.
+package layout
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	pb "ticket-booking/proto/train"
+)
+
+const (
+	maxCoachRows    = 40
+	maxCoachColumns = 6
+	seatLetters     = "ABCDEF"
+)
+
+// Classes lists the travel classes a coach may be fitted for, in the
+// order seat maps and inventories present them.
+var Classes = []string{"executive", "business", "premium", "economy"}
+
+// IsClass reports whether class is one of Classes.
+func IsClass(class string) bool {
+	for _, c := range Classes {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// SeatNumber labels a seat by row and column letter, e.g. 12A.
+func SeatNumber(row, column int32) string {
+	return fmt.Sprintf("%d%c", row, seatLetters[column-1])
+}
+
+// Normalize validates a coach layout, canonicalises its seat lists and
+// sets SeatCount from the grid minus blocked positions.
+func Normalize(c *pb.Coach) error {
+	c.Class = strings.ToLower(strings.TrimSpace(c.Class))
+	if !IsClass(c.Class) {
+		return fmt.Errorf("class must be one of %s", strings.Join(Classes, ", "))
+	}
+	if c.CoachNumber <= 0 {
+		return errors.New("coach number must be greater than zero")
+	}
+	if c.Rows <= 0 || c.Rows > maxCoachRows {
+		return fmt.Errorf("rows must be between 1 and %d", maxCoachRows)
+	}
+	if c.Columns <= 0 || c.Columns > maxCoachColumns {
+		return fmt.Errorf("columns must be between 1 and %d", maxCoachColumns)
+	}
+	// 0 means no aisle; otherwise the aisle runs after that column.
+	if c.AisleAfter < 0 || c.AisleAfter >= c.Columns {
+		return errors.New("aisle_after must be 0 or fall between two columns")
+	}
+
+	blocked, err := canonicalSeats(c, c.BlockedSeats)
+	if err != nil {
+		return fmt.Errorf("blocked seats: %w", err)
+	}
+	accessible, err := canonicalSeats(c, c.AccessibleSeats)
+	if err != nil {
+		return fmt.Errorf("accessible seats: %w", err)
+	}
+	for _, seat := range accessible {
+		if containsSeat(blocked, seat) {
+			return fmt.Errorf("seat %s cannot be both blocked and accessible", seat)
+		}
+	}
+
+	c.BlockedSeats = blocked
+	c.AccessibleSeats = accessible
+	c.SeatCount = c.Rows*c.Columns - int32(len(blocked))
+	if c.SeatCount <= 0 {
+		return errors.New("coach must have at least one seat")
+	}
+	return nil
+}
+
+// canonicalSeats parses seat numbers against the coach grid and returns them
+// upper-cased, de-duplicated and in row/column order.
+func canonicalSeats(c *pb.Coach, seats []string) ([]string, error) {
+	type position struct{ row, column int32 }
+	seen := make(map[position]bool, len(seats))
+	var positions []position
+
+	for _, raw := range seats {
+		s := strings.ToUpper(strings.TrimSpace(raw))
+		if len(s) < 2 {
+			return nil, fmt.Errorf("invalid seat %q", raw)
+		}
+		row, err := strconv.Atoi(s[:len(s)-1])
+		column := strings.IndexByte(seatLetters, s[len(s)-1]) + 1
+		if err != nil || row < 1 || int32(row) > c.Rows || column < 1 || int32(column) > c.Columns {
+			return nil, fmt.Errorf("seat %q is not on a %dx%d layout", raw, c.Rows, c.Columns)
+		}
+
+		p := position{int32(row), int32(column)}
+		if !seen[p] {
+			seen[p] = true
+			positions = append(positions, p)
+		}
+	}
+
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].row != positions[j].row {
+			return positions[i].row < positions[j].row
+		}
+		return positions[i].column < positions[j].column
+	})
+
+	out := make([]string, 0, len(positions))
+	for _, p := range positions {
+		out = append(out, SeatNumber(p.row, p.column))
+	}
+	return out, nil
+}
+
+func containsSeat(seats []string, seat string) bool {
+	for _, s := range seats {
+		if s == seat {
+			return true
+		}
+	}
+	return false
+}
+
+// Seats expands a coach layout into its bookable seats. The outermost
+// columns are windows; the columns either side of the aisle are aisle seats.
+func Seats(c *pb.Coach) []*pb.Seat {
+	seats := make([]*pb.Seat, 0, c.SeatCount)
+	for row := int32(1); row <= c.Rows; row++ {
+		for column := int32(1); column <= c.Columns; column++ {
+			number := SeatNumber(row, column)
+			if containsSeat(c.BlockedSeats, number) {
+				continue
+			}
+			seats = append(seats, &pb.Seat{
+				Number:     number,
+				Row:        row,
+				Column:     column,
+				Window:     column == 1 || column == c.Columns,
+				Aisle:      c.AisleAfter > 0 && (column == c.AisleAfter || column == c.AisleAfter+1),
+				Accessible: containsSeat(c.AccessibleSeats, number),
+			})
+		}
+	}
+	return seats
+}
+
+// Inventory totals seats per class across a composition.
+func Inventory(coaches []*pb.Coach) []*pb.ClassInventory {
+	byClass := make(map[string]*pb.ClassInventory)
+	for _, c := range coaches {
+		inv, ok := byClass[c.Class]
+		if !ok {
+			inv = &pb.ClassInventory{Class: c.Class}
+			byClass[c.Class] = inv
+		}
+		inv.Seats += c.SeatCount
+		inv.AccessibleSeats += int32(len(c.AccessibleSeats))
+		inv.Coaches++
+	}
+
+	inventory := make([]*pb.ClassInventory, 0, len(byClass))
+	for _, class := range Classes {
+		if inv, ok := byClass[class]; ok {
+			inventory = append(inventory, inv)
+		}
+	}
+	return inventory
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	pb "ticket-booking/proto/train"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AmenityRepository interface {
+	List(ctx context.Context) ([]*pb.Amenity, error)
+}
+
+type amenityRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAmenityRepository(db *pgxpool.Pool) AmenityRepository {
+	return &amenityRepository{db: db}
+}
+
+func (r *amenityRepository) List(ctx context.Context) ([]*pb.Amenity, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT code, name, COALESCE(description, '')
+		FROM amenities ORDER BY name ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var amenities []*pb.Amenity
+	for rows.Next() {
+		var a pb.Amenity
+		if err := rows.Scan(&a.Code, &a.Name, &a.Description); err != nil {
+			return nil, err
+		}
+		amenities = append(amenities, &a)
+	}
+
+	return amenities, rows.Err()
+}
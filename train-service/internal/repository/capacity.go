@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "ticket-booking/proto/train"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Sources of a capacity change, recorded in the audit.
+const (
+	CapacitySourceTrainUpdate  = "train_update"
+	CapacitySourceCoachAdded   = "coach_added"
+	CapacitySourceCoachUpdated = "coach_updated"
+	CapacitySourceCoachRemoved = "coach_removed"
+)
+
+// SoldSeats is how many seats are sold on one schedule.
+type SoldSeats struct {
+	ScheduleId int64
+	Sold       int32
+}
+
+// CapacityConflictError refuses a capacity below the seats already sold on
+// some of the train's upcoming schedules.
+type CapacityConflictError struct {
+	Capacity  int32
+	Schedules []SoldSeats
+}
+
+func (e *CapacityConflictError) Error() string {
+	sold := make([]string, len(e.Schedules))
+	for i, s := range e.Schedules {
+		sold[i] = fmt.Sprintf("schedule %d has %d", s.ScheduleId, s.Sold)
+	}
+	return fmt.Sprintf("capacity %d is below the seats already sold (%s); reassign those schedules first",
+		e.Capacity, strings.Join(sold, ", "))
+}
+
+// setCapacity changes the capacity of a locked train and recomputes the
+// available seats of its upcoming schedules from what they have sold, so
+// availability never drifts from the train's real size. Departed and
+// cancelled schedules are left alone. Every change is written to
+// train_capacity_changes.
+func setCapacity(ctx context.Context, tx pgx.Tx, trainId int64, capacity int32, source string) error {
+	var current int32
+	err := tx.QueryRow(ctx, `SELECT capacity FROM trains WHERE id = $1`, trainId).Scan(&current)
+	if err != nil {
+		return err
+	}
+	if current == capacity {
+		return nil
+	}
+
+	// Locking the schedules holds off bookings, which lock the schedule
+	// before taking seats, until the new availability is written.
+	rows, err := tx.Query(ctx, `
+		SELECT s.id, (
+			SELECT COALESCE(SUM(b.seat_count), 0)::int FROM bookings b
+			WHERE b.schedule_id = s.id AND b.status IN (1, 2) AND b.deleted_at IS NULL
+		)
+		FROM schedules s
+		WHERE s.train_id = $1 AND s.deleted_at IS NULL AND s.status <> 'cancelled'
+		  AND s.departure_time > NOW()
+		ORDER BY s.id
+		FOR UPDATE OF s`, trainId)
+	if err != nil {
+		return err
+	}
+	var ids []int64
+	var sold []int32
+	var conflicts []SoldSeats
+	for rows.Next() {
+		var s SoldSeats
+		if err := rows.Scan(&s.ScheduleId, &s.Sold); err != nil {
+			rows.Close()
+			return err
+		}
+		if s.Sold > capacity {
+			conflicts = append(conflicts, s)
+		}
+		ids = append(ids, s.ScheduleId)
+		sold = append(sold, s.Sold)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &CapacityConflictError{Capacity: capacity, Schedules: conflicts}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE schedules s SET available_seats = $2 - v.sold, updated_at = NOW()
+		FROM unnest($1::bigint[], $3::int[]) AS v(id, sold)
+		WHERE s.id = v.id`, ids, capacity, sold)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE trains SET capacity = $2, updated_at = NOW() WHERE id = $1`, trainId, capacity)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO train_capacity_changes (train_id, old_capacity, new_capacity, source, schedules_updated, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())`,
+		trainId, current, capacity, source, len(ids))
+	return err
+}
+
+// ListCapacityChanges returns the audit of a train's capacity, newest first.
+func (r *trainRepository) ListCapacityChanges(ctx context.Context, trainId int64) ([]*pb.CapacityChange, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, train_id, old_capacity, new_capacity, source, schedules_updated, created_at
+		FROM train_capacity_changes
+		WHERE train_id = $1
+		ORDER BY created_at DESC, id DESC`, trainId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []*pb.CapacityChange
+	for rows.Next() {
+		var c pb.CapacityChange
+		var createdAt time.Time
+		if err := rows.Scan(&c.Id, &c.TrainId, &c.OldCapacity, &c.NewCapacity, &c.Source, &c.SchedulesUpdated, &createdAt); err != nil {
+			return nil, err
+		}
+		c.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+		changes = append(changes, &c)
+	}
+
+	return changes, rows.Err()
+}
@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	pb "ticket-booking/proto/train"
+	"ticket-booking/train-service/internal/layout"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrTrainNotFound       = errors.New("train not found")
+	ErrCoachNotFound       = errors.New("coach not found")
+	ErrCoachNumberConflict = errors.New("coach number already used on this train")
+	ErrCapacityFromCoaches = errors.New("capacity is derived from the coaches, change them instead")
+)
+
+// CoachRepository stores train compositions. Every change rewrites the
+// coach's rows in coach_seats and recomputes the train's capacity in the same
+// transaction, so trains.capacity always equals the sum of its coaches' seats
+// and upcoming schedules follow it.
+type CoachRepository interface {
+	Create(ctx context.Context, coach *pb.Coach) (*pb.Coach, int32, error)
+	Update(ctx context.Context, coach *pb.Coach) (*pb.Coach, int32, error)
+	Delete(ctx context.Context, id int64) (int32, error)
+	GetByID(ctx context.Context, id int64) (*pb.Coach, error)
+	ListByTrain(ctx context.Context, trainId int64) ([]*pb.Coach, error)
+}
+
+type coachRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCoachRepository(db *pgxpool.Pool) CoachRepository {
+	return &coachRepository{db: db}
+}
+
+const coachColumns = `id, train_id, coach_number, class, seat_rows, seat_columns, aisle_after,
+		       blocked_seats, accessible_seats, seat_count, amenities`
+
+func scanCoach(row pgx.Row) (*pb.Coach, error) {
+	var c pb.Coach
+	err := row.Scan(&c.Id, &c.TrainId, &c.CoachNumber, &c.Class, &c.Rows, &c.Columns, &c.AisleAfter,
+		&c.BlockedSeats, &c.AccessibleSeats, &c.SeatCount, &c.Amenities)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *coachRepository) Create(ctx context.Context, coach *pb.Coach) (*pb.Coach, int32, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Locking the train serialises composition changes so capacity sums and
+	// coach number checks never race.
+	var trainId int64
+	err = tx.QueryRow(ctx, `SELECT id FROM trains WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, coach.TrainId).Scan(&trainId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, 0, ErrTrainNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := checkCoachNumber(ctx, tx, coach.TrainId, coach.CoachNumber, 0); err != nil {
+		return nil, 0, err
+	}
+
+	created, err := insertCoach(ctx, tx, coach)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	capacity, err := syncCapacity(ctx, tx, coach.TrainId, CapacitySourceCoachAdded)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, 0, err
+	}
+	return created, capacity, nil
+}
+
+func (r *coachRepository) Update(ctx context.Context, coach *pb.Coach) (*pb.Coach, int32, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	trainId, err := lockCoachTrain(ctx, tx, coach.Id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := checkCoachNumber(ctx, tx, trainId, coach.CoachNumber, coach.Id); err != nil {
+		return nil, 0, err
+	}
+
+	updated, err := scanCoach(tx.QueryRow(ctx, `
+		UPDATE coaches
+		SET coach_number = $2, class = $3, seat_rows = $4, seat_columns = $5, aisle_after = $6,
+		    blocked_seats = $7, accessible_seats = $8, seat_count = $9, amenities = $10, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING `+coachColumns,
+		coach.Id, coach.CoachNumber, coach.Class, coach.Rows, coach.Columns, coach.AisleAfter,
+		nonNil(coach.BlockedSeats), nonNil(coach.AccessibleSeats), coach.SeatCount, nonNil(coach.Amenities)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := writeSeats(ctx, tx, updated); err != nil {
+		return nil, 0, err
+	}
+
+	capacity, err := syncCapacity(ctx, tx, trainId, CapacitySourceCoachUpdated)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, 0, err
+	}
+	return updated, capacity, nil
+}
+
+func (r *coachRepository) Delete(ctx context.Context, id int64) (int32, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	trainId, err := lockCoachTrain(ctx, tx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE coaches SET deleted_at = NOW() WHERE id = $1`, id)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM coach_seats WHERE coach_id = $1`, id)
+	if err != nil {
+		return 0, err
+	}
+
+	capacity, err := syncCapacity(ctx, tx, trainId, CapacitySourceCoachRemoved)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return capacity, nil
+}
+
+func (r *coachRepository) GetByID(ctx context.Context, id int64) (*pb.Coach, error) {
+	coach, err := scanCoach(r.db.QueryRow(ctx, `
+		SELECT `+coachColumns+`
+		FROM coaches WHERE id = $1 AND deleted_at IS NULL`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCoachNotFound
+	}
+	return coach, err
+}
+
+func (r *coachRepository) ListByTrain(ctx context.Context, trainId int64) ([]*pb.Coach, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+coachColumns+`
+		FROM coaches WHERE train_id = $1 AND deleted_at IS NULL
+		ORDER BY coach_number ASC`, trainId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var coaches []*pb.Coach
+	for rows.Next() {
+		coach, err := scanCoach(rows)
+		if err != nil {
+			return nil, err
+		}
+		coaches = append(coaches, coach)
+	}
+
+	return coaches, rows.Err()
+}
+
+func insertCoach(ctx context.Context, tx pgx.Tx, coach *pb.Coach) (*pb.Coach, error) {
+	created, err := scanCoach(tx.QueryRow(ctx, `
+		INSERT INTO coaches (train_id, coach_number, class, seat_rows, seat_columns, aisle_after,
+		                     blocked_seats, accessible_seats, seat_count, amenities, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW())
+		RETURNING `+coachColumns,
+		coach.TrainId, coach.CoachNumber, coach.Class, coach.Rows, coach.Columns, coach.AisleAfter,
+		nonNil(coach.BlockedSeats), nonNil(coach.AccessibleSeats), coach.SeatCount, nonNil(coach.Amenities)))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeSeats(ctx, tx, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// writeSeats replaces the materialised seats of a coach with the ones its
+// layout generates. Other services allocate and map seats from these rows.
+func writeSeats(ctx context.Context, tx pgx.Tx, coach *pb.Coach) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM coach_seats WHERE coach_id = $1`, coach.Id); err != nil {
+		return err
+	}
+
+	seats := layout.Seats(coach)
+	_, err := tx.CopyFrom(ctx, pgx.Identifier{"coach_seats"},
+		[]string{"coach_id", "train_id", "coach_number", "class", "seat_number", "seat_row", "seat_column",
+			"is_window", "is_aisle", "is_accessible"},
+		pgx.CopyFromSlice(len(seats), func(i int) ([]interface{}, error) {
+			seat := seats[i]
+			return []interface{}{coach.Id, coach.TrainId, coach.CoachNumber, coach.Class, seat.Number,
+				seat.Row, seat.Column, seat.Window, seat.Aisle, seat.Accessible}, nil
+		}))
+	return err
+}
+
+// lockCoachTrain finds the train a coach belongs to and locks it for the
+// rest of the transaction.
+func lockCoachTrain(ctx context.Context, tx pgx.Tx, coachId int64) (int64, error) {
+	var trainId int64
+	err := tx.QueryRow(ctx, `
+		SELECT t.id FROM coaches c
+		JOIN trains t ON t.id = c.train_id
+		WHERE c.id = $1 AND c.deleted_at IS NULL
+		FOR UPDATE OF t`, coachId).Scan(&trainId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrCoachNotFound
+	}
+	return trainId, err
+}
+
+func checkCoachNumber(ctx context.Context, tx pgx.Tx, trainId int64, number int32, exceptId int64) error {
+	var taken bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM coaches
+			WHERE train_id = $1 AND coach_number = $2 AND id <> $3 AND deleted_at IS NULL
+		)`, trainId, number, exceptId).Scan(&taken)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrCoachNumberConflict
+	}
+	return nil
+}
+
+// syncCapacity sets the train's capacity to its coaches' seat total and
+// passes the change on to upcoming schedules.
+func syncCapacity(ctx context.Context, tx pgx.Tx, trainId int64, source string) (int32, error) {
+	var capacity int32
+	err := tx.QueryRow(ctx, `
+		SELECT COALESCE(SUM(seat_count), 0) FROM coaches
+		WHERE train_id = $1 AND deleted_at IS NULL`, trainId).Scan(&capacity)
+	if err != nil {
+		return 0, err
+	}
+	return capacity, setCapacity(ctx, tx, trainId, capacity, source)
+}
+
+// nonNil keeps empty seat and amenity lists as '{}' rather than NULL.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "ticket-booking/proto/train"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrMaintenanceWindowNotFound = errors.New("maintenance window not found")
+
+// MaintenanceConflictError lists the schedules a train would miss if the
+// window were accepted.
+type MaintenanceConflictError struct {
+	ScheduleIDs []int64
+}
+
+func (e *MaintenanceConflictError) Error() string {
+	ids := make([]string, len(e.ScheduleIDs))
+	for i, id := range e.ScheduleIDs {
+		ids[i] = fmt.Sprintf("%d", id)
+	}
+	return fmt.Sprintf("train is scheduled during this window (schedules %s); reassign or cancel them first", strings.Join(ids, ", "))
+}
+
+type MaintenanceRepository interface {
+	Create(ctx context.Context, trainId int64, startsAt, endsAt time.Time, reason string) (*pb.MaintenanceWindow, error)
+	ListByTrain(ctx context.Context, trainId int64, since time.Time) ([]*pb.MaintenanceWindow, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type maintenanceRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewMaintenanceRepository(db *pgxpool.Pool) MaintenanceRepository {
+	return &maintenanceRepository{db: db}
+}
+
+const maintenanceColumns = `id, train_id, starts_at, ends_at, COALESCE(reason, '')`
+
+func scanMaintenanceWindow(row pgx.Row) (*pb.MaintenanceWindow, error) {
+	var w pb.MaintenanceWindow
+	var startsAt, endsAt time.Time
+	if err := row.Scan(&w.Id, &w.TrainId, &startsAt, &endsAt, &w.Reason); err != nil {
+		return nil, err
+	}
+	w.StartsAt = startsAt.UTC().Format(time.RFC3339)
+	w.EndsAt = endsAt.UTC().Format(time.RFC3339)
+	return &w, nil
+}
+
+// Create refuses windows that overlap schedules the train still runs. The
+// train row is locked so a schedule cannot be assigned in between.
+func (r *maintenanceRepository) Create(ctx context.Context, trainId int64, startsAt, endsAt time.Time, reason string) (*pb.MaintenanceWindow, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var id int64
+	err = tx.QueryRow(ctx, `SELECT id FROM trains WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, trainId).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTrainNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT id FROM schedules
+		WHERE train_id = $1 AND deleted_at IS NULL AND status <> 'cancelled'
+		  AND departure_time < $3 AND arrival_time > $2
+		ORDER BY departure_time`, trainId, startsAt, endsAt)
+	if err != nil {
+		return nil, err
+	}
+	var conflicts []int64
+	for rows.Next() {
+		var scheduleId int64
+		if err := rows.Scan(&scheduleId); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		conflicts = append(conflicts, scheduleId)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 {
+		return nil, &MaintenanceConflictError{ScheduleIDs: conflicts}
+	}
+
+	window, err := scanMaintenanceWindow(tx.QueryRow(ctx, `
+		INSERT INTO maintenance_windows (train_id, starts_at, ends_at, reason, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NOW())
+		RETURNING `+maintenanceColumns,
+		trainId, startsAt, endsAt, reason))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return window, nil
+}
+
+// ListByTrain returns the windows still running or starting after since.
+func (r *maintenanceRepository) ListByTrain(ctx context.Context, trainId int64, since time.Time) ([]*pb.MaintenanceWindow, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+maintenanceColumns+`
+		FROM maintenance_windows
+		WHERE train_id = $1 AND deleted_at IS NULL AND ends_at > $2
+		ORDER BY starts_at ASC`, trainId, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var windows []*pb.MaintenanceWindow
+	for rows.Next() {
+		w, err := scanMaintenanceWindow(rows)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, w)
+	}
+
+	return windows, rows.Err()
+}
+
+func (r *maintenanceRepository) Delete(ctx context.Context, id int64) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE maintenance_windows SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrMaintenanceWindowNotFound
+	}
+	return nil
+}
@@ -2,17 +2,23 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+
 	pb "ticket-booking/proto/train"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type TrainRepository interface {
 	Create(ctx context.Context, req *pb.CreateTrainRequest) (*pb.Train, error)
 	GetByID(ctx context.Context, id int64) (*pb.Train, error)
-	List(ctx context.Context, page, limit int32) ([]*pb.Train, int32, error)
+	List(ctx context.Context, search TrainSearch) ([]*pb.Train, int32, error)
 	Update(ctx context.Context, req *pb.UpdateTrainRequest) (*pb.Train, error)
+	SetAmenities(ctx context.Context, trainId int64, amenities []string) (*pb.Train, error)
 	Delete(ctx context.Context, id int64) error
+	ListCapacityChanges(ctx context.Context, trainId int64) ([]*pb.CapacityChange, error)
 }
 
 type trainRepository struct {
@@ -23,43 +29,73 @@ func NewTrainRepository(db *pgxpool.Pool) TrainRepository {
 	return &trainRepository{db: db}
 }
 
+const trainColumns = `t.id, t.name, t.type, t.capacity, t.status, ` + pb.AmenitiesSQL
+
+func scanTrain(row pgx.Row) (*pb.Train, error) {
+	var train pb.Train
+	err := row.Scan(&train.Id, &train.Name, &train.Type, &train.Capacity, &train.Status, &train.Amenities)
+	if err != nil {
+		return nil, err
+	}
+	return &train, nil
+}
+
+// Create inserts the train together with its coaches, if any, in one
+// transaction; with coaches the stored capacity is their seat total.
 func (r *trainRepository) Create(ctx context.Context, req *pb.CreateTrainRequest) (*pb.Train, error) {
-	var id int64
-	err := r.db.QueryRow(ctx, `
-		INSERT INTO trains (name, type, capacity, status, created_at) 
-		VALUES ($1, $2, $3, 'active', NOW()) RETURNING id`,
-		req.Name, req.Type, req.Capacity).Scan(&id)
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer tx.Rollback(ctx)
 
-	return &pb.Train{
-		Id:       id,
-		Name:     req.Name,
-		Type:     req.Type,
-		Capacity: req.Capacity,
-		Status:   "active",
-	}, nil
-}
+	capacity := req.Capacity
+	if len(req.Coaches) > 0 {
+		capacity = 0
+		for _, coach := range req.Coaches {
+			capacity += coach.SeatCount
+		}
+	}
 
-func (r *trainRepository) GetByID(ctx context.Context, id int64) (*pb.Train, error) {
-	var train pb.Train
-	err := r.db.QueryRow(ctx, `
-		SELECT id, name, type, capacity, status 
-		FROM trains WHERE id = $1 AND deleted_at IS NULL`, id).Scan(
-		&train.Id, &train.Name, &train.Type, &train.Capacity, &train.Status)
+	var id int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO trains (name, type, capacity, status, amenities, created_at) 
+		VALUES ($1, $2, $3, 'active', $4, NOW()) RETURNING id`,
+		req.Name, req.Type, capacity, nonNil(req.Amenities)).Scan(&id)
 	if err != nil {
 		return nil, err
 	}
-	return &train, nil
+
+	for _, coach := range req.Coaches {
+		coach.TrainId = id
+		if _, err := insertCoach(ctx, tx, coach); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+func (r *trainRepository) GetByID(ctx context.Context, id int64) (*pb.Train, error) {
+	return scanTrain(r.db.QueryRow(ctx, `
+		SELECT `+trainColumns+`
+		FROM trains t WHERE t.id = $1 AND t.deleted_at IS NULL`, id))
 }
 
-func (r *trainRepository) List(ctx context.Context, page, limit int32) ([]*pb.Train, int32, error) {
-	offset := (page - 1) * limit
-	rows, err := r.db.Query(ctx, `
-		SELECT id, name, type, capacity, status 
-		FROM trains WHERE deleted_at IS NULL 
-		ORDER BY id DESC LIMIT $1 OFFSET $2`, limit, offset)
+func (r *trainRepository) List(ctx context.Context, search TrainSearch) ([]*pb.Train, int32, error) {
+	where, args := search.where()
+
+	query := `
+		SELECT ` + trainColumns + `
+		FROM trains t` + where + `
+		ORDER BY t.id DESC`
+	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	pageArgs := append(append([]interface{}{}, args...), search.Limit, (search.Page-1)*search.Limit)
+
+	rows, err := r.db.Query(ctx, query, pageArgs...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -67,16 +103,15 @@ func (r *trainRepository) List(ctx context.Context, page, limit int32) ([]*pb.Tr
 
 	var trains []*pb.Train
 	for rows.Next() {
-		var train pb.Train
-		err := rows.Scan(&train.Id, &train.Name, &train.Type, &train.Capacity, &train.Status)
+		train, err := scanTrain(rows)
 		if err != nil {
 			return nil, 0, err
 		}
-		trains = append(trains, &train)
+		trains = append(trains, train)
 	}
 
 	var total int32
-	err = r.db.QueryRow(ctx, `SELECT COUNT(*) FROM trains WHERE deleted_at IS NULL`).Scan(&total)
+	err = r.db.QueryRow(ctx, `SELECT COUNT(*) FROM trains t`+where, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -84,18 +119,68 @@ func (r *trainRepository) List(ctx context.Context, page, limit int32) ([]*pb.Tr
 	return trains, total, nil
 }
 
+// Update only writes capacity for trains without a composition; once coaches
+// exist the capacity is derived from them and sending one is an error. A new
+// capacity is passed on to upcoming schedules and refused if it is below what
+// one of them has sold. An empty status and a zero capacity keep the current
+// ones.
 func (r *trainRepository) Update(ctx context.Context, req *pb.UpdateTrainRequest) (*pb.Train, error) {
-	_, err := r.db.Exec(ctx, `
-		UPDATE trains SET name = $1, type = $2, capacity = $3, status = $4, updated_at = NOW() 
-		WHERE id = $5 AND deleted_at IS NULL`,
-		req.Name, req.Type, req.Capacity, req.Status, req.TrainId)
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var hasCoaches bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM coaches WHERE train_id = t.id AND deleted_at IS NULL)
+		FROM trains t WHERE t.id = $1 AND t.deleted_at IS NULL
+		FOR UPDATE OF t`, req.TrainId).Scan(&hasCoaches)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTrainNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if hasCoaches && req.Capacity > 0 {
+		return nil, ErrCapacityFromCoaches
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE trains SET name = $1, type = $2, status = COALESCE(NULLIF($3, ''), status), updated_at = NOW()
+		WHERE id = $4`,
+		req.Name, req.Type, req.Status, req.TrainId)
 	if err != nil {
 		return nil, err
 	}
 
+	if req.Capacity > 0 {
+		if err := setCapacity(ctx, tx, req.TrainId, req.Capacity, CapacitySourceTrainUpdate); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
 	return r.GetByID(ctx, req.TrainId)
 }
 
+// SetAmenities replaces the train-wide amenities; coach amenities are kept.
+func (r *trainRepository) SetAmenities(ctx context.Context, trainId int64, amenities []string) (*pb.Train, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE trains SET amenities = $2, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL`, trainId, nonNil(amenities))
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, ErrTrainNotFound
+	}
+
+	return r.GetByID(ctx, trainId)
+}
+
 func (r *trainRepository) Delete(ctx context.Context, id int64) error {
 	_, err := r.db.Exec(ctx, `
 		UPDATE trains SET deleted_at = NOW() WHERE id = $1`, id)
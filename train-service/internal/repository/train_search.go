@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	pb "ticket-booking/proto/train"
+)
+
+// TrainSearch is the typed form of a train list query. Zero values mean
+// "no filter"; a train matches Amenities only if it offers all of them,
+// counting its coaches' amenities too.
+type TrainSearch struct {
+	Query     string
+	Type      string
+	Status    string
+	Amenities []string
+	Page      int32
+	Limit     int32
+}
+
+func (q *TrainSearch) where() (string, []interface{}) {
+	conds := []string{"t.deleted_at IS NULL"}
+	var args []interface{}
+
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if q.Query != "" {
+		add("t.name ILIKE $%d", "%"+q.Query+"%")
+	}
+	if q.Type != "" {
+		add("LOWER(t.type) = LOWER($%d)", q.Type)
+	}
+	if q.Status != "" {
+		add("t.status = $%d", q.Status)
+	}
+	if len(q.Amenities) > 0 {
+		add("$%d::text[] <@ "+pb.AmenitiesSQL, q.Amenities)
+	}
+
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
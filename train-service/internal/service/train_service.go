@@ -2,27 +2,78 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
 	pb "ticket-booking/proto/train"
+	"ticket-booking/train-service/internal/layout"
 	"ticket-booking/train-service/internal/repository"
 )
 
 type TrainService interface {
 	CreateTrain(ctx context.Context, req *pb.CreateTrainRequest) (*pb.Train, error)
 	GetTrain(ctx context.Context, id int64) (*pb.Train, error)
-	ListTrains(ctx context.Context, page, limit int32) ([]*pb.Train, int32, error)
+	ListTrains(ctx context.Context, req *pb.ListTrainsRequest) ([]*pb.Train, int32, error)
 	UpdateTrain(ctx context.Context, req *pb.UpdateTrainRequest) (*pb.Train, error)
 	DeleteTrain(ctx context.Context, id int64) error
+	AddCoach(ctx context.Context, req *pb.AddCoachRequest) (*pb.Coach, int32, error)
+	UpdateCoach(ctx context.Context, req *pb.UpdateCoachRequest) (*pb.Coach, int32, error)
+	RemoveCoach(ctx context.Context, coachId int64) (int32, error)
+	ListCoaches(ctx context.Context, trainId int64) ([]*pb.Coach, int32, error)
+	GetSeatMap(ctx context.Context, trainId int64, class string) (*pb.GetSeatMapResponse, error)
+	AddMaintenanceWindow(ctx context.Context, req *pb.AddMaintenanceWindowRequest) (*pb.MaintenanceWindow, error)
+	ListMaintenanceWindows(ctx context.Context, trainId int64, includePast bool) ([]*pb.MaintenanceWindow, error)
+	RemoveMaintenanceWindow(ctx context.Context, windowId int64) error
+	ListCapacityChanges(ctx context.Context, trainId int64) ([]*pb.CapacityChange, error)
+	ListAmenities(ctx context.Context) ([]*pb.Amenity, error)
+	SetTrainAmenities(ctx context.Context, trainId int64, amenities []string) (*pb.Train, error)
 }
 
+// Train statuses. Only active trains can be given new schedules; maintenance
+// covers open-ended work, dated work goes into maintenance windows.
+const (
+	TrainStatusActive      = "active"
+	TrainStatusMaintenance = "maintenance"
+	TrainStatusRetired     = "retired"
+)
+
 type trainService struct {
-	trainRepo repository.TrainRepository
+	trainRepo       repository.TrainRepository
+	coachRepo       repository.CoachRepository
+	maintenanceRepo repository.MaintenanceRepository
+	amenityRepo     repository.AmenityRepository
 }
 
-func NewTrainService(trainRepo repository.TrainRepository) TrainService {
-	return &trainService{trainRepo: trainRepo}
+func NewTrainService(trainRepo repository.TrainRepository, coachRepo repository.CoachRepository, maintenanceRepo repository.MaintenanceRepository, amenityRepo repository.AmenityRepository) TrainService {
+	return &trainService{trainRepo: trainRepo, coachRepo: coachRepo, maintenanceRepo: maintenanceRepo, amenityRepo: amenityRepo}
 }
 
 func (s *trainService) CreateTrain(ctx context.Context, req *pb.CreateTrainRequest) (*pb.Train, error) {
+	catalogue, err := s.amenityCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Amenities, err = normalizeAmenities(req.Amenities, catalogue); err != nil {
+		return nil, err
+	}
+
+	numbers := make(map[int32]bool, len(req.Coaches))
+	for _, coach := range req.Coaches {
+		if err := layout.Normalize(coach); err != nil {
+			return nil, fmt.Errorf("coach %d: %w", coach.CoachNumber, err)
+		}
+		if coach.Amenities, err = normalizeAmenities(coach.Amenities, catalogue); err != nil {
+			return nil, fmt.Errorf("coach %d: %w", coach.CoachNumber, err)
+		}
+		if numbers[coach.CoachNumber] {
+			return nil, repository.ErrCoachNumberConflict
+		}
+		numbers[coach.CoachNumber] = true
+	}
+
 	return s.trainRepo.Create(ctx, req)
 }
 
@@ -30,20 +81,246 @@ func (s *trainService) GetTrain(ctx context.Context, id int64) (*pb.Train, error
 	return s.trainRepo.GetByID(ctx, id)
 }
 
-func (s *trainService) ListTrains(ctx context.Context, page, limit int32) ([]*pb.Train, int32, error) {
-	if page <= 0 {
-		page = 1
+func (s *trainService) ListTrains(ctx context.Context, req *pb.ListTrainsRequest) ([]*pb.Train, int32, error) {
+	search := repository.TrainSearch{
+		Query:  strings.TrimSpace(req.Query),
+		Type:   strings.TrimSpace(req.Type),
+		Status: req.Status,
+		Page:   req.Page,
+		Limit:  req.Limit,
+	}
+	switch search.Status {
+	case "", TrainStatusActive, TrainStatusMaintenance, TrainStatusRetired:
+	default:
+		return nil, 0, errors.New("invalid status, expected active, maintenance or retired")
+	}
+	for _, a := range req.Amenities {
+		if a = strings.ToLower(strings.TrimSpace(a)); a != "" {
+			search.Amenities = append(search.Amenities, a)
+		}
+	}
+
+	if search.Page <= 0 {
+		search.Page = 1
+	}
+	if search.Limit <= 0 {
+		search.Limit = 10
 	}
-	if limit <= 0 {
-		limit = 10
+	if search.Limit > 100 {
+		search.Limit = 100
 	}
-	return s.trainRepo.List(ctx, page, limit)
+	return s.trainRepo.List(ctx, search)
 }
 
 func (s *trainService) UpdateTrain(ctx context.Context, req *pb.UpdateTrainRequest) (*pb.Train, error) {
+	switch req.Status {
+	case "", TrainStatusActive, TrainStatusMaintenance, TrainStatusRetired:
+	default:
+		return nil, errors.New("invalid status, expected active, maintenance or retired")
+	}
+	if req.Capacity < 0 {
+		return nil, errors.New("capacity must not be negative")
+	}
+
 	return s.trainRepo.Update(ctx, req)
 }
 
 func (s *trainService) DeleteTrain(ctx context.Context, id int64) error {
 	return s.trainRepo.Delete(ctx, id)
 }
+
+func (s *trainService) AddCoach(ctx context.Context, req *pb.AddCoachRequest) (*pb.Coach, int32, error) {
+	if req.TrainId <= 0 {
+		return nil, 0, errors.New("train id is required")
+	}
+
+	coach := &pb.Coach{
+		TrainId:         req.TrainId,
+		CoachNumber:     req.CoachNumber,
+		Class:           req.Class,
+		Rows:            req.Rows,
+		Columns:         req.Columns,
+		AisleAfter:      req.AisleAfter,
+		BlockedSeats:    req.BlockedSeats,
+		AccessibleSeats: req.AccessibleSeats,
+	}
+	if err := layout.Normalize(coach); err != nil {
+		return nil, 0, err
+	}
+	catalogue, err := s.amenityCodes(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if coach.Amenities, err = normalizeAmenities(req.Amenities, catalogue); err != nil {
+		return nil, 0, err
+	}
+
+	return s.coachRepo.Create(ctx, coach)
+}
+
+func (s *trainService) UpdateCoach(ctx context.Context, req *pb.UpdateCoachRequest) (*pb.Coach, int32, error) {
+	if req.CoachId <= 0 {
+		return nil, 0, errors.New("coach id is required")
+	}
+
+	coach := &pb.Coach{
+		Id:              req.CoachId,
+		CoachNumber:     req.CoachNumber,
+		Class:           req.Class,
+		Rows:            req.Rows,
+		Columns:         req.Columns,
+		AisleAfter:      req.AisleAfter,
+		BlockedSeats:    req.BlockedSeats,
+		AccessibleSeats: req.AccessibleSeats,
+	}
+	if err := layout.Normalize(coach); err != nil {
+		return nil, 0, err
+	}
+	catalogue, err := s.amenityCodes(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if coach.Amenities, err = normalizeAmenities(req.Amenities, catalogue); err != nil {
+		return nil, 0, err
+	}
+
+	return s.coachRepo.Update(ctx, coach)
+}
+
+func (s *trainService) RemoveCoach(ctx context.Context, coachId int64) (int32, error) {
+	return s.coachRepo.Delete(ctx, coachId)
+}
+
+// ListCoaches returns the composition in coach order with the capacity it
+// adds up to.
+func (s *trainService) ListCoaches(ctx context.Context, trainId int64) ([]*pb.Coach, int32, error) {
+	if _, err := s.trainRepo.GetByID(ctx, trainId); err != nil {
+		return nil, 0, repository.ErrTrainNotFound
+	}
+
+	coaches, err := s.coachRepo.ListByTrain(ctx, trainId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var capacity int32
+	for _, c := range coaches {
+		capacity += c.SeatCount
+	}
+	return coaches, capacity, nil
+}
+
+// GetSeatMap generates every seat of the train's composition, optionally for
+// one class only. The inventory always covers the whole train.
+func (s *trainService) GetSeatMap(ctx context.Context, trainId int64, class string) (*pb.GetSeatMapResponse, error) {
+	class = strings.ToLower(strings.TrimSpace(class))
+	coaches, capacity, err := s.ListCoaches(ctx, trainId)
+	if err != nil {
+		return nil, err
+	}
+	if class != "" && !layout.IsClass(class) {
+		return nil, errors.New("unknown class")
+	}
+
+	resp := &pb.GetSeatMapResponse{
+		TrainId:   trainId,
+		Capacity:  capacity,
+		Inventory: layout.Inventory(coaches),
+	}
+	for _, c := range coaches {
+		if class != "" && c.Class != class {
+			continue
+		}
+		resp.Coaches = append(resp.Coaches, &pb.CoachSeatMap{Coach: c, Seats: layout.Seats(c)})
+	}
+	return resp, nil
+}
+
+func (s *trainService) AddMaintenanceWindow(ctx context.Context, req *pb.AddMaintenanceWindowRequest) (*pb.MaintenanceWindow, error) {
+	startsAt, err := time.Parse(time.RFC3339, req.StartsAt)
+	if err != nil {
+		return nil, errors.New("starts_at must be an RFC3339 timestamp")
+	}
+	endsAt, err := time.Parse(time.RFC3339, req.EndsAt)
+	if err != nil {
+		return nil, errors.New("ends_at must be an RFC3339 timestamp")
+	}
+	if !endsAt.After(startsAt) {
+		return nil, errors.New("maintenance window must end after it starts")
+	}
+	if !endsAt.After(time.Now()) {
+		return nil, errors.New("maintenance window is already over")
+	}
+
+	return s.maintenanceRepo.Create(ctx, req.TrainId, startsAt, endsAt, strings.TrimSpace(req.Reason))
+}
+
+func (s *trainService) ListMaintenanceWindows(ctx context.Context, trainId int64, includePast bool) ([]*pb.MaintenanceWindow, error) {
+	since := time.Now()
+	if includePast {
+		since = time.Time{}
+	}
+	return s.maintenanceRepo.ListByTrain(ctx, trainId, since)
+}
+
+func (s *trainService) RemoveMaintenanceWindow(ctx context.Context, windowId int64) error {
+	return s.maintenanceRepo.Delete(ctx, windowId)
+}
+
+func (s *trainService) ListCapacityChanges(ctx context.Context, trainId int64) ([]*pb.CapacityChange, error) {
+	if _, err := s.trainRepo.GetByID(ctx, trainId); err != nil {
+		return nil, repository.ErrTrainNotFound
+	}
+	return s.trainRepo.ListCapacityChanges(ctx, trainId)
+}
+
+func (s *trainService) ListAmenities(ctx context.Context) ([]*pb.Amenity, error) {
+	return s.amenityRepo.List(ctx)
+}
+
+func (s *trainService) SetTrainAmenities(ctx context.Context, trainId int64, amenities []string) (*pb.Train, error) {
+	if trainId <= 0 {
+		return nil, errors.New("train id is required")
+	}
+	catalogue, err := s.amenityCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if amenities, err = normalizeAmenities(amenities, catalogue); err != nil {
+		return nil, err
+	}
+
+	return s.trainRepo.SetAmenities(ctx, trainId, amenities)
+}
+
+func (s *trainService) amenityCodes(ctx context.Context) (map[string]bool, error) {
+	amenities, err := s.amenityRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	codes := make(map[string]bool, len(amenities))
+	for _, a := range amenities {
+		codes[a.Code] = true
+	}
+	return codes, nil
+}
+
+// normalizeAmenities lower-cases and de-duplicates amenity codes and rejects
+// any that are not in the catalogue.
+func normalizeAmenities(amenities []string, catalogue map[string]bool) ([]string, error) {
+	seen := make(map[string]bool, len(amenities))
+	var codes []string
+	for _, a := range amenities {
+		a = strings.ToLower(strings.TrimSpace(a))
+		if a == "" || seen[a] {
+			continue
+		}
+		if !catalogue[a] {
+			return nil, fmt.Errorf("unknown amenity %q", a)
+		}
+		seen[a] = true
+		codes = append(codes, a)
+	}
+	sort.Strings(codes)
+	return codes, nil
+}
@@ -34,9 +34,12 @@ func main() {
 
 	// Initialize repositories
 	trainRepo := repository.NewTrainRepository(pool)
+	coachRepo := repository.NewCoachRepository(pool)
+	maintenanceRepo := repository.NewMaintenanceRepository(pool)
+	amenityRepo := repository.NewAmenityRepository(pool)
 
 	// Initialize services
-	trainService := service.NewTrainService(trainRepo)
+	trainService := service.NewTrainService(trainRepo, coachRepo, maintenanceRepo, amenityRepo)
 
 	// Start HTTP server for health check
 	go func() {
@@ -55,7 +58,9 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(handler.Permissions.UnaryServerInterceptor()),
+	)
 	pb.RegisterTrainServiceServer(grpcServer, handler.NewGrpcServer(trainService))
 
 	log.Printf("%s gRPC server listening on port %d", cfg.ServiceName, cfg.GRPCPort)
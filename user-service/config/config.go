@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -18,7 +19,24 @@ type Config struct {
 	DBUser      string
 	DBPassword  string
 	DBSSLMode   string
-	JWTSecret   string
+	// JWTKeysDir holds the <kid>.pem RSA keys access tokens are signed
+	// with; JWTActiveKid picks the signing key (default: last by name)
+	JWTKeysDir   string
+	JWTActiveKid string
+	// FieldKey is the base64 AES-256 key for personal data encrypted at
+	// rest, such as travellers' ID numbers. FieldRetiredKeys are earlier
+	// keys that values sealed before a rotation can still be read with.
+	FieldKey         string
+	FieldRetiredKeys []string
+	// PublicURL is where users reach the gateway, for links in emails
+	PublicURL string
+	// Emails go through SMTPHost when set, otherwise into files in MailDir
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	MailFrom     string
+	MailDir      string
 }
 
 func LoadEnv(prefix string) (*Config, error) {
@@ -33,6 +51,10 @@ func LoadEnv(prefix string) (*Config, error) {
 		}
 		return "", fmt.Errorf("missing env %s or %s", prefix+k, k)
 	}
+	getOpt := func(k string) string {
+		v, _ := getReq(k)
+		return v
+	}
 
 	name, err := getReq("SERVICE_NAME")
 	if err != nil {
@@ -74,22 +96,52 @@ func LoadEnv(prefix string) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
-	jwtSecret, err := getReq("JWT_SECRET")
-	if err != nil {
-		return nil, err
+
+	publicURL := getOpt("PUBLIC_URL")
+	if publicURL == "" {
+		publicURL = "http://localhost:8080"
+	}
+
+	smtpPort := 587
+	if v := getOpt("SMTP_PORT"); v != "" {
+		if smtpPort, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("invalid SMTP_PORT: %v", err)
+		}
+	}
+	mailFrom := getOpt("MAIL_FROM")
+	if mailFrom == "" {
+		mailFrom = "Ticket Booking <no-reply@localhost>"
+	}
+	mailDir := getOpt("MAIL_DIR")
+	if mailDir == "" {
+		mailDir = "mail"
+	}
+	var retiredKeys []string
+	if v := getOpt("FIELD_ENCRYPTION_RETIRED_KEYS"); v != "" {
+		retiredKeys = strings.Split(v, ",")
 	}
 
 	return &Config{
-		ServiceName: name,
-		Port:        port,
-		GRPCPort:    grpcPort,
-		DBHost:      host,
-		DBPort:      dbPort,
-		DBName:      dbName,
-		DBUser:      dbUser,
-		DBPassword:  dbPass,
-		DBSSLMode:   sslMode,
-		JWTSecret:   jwtSecret,
+		ServiceName:      name,
+		Port:             port,
+		GRPCPort:         grpcPort,
+		DBHost:           host,
+		DBPort:           dbPort,
+		DBName:           dbName,
+		DBUser:           dbUser,
+		DBPassword:       dbPass,
+		DBSSLMode:        sslMode,
+		JWTKeysDir:       getOpt("JWT_KEYS_DIR"),
+		JWTActiveKid:     getOpt("JWT_ACTIVE_KID"),
+		FieldKey:         getOpt("FIELD_ENCRYPTION_KEY"),
+		FieldRetiredKeys: retiredKeys,
+		PublicURL:        strings.TrimSuffix(publicURL, "/"),
+		SMTPHost:         getOpt("SMTP_HOST"),
+		SMTPPort:         smtpPort,
+		SMTPUsername:     getOpt("SMTP_USERNAME"),
+		SMTPPassword:     getOpt("SMTP_PASSWORD"),
+		MailFrom:         mailFrom,
+		MailDir:          mailDir,
 	}, nil
 }
 
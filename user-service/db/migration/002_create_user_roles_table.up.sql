@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    role VARCHAR(20) NOT NULL CHECK (role IN ('operator', 'admin', 'support')),
+    granted_by BIGINT NULL REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, role)
+);
+
+-- Every user is a customer, so only the extra roles are stored. The first
+-- admin has to be granted by hand:
+--   INSERT INTO user_roles (user_id, role) VALUES (<user id>, 'admin');
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS notification_outbox;
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
\ No newline at end of file
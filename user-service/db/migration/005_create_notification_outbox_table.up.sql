@@ -0,0 +1,20 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale VARCHAR(10) NOT NULL DEFAULT 'en';
+
+CREATE TABLE IF NOT EXISTS notification_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    template VARCHAR(50) NOT NULL,
+    locale VARCHAR(10) NOT NULL DEFAULT 'en',
+    recipient VARCHAR(100) NOT NULL,
+    data JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_notification_outbox_pending ON notification_outbox(next_attempt_at) WHERE status = 'pending';
+
+-- Any service may insert into the outbox in the same transaction as the
+-- change the email is about; user-service delivers it.
\ No newline at end of file
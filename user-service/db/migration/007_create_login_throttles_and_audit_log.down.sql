@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS audit_log;
+DROP TABLE IF EXISTS login_throttles;
\ No newline at end of file
@@ -0,0 +1,23 @@
+-- Failed logins per account (scope 'user', keyed by lowercased username)
+-- and per client address (scope 'ip'). Kept in Postgres so every replica
+-- sees the same counts.
+CREATE TABLE IF NOT EXISTS login_throttles (
+    scope VARCHAR(10) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    failures INTEGER NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP NULL,
+    last_failure_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (scope, key)
+);
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    action VARCHAR(50) NOT NULL,
+    user_id BIGINT NULL REFERENCES users(id),
+    actor_id BIGINT NULL REFERENCES users(id),
+    ip VARCHAR(64) NULL,
+    detail JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_user_id ON audit_log(user_id, created_at);
\ No newline at end of file
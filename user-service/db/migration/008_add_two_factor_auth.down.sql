@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS login_challenges;
+DROP TABLE IF EXISTS totp_recovery_codes;
+DROP TABLE IF EXISTS user_totp;
+ALTER TABLE users DROP COLUMN IF EXISTS two_factor_required;
\ No newline at end of file
@@ -0,0 +1,32 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS two_factor_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- enabled_at stays NULL until the user confirms enrollment with a code;
+-- last_step is the last TOTP time step used, so a code works only once
+CREATE TABLE IF NOT EXISTS user_totp (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id),
+    secret VARCHAR(64) NOT NULL,
+    enabled_at TIMESTAMP NULL,
+    last_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS totp_recovery_codes (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    code_hash CHAR(64) NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_totp_recovery_codes_user_id ON totp_recovery_codes(user_id);
+
+-- Issued when the password was right but a second factor is still needed
+CREATE TABLE IF NOT EXISTS login_challenges (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE email_verification_tokens DROP COLUMN IF EXISTS email;
+ALTER TABLE users
+    DROP COLUMN IF EXISTS date_of_birth,
+    DROP COLUMN IF EXISTS phone,
+    DROP COLUMN IF EXISTS full_name;
\ No newline at end of file
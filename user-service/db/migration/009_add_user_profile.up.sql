@@ -0,0 +1,8 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS full_name VARCHAR(100) NULL,
+    ADD COLUMN IF NOT EXISTS phone VARCHAR(20) NULL,
+    ADD COLUMN IF NOT EXISTS date_of_birth DATE NULL;
+
+-- A token with an email confirms a change to that address; the account
+-- keeps its old address until then
+ALTER TABLE email_verification_tokens ADD COLUMN IF NOT EXISTS email VARCHAR(100) NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS travellers;
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- Co-travellers a user books for. id_number is encrypted by user-service
+-- (AES-GCM, base64); booking-service copies the name and passenger type
+-- into its bookings and never sees the ID number
+CREATE TABLE IF NOT EXISTS travellers (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    full_name VARCHAR(100) NOT NULL,
+    id_number TEXT NULL,
+    passenger_type VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_travellers_user_id ON travellers(user_id);
\ No newline at end of file
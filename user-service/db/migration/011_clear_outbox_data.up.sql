@@ -0,0 +1,3 @@
+-- Delivered and abandoned emails no longer need their template data, which
+-- holds the links and tokens they carried
+UPDATE notification_outbox SET data = '{}' WHERE status IN ('sent', 'failed');
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_password_reset_tokens_requested_ip;
+ALTER TABLE password_reset_tokens DROP COLUMN IF EXISTS requested_ip;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- Where a reset was requested from, so requests can be limited per client
+-- address as well as per account
+ALTER TABLE password_reset_tokens ADD COLUMN IF NOT EXISTS requested_ip VARCHAR(64) NULL;
+
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_requested_ip ON password_reset_tokens(requested_ip, created_at);
\ No newline at end of file
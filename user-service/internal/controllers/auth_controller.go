@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"ticket-booking/user-service/internal/helper"
@@ -27,7 +28,7 @@ func (c *AuthController) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, token, err := c.userService.Register(r.Context(), req.Username, req.Email, req.Password, req.ConfirmPassword)
+	user, tokens, err := c.userService.Register(r.Context(), req.Username, req.Email, req.Password, req.ConfirmPassword, req.Locale, req.FullName)
 	if err != nil {
 		helper.WriteBadRequest(w, "Registration failed", err)
 		return
@@ -39,7 +40,9 @@ func (c *AuthController) Register(w http.ResponseWriter, r *http.Request) {
 			Username: user.Username,
 			Email:    user.Email,
 		},
-		Token: token,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
 	}
 
 	helper.WriteSuccess(w, "User registered successfully", response)
@@ -53,7 +56,11 @@ func (c *AuthController) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, token, err := c.userService.Login(r.Context(), req.Username, req.Password)
+	user, tokens, err := c.userService.Login(r.Context(), req.Username, req.Password, helper.ClientIP(r))
+	if errors.Is(err, service.ErrLoginLocked) {
+		helper.WriteError(w, http.StatusTooManyRequests, "Login failed", err)
+		return
+	}
 	if err != nil {
 		helper.WriteUnauthorized(w, "Login failed", err)
 		return
@@ -65,7 +72,11 @@ func (c *AuthController) Login(w http.ResponseWriter, r *http.Request) {
 			Username: user.Username,
 			Email:    user.Email,
 		},
-		Token: token,
+		Token:             tokens.AccessToken,
+		RefreshToken:      tokens.RefreshToken,
+		ExpiresIn:         tokens.ExpiresIn,
+		TwoFactorRequired: tokens.ChallengeToken != "",
+		ChallengeToken:    tokens.ChallengeToken,
 	}
 
 	helper.WriteSuccess(w, "Login successful", response)
@@ -2,8 +2,17 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"ticket-booking/proto/auth"
 	pb "ticket-booking/proto/user"
+	"ticket-booking/user-service/internal/helper"
+	"ticket-booking/user-service/internal/repository"
 	"ticket-booking/user-service/internal/service"
 )
 
@@ -19,31 +28,71 @@ func NewGrpcServer(userService service.UserService) *GrpcServer {
 }
 
 func (s *GrpcServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
-	user, token, err := s.userService.Register(ctx, req.Username, req.Email, req.Password, req.ConfirmPassword)
+	user, tokens, err := s.userService.Register(ctx, req.Username, req.Email, req.Password, req.ConfirmPassword, req.Locale, req.FullName)
 	if err != nil {
 		return nil, err
 	}
 
 	return &pb.RegisterResponse{
-		UserId:   user.ID,
-		Username: user.Username,
-		Email:    user.Email,
-		Token:    token,
+		UserId:        user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		Token:         tokens.AccessToken,
+		Roles:         user.Roles,
+		RefreshToken:  tokens.RefreshToken,
+		ExpiresIn:     tokens.ExpiresIn,
+		EmailVerified: user.EmailVerifiedAt != nil,
 	}, nil
 }
 
 func (s *GrpcServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
-	user, token, err := s.userService.Login(ctx, req.Username, req.Password)
+	user, tokens, err := s.userService.Login(ctx, req.Username, req.Password, peerIP(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return loginResponse(user, tokens), nil
+}
+
+// LoginTwoFactor finishes a login that returned a challenge.
+func (s *GrpcServer) LoginTwoFactor(ctx context.Context, req *pb.LoginTwoFactorRequest) (*pb.LoginResponse, error) {
+	user, tokens, err := s.userService.LoginTwoFactor(ctx, req.ChallengeToken, req.Code, peerIP(ctx))
 	if err != nil {
 		return nil, err
 	}
 
+	return loginResponse(user, tokens), nil
+}
+
+// peerIP is the address of the connection the call came in on.
+func peerIP(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return helper.HostOnly(p.Addr.String())
+	}
+	return ""
+}
+
+func loginResponse(user *repository.User, tokens *service.Tokens) *pb.LoginResponse {
+	if tokens.ChallengeToken != "" {
+		return &pb.LoginResponse{
+			UserId:            user.ID,
+			Username:          user.Username,
+			ExpiresIn:         tokens.ExpiresIn,
+			TwoFactorRequired: true,
+			ChallengeToken:    tokens.ChallengeToken,
+		}
+	}
+
 	return &pb.LoginResponse{
-		UserId:   user.ID,
-		Username: user.Username,
-		Email:    user.Email,
-		Token:    token,
-	}, nil
+		UserId:        user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		Token:         tokens.AccessToken,
+		Roles:         user.Roles,
+		RefreshToken:  tokens.RefreshToken,
+		ExpiresIn:     tokens.ExpiresIn,
+		EmailVerified: user.EmailVerifiedAt != nil,
+	}
 }
 
 func (s *GrpcServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
@@ -52,15 +101,90 @@ func (s *GrpcServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.G
 		return nil, err
 	}
 
-	return &pb.GetUserResponse{
-		UserId:   user.ID,
-		Username: user.Username,
-		Email:    user.Email,
+	return getUserResponse(user), nil
+}
+
+func getUserResponse(user *repository.User) *pb.GetUserResponse {
+	resp := &pb.GetUserResponse{
+		UserId:            user.ID,
+		Username:          user.Username,
+		Email:             user.Email,
+		Roles:             user.Roles,
+		EmailVerified:     user.EmailVerifiedAt != nil,
+		TwoFactorEnabled:  user.TwoFactorEnabled,
+		TwoFactorRequired: user.TwoFactorRequired,
+		FullName:          user.FullName,
+		Phone:             user.Phone,
+		Locale:            user.Locale,
+	}
+	if user.DateOfBirth != nil {
+		resp.DateOfBirth = user.DateOfBirth.Format("2006-01-02")
+	}
+	return resp
+}
+
+func (s *GrpcServer) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	user, tokens, err := s.userService.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RefreshTokenResponse{
+		UserId:       user.ID,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+		Roles:        user.Roles,
+	}, nil
+}
+
+func (s *GrpcServer) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	if err := s.userService.Logout(ctx, req.RefreshToken); err != nil {
+		return nil, err
+	}
+
+	return &pb.LogoutResponse{Success: true}, nil
+}
+
+func (s *GrpcServer) CheckSession(ctx context.Context, req *pb.CheckSessionRequest) (*pb.CheckSessionResponse, error) {
+	revoked, err := s.userService.IsSessionRevoked(ctx, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CheckSessionResponse{Revoked: revoked}, nil
+}
+
+func (s *GrpcServer) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
+	if err := s.userService.VerifyEmail(ctx, req.Token); err != nil {
+		return &pb.VerifyEmailResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.VerifyEmailResponse{
+		Success: true,
+		Message: "Email verified",
+	}, nil
+}
+
+func (s *GrpcServer) ResendVerification(ctx context.Context, req *pb.ResendVerificationRequest) (*pb.ResendVerificationResponse, error) {
+	if err := s.userService.ResendVerification(ctx, req.Email); err != nil {
+		return &pb.ResendVerificationResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.ResendVerificationResponse{
+		Success: true,
+		Message: "If the address belongs to an unverified account, a new link is on its way",
 	}, nil
 }
 
 func (s *GrpcServer) ForgotPassword(ctx context.Context, req *pb.ForgotPasswordRequest) (*pb.ForgotPasswordResponse, error) {
-	token, err := s.userService.ForgotPassword(ctx, req.Email)
+	err := s.userService.ForgotPassword(ctx, req.Email, peerIP(ctx))
 	if err != nil {
 		return &pb.ForgotPasswordResponse{
 			Success: false,
@@ -70,8 +194,7 @@ func (s *GrpcServer) ForgotPassword(ctx context.Context, req *pb.ForgotPasswordR
 
 	return &pb.ForgotPasswordResponse{
 		Success: true,
-		Token:   token,
-		Message: "Reset token sent to email",
+		Message: "If the address belongs to an account, a reset token is on its way",
 	}, nil
 }
 
@@ -89,3 +212,294 @@ func (s *GrpcServer) ResetPassword(ctx context.Context, req *pb.ResetPasswordReq
 		Message: "Password reset successful",
 	}, nil
 }
+
+func (s *GrpcServer) GrantRole(ctx context.Context, req *pb.GrantRoleRequest) (*pb.GrantRoleResponse, error) {
+	grantedBy := req.GrantedBy
+	if id, ok := auth.FromIncomingContext(ctx); ok {
+		grantedBy = id.UserID
+	}
+
+	roles, err := s.userService.GrantRole(ctx, req.UserId, req.Role, grantedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GrantRoleResponse{
+		UserId: req.UserId,
+		Roles:  roles,
+	}, nil
+}
+
+func (s *GrpcServer) RevokeRole(ctx context.Context, req *pb.RevokeRoleRequest) (*pb.RevokeRoleResponse, error) {
+	roles, err := s.userService.RevokeRole(ctx, req.UserId, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RevokeRoleResponse{
+		UserId: req.UserId,
+		Roles:  roles,
+	}, nil
+}
+
+func (s *GrpcServer) UnlockUser(ctx context.Context, req *pb.UnlockUserRequest) (*pb.UnlockUserResponse, error) {
+	unlockedBy := req.UnlockedBy
+	if id, ok := auth.FromIncomingContext(ctx); ok {
+		unlockedBy = id.UserID
+	}
+
+	wasLocked, err := s.userService.UnlockUser(ctx, req.UserId, unlockedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UnlockUserResponse{
+		UserId:    req.UserId,
+		WasLocked: wasLocked,
+	}, nil
+}
+
+// SetupTwoFactor, EnableTwoFactor and DisableTwoFactor act on the caller
+// the gateway authenticated.
+func (s *GrpcServer) SetupTwoFactor(ctx context.Context, req *pb.SetupTwoFactorRequest) (*pb.SetupTwoFactorResponse, error) {
+	id, ok := auth.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not logged in")
+	}
+
+	secret, uri, err := s.userService.SetupTwoFactor(ctx, id.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SetupTwoFactorResponse{
+		Secret:          secret,
+		ProvisioningUri: uri,
+	}, nil
+}
+
+func (s *GrpcServer) EnableTwoFactor(ctx context.Context, req *pb.EnableTwoFactorRequest) (*pb.EnableTwoFactorResponse, error) {
+	id, ok := auth.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not logged in")
+	}
+
+	recoveryCodes, err := s.userService.EnableTwoFactor(ctx, id.UserID, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.EnableTwoFactorResponse{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (s *GrpcServer) DisableTwoFactor(ctx context.Context, req *pb.DisableTwoFactorRequest) (*pb.DisableTwoFactorResponse, error) {
+	id, ok := auth.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not logged in")
+	}
+
+	err := s.userService.DisableTwoFactor(ctx, id.UserID, req.Password, req.Code)
+	if err != nil {
+		return &pb.DisableTwoFactorResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.DisableTwoFactorResponse{
+		Success: true,
+		Message: "Two-factor authentication disabled",
+	}, nil
+}
+
+func (s *GrpcServer) RequireTwoFactor(ctx context.Context, req *pb.RequireTwoFactorRequest) (*pb.RequireTwoFactorResponse, error) {
+	changedBy := req.ChangedBy
+	if id, ok := auth.FromIncomingContext(ctx); ok {
+		changedBy = id.UserID
+	}
+
+	if err := s.userService.RequireTwoFactor(ctx, req.UserId, req.Required, changedBy); err != nil {
+		return nil, err
+	}
+
+	return &pb.RequireTwoFactorResponse{
+		UserId:   req.UserId,
+		Required: req.Required,
+	}, nil
+}
+
+func (s *GrpcServer) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.GetUserResponse, error) {
+	id, ok := auth.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not logged in")
+	}
+
+	profile := &repository.Profile{
+		FullName: req.FullName,
+		Phone:    req.Phone,
+		Locale:   req.Locale,
+	}
+	if req.DateOfBirth != "" {
+		dob, err := time.Parse("2006-01-02", req.DateOfBirth)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "date_of_birth must be YYYY-MM-DD")
+		}
+		profile.DateOfBirth = &dob
+	}
+
+	user, err := s.userService.UpdateProfile(ctx, id.UserID, profile)
+	if err != nil {
+		return nil, profileError(err)
+	}
+
+	return getUserResponse(user), nil
+}
+
+func (s *GrpcServer) ChangeEmail(ctx context.Context, req *pb.ChangeEmailRequest) (*pb.ChangeEmailResponse, error) {
+	id, ok := auth.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not logged in")
+	}
+
+	if err := s.userService.ChangeEmail(ctx, id.UserID, req.Password, req.NewEmail); err != nil {
+		return nil, profileError(err)
+	}
+
+	return &pb.ChangeEmailResponse{
+		Success: true,
+		Message: "Follow the link sent to the new address to finish the change",
+	}, nil
+}
+
+func (s *GrpcServer) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	id, ok := auth.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not logged in")
+	}
+
+	tokens, err := s.userService.ChangePassword(ctx, id.UserID, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		return nil, profileError(err)
+	}
+	user, err := s.userService.GetUser(ctx, id.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ChangePasswordResponse{
+		UserId:       user.ID,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+		Roles:        user.Roles,
+	}, nil
+}
+
+func (s *GrpcServer) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	id, ok := auth.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not logged in")
+	}
+
+	if err := s.userService.DeleteAccount(ctx, id.UserID, req.Password, req.Code); err != nil {
+		return nil, profileError(err)
+	}
+
+	return &pb.DeleteAccountResponse{
+		Success: true,
+		Message: "Account deleted",
+	}, nil
+}
+
+func (s *GrpcServer) ListTravellers(ctx context.Context, req *pb.ListTravellersRequest) (*pb.ListTravellersResponse, error) {
+	id, ok := auth.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not logged in")
+	}
+
+	travellers, err := s.userService.ListTravellers(ctx, id.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListTravellersResponse{Travellers: make([]*pb.Traveller, 0, len(travellers))}
+	for _, t := range travellers {
+		resp.Travellers = append(resp.Travellers, travellerResponse(t))
+	}
+	return resp, nil
+}
+
+func (s *GrpcServer) CreateTraveller(ctx context.Context, req *pb.CreateTravellerRequest) (*pb.Traveller, error) {
+	id, ok := auth.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not logged in")
+	}
+
+	traveller, err := s.userService.CreateTraveller(ctx, id.UserID, req.FullName, req.IdNumber, req.PassengerType)
+	if err != nil {
+		return nil, profileError(err)
+	}
+
+	return travellerResponse(traveller), nil
+}
+
+func (s *GrpcServer) UpdateTraveller(ctx context.Context, req *pb.UpdateTravellerRequest) (*pb.Traveller, error) {
+	id, ok := auth.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not logged in")
+	}
+
+	traveller, err := s.userService.UpdateTraveller(ctx, id.UserID, req.TravellerId, req.FullName, req.IdNumber, req.PassengerType)
+	if err != nil {
+		return nil, profileError(err)
+	}
+
+	return travellerResponse(traveller), nil
+}
+
+func (s *GrpcServer) DeleteTraveller(ctx context.Context, req *pb.DeleteTravellerRequest) (*pb.DeleteTravellerResponse, error) {
+	id, ok := auth.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not logged in")
+	}
+
+	if err := s.userService.DeleteTraveller(ctx, id.UserID, req.TravellerId); err != nil {
+		return nil, profileError(err)
+	}
+
+	return &pb.DeleteTravellerResponse{
+		Success: true,
+		Message: "Traveller removed",
+	}, nil
+}
+
+func travellerResponse(t *repository.Traveller) *pb.Traveller {
+	return &pb.Traveller{
+		Id:            t.ID,
+		FullName:      t.FullName,
+		IdNumberHint:  t.IDNumberHint,
+		PassengerType: t.PassengerType,
+		CreatedAt:     t.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// profileError gives the errors of the self-service RPCs a status code, so
+// the gateway can tell a taken address or a throttled request from bad input.
+func profileError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrEmailTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, service.ErrVerificationThrottled), errors.Is(err, service.ErrLoginLocked):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, repository.ErrLastAdmin), errors.Is(err, repository.ErrTooManyTravellers):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, repository.ErrTravellerNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, helper.ErrDecrypt):
+		return status.Error(codes.Internal, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
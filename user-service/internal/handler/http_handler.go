@@ -2,8 +2,11 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
+	"ticket-booking/user-service/internal/helper"
+	"ticket-booking/user-service/internal/repository"
 	"ticket-booking/user-service/internal/service"
 )
 
@@ -21,6 +24,8 @@ func (h *HTTPHandler) Register(w http.ResponseWriter, r *http.Request) {
 		Email           string `json:"email"`
 		Password        string `json:"password"`
 		ConfirmPassword string `json:"confirm_password"`
+		Locale          string `json:"locale"`
+		FullName        string `json:"full_name"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -28,22 +33,30 @@ func (h *HTTPHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, token, err := h.userService.Register(r.Context(), req.Username, req.Email, req.Password, req.ConfirmPassword)
+	user, tokens, err := h.userService.Register(r.Context(), req.Username, req.Email, req.Password, req.ConfirmPassword, req.Locale, req.FullName)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	response := struct {
-		UserId   int64  `json:"user_id"`
-		Username string `json:"username"`
-		Email    string `json:"email"`
-		Token    string `json:"token"`
+		UserId        int64    `json:"user_id"`
+		Username      string   `json:"username"`
+		Email         string   `json:"email"`
+		Token         string   `json:"token"`
+		Roles         []string `json:"roles"`
+		RefreshToken  string   `json:"refresh_token"`
+		ExpiresIn     int64    `json:"expires_in"`
+		EmailVerified bool     `json:"email_verified"`
 	}{
-		UserId:   user.ID,
-		Username: user.Username,
-		Email:    user.Email,
-		Token:    token,
+		UserId:        user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		Token:         tokens.AccessToken,
+		Roles:         user.Roles,
+		RefreshToken:  tokens.RefreshToken,
+		ExpiresIn:     tokens.ExpiresIn,
+		EmailVerified: user.EmailVerifiedAt != nil,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -61,22 +74,168 @@ func (h *HTTPHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, token, err := h.userService.Login(r.Context(), req.Username, req.Password)
+	user, tokens, err := h.userService.Login(r.Context(), req.Username, req.Password, helper.ClientIP(r))
+	if errors.Is(err, service.ErrLoginLocked) {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
+	if tokens.ChallengeToken != "" {
+		writeChallenge(w, tokens)
+		return
+	}
+
+	writeLogin(w, user, tokens)
+}
 
+// writeLogin writes the response of a completed login.
+func writeLogin(w http.ResponseWriter, user *repository.User, tokens *service.Tokens) {
 	response := struct {
-		UserId   int64  `json:"user_id"`
-		Username string `json:"username"`
-		Email    string `json:"email"`
-		Token    string `json:"token"`
+		UserId        int64    `json:"user_id"`
+		Username      string   `json:"username"`
+		Email         string   `json:"email"`
+		Token         string   `json:"token"`
+		Roles         []string `json:"roles"`
+		RefreshToken  string   `json:"refresh_token"`
+		ExpiresIn     int64    `json:"expires_in"`
+		EmailVerified bool     `json:"email_verified"`
+	}{
+		UserId:        user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		Token:         tokens.AccessToken,
+		Roles:         user.Roles,
+		RefreshToken:  tokens.RefreshToken,
+		ExpiresIn:     tokens.ExpiresIn,
+		EmailVerified: user.EmailVerifiedAt != nil,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// Refresh trades a refresh token for a new access and refresh token. The
+// old refresh token cannot be used again.
+func (h *HTTPHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, tokens, err := h.userService.Refresh(r.Context(), req.RefreshToken)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	response := struct {
+		UserId       int64    `json:"user_id"`
+		Token        string   `json:"token"`
+		RefreshToken string   `json:"refresh_token"`
+		ExpiresIn    int64    `json:"expires_in"`
+		Roles        []string `json:"roles"`
 	}{
-		UserId:   user.ID,
-		Username: user.Username,
-		Email:    user.Email,
-		Token:    token,
+		UserId:       user.ID,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+		Roles:        user.Roles,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// Logout ends the session of the given refresh token, including the access
+// tokens issued for it.
+func (h *HTTPHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.userService.Logout(r.Context(), req.RefreshToken); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := struct {
+		Success bool   `json:"success"`
+		Message string `json:"message"`
+	}{
+		Success: true,
+		Message: "Logged out",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// VerifyEmail takes the token from the emailed link (?token=) or from a
+// JSON body.
+func (h *HTTPHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" && r.Method == http.MethodPost {
+		var req struct {
+			Token string `json:"token"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		token = req.Token
+	}
+
+	if err := h.userService.VerifyEmail(r.Context(), token); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := struct {
+		Success bool   `json:"success"`
+		Message string `json:"message"`
+	}{
+		Success: true,
+		Message: "Email verified",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *HTTPHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Email string `json:"email"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err := h.userService.ResendVerification(r.Context(), req.Email)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := struct {
+		Success bool   `json:"success"`
+		Message string `json:"message"`
+	}{
+		Success: true,
+		Message: "If the address belongs to an unverified account, a new link is on its way",
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -93,7 +252,7 @@ func (h *HTTPHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.userService.ForgotPassword(r.Context(), req.Email)
+	err := h.userService.ForgotPassword(r.Context(), req.Email, helper.ClientIP(r))
 	if err != nil {
 		response := struct {
 			Success bool   `json:"success"`
@@ -109,12 +268,10 @@ func (h *HTTPHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
 
 	response := struct {
 		Success bool   `json:"success"`
-		Token   string `json:"token"`
 		Message string `json:"message"`
 	}{
 		Success: true,
-		Token:   token,
-		Message: "Reset token sent to email",
+		Message: "If the address belongs to an account, a reset token is on its way",
 	}
 
 	w.Header().Set("Content-Type", "application/json")
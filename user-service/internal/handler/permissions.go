@@ -0,0 +1,11 @@
+package handler
+
+import "ticket-booking/proto/auth"
+
+// Permissions lists the RPCs that need a role beyond being logged in.
+var Permissions = auth.Rules{
+	"/user.UserService/GrantRole":        {auth.RoleAdmin},
+	"/user.UserService/RevokeRole":       {auth.RoleAdmin},
+	"/user.UserService/UnlockUser":       {auth.RoleAdmin},
+	"/user.UserService/RequireTwoFactor": {auth.RoleAdmin},
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"ticket-booking/user-service/internal/helper"
+	"ticket-booking/user-service/internal/repository"
+	"ticket-booking/user-service/internal/service"
+)
+
+// writeChallenge answers a login that needs a second factor.
+func writeChallenge(w http.ResponseWriter, tokens *service.Tokens) {
+	response := struct {
+		TwoFactorRequired bool   `json:"two_factor_required"`
+		ChallengeToken    string `json:"challenge_token"`
+		ExpiresIn         int64  `json:"expires_in"`
+	}{
+		TwoFactorRequired: true,
+		ChallengeToken:    tokens.ChallengeToken,
+		ExpiresIn:         tokens.ExpiresIn,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// LoginTwoFactor takes the challenge token from /auth/login with a TOTP or
+// recovery code and completes the login.
+func (h *HTTPHandler) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ChallengeToken string `json:"challenge_token"`
+		Code           string `json:"code"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, tokens, err := h.userService.LoginTwoFactor(r.Context(), req.ChallengeToken, req.Code, helper.ClientIP(r))
+	if errors.Is(err, service.ErrLoginLocked) {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	writeLogin(w, user, tokens)
+}
+
+// SetupTwoFactor starts an enrollment for the bearer of the access token.
+// The secret goes into an authenticator app, usually by rendering the
+// provisioning URI as a QR code.
+func (h *HTTPHandler) SetupTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userId, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	secret, uri, err := h.userService.SetupTwoFactor(r.Context(), userId)
+	if errors.Is(err, repository.ErrTwoFactorEnabled) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := struct {
+		Secret          string `json:"secret"`
+		ProvisioningUri string `json:"provisioning_uri"`
+	}{
+		Secret:          secret,
+		ProvisioningUri: uri,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// EnableTwoFactor confirms the enrollment with a code and returns the
+// recovery codes, which are not shown again.
+func (h *HTTPHandler) EnableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userId, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Code string `json:"code"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	recoveryCodes, err := h.userService.EnableTwoFactor(r.Context(), userId, req.Code)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}{
+		RecoveryCodes: recoveryCodes,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *HTTPHandler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userId, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Password string `json:"password"`
+		Code     string `json:"code"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err := h.userService.DisableTwoFactor(r.Context(), userId, req.Password, req.Code)
+	if errors.Is(err, service.ErrLoginLocked) {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+	if errors.Is(err, service.ErrTwoFactorMandatory) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := struct {
+		Success bool   `json:"success"`
+		Message string `json:"message"`
+	}{
+		Success: true,
+		Message: "Two-factor authentication disabled",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// authenticate reads the caller from the Authorization header, writing a
+// 401 when it is missing or invalid.
+func (h *HTTPHandler) authenticate(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == r.Header.Get("Authorization") {
+		http.Error(w, "Authorization header required", http.StatusUnauthorized)
+		return 0, false
+	}
+
+	userId, err := h.userService.Authenticate(r.Context(), token)
+	if errors.Is(err, service.ErrUnauthenticated) {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return 0, false
+	}
+	if err != nil {
+		http.Error(w, "Could not verify session", http.StatusServiceUnavailable)
+		return 0, false
+	}
+	return userId, true
+}
@@ -0,0 +1,120 @@
+package helper
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrDecrypt is returned for ciphertext that is malformed or was sealed
+// with another key or for another owner.
+var ErrDecrypt = errors.New("cannot decrypt value")
+
+// FieldCipher encrypts single column values at rest with AES-256-GCM. The
+// stored form is "<key id>:" followed by base64(nonce || ciphertext), so
+// values sealed with a retired key can still be read after a rotation.
+type FieldCipher struct {
+	keyID string
+	aeads map[string]cipher.AEAD
+}
+
+// NewFieldCipher takes the base64 encoded 32-byte key new values are
+// sealed with, and any retired keys older values may still be sealed with.
+func NewFieldCipher(encodedKey string, retiredKeys ...string) (*FieldCipher, error) {
+	c := &FieldCipher{aeads: make(map[string]cipher.AEAD)}
+	for i, encoded := range append([]string{encodedKey}, retiredKeys...) {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("key is not base64: %w", err)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("key must be 32 bytes, got %d", len(key))
+		}
+		id, err := c.add(key)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			c.keyID = id
+		}
+	}
+	return c, nil
+}
+
+// GenerateFieldCipher makes a cipher with a random key, for development.
+// Values it encrypts cannot be read after a restart.
+func GenerateFieldCipher() (*FieldCipher, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	c := &FieldCipher{aeads: make(map[string]cipher.AEAD)}
+	id, err := c.add(key)
+	if err != nil {
+		return nil, err
+	}
+	c.keyID = id
+	return c, nil
+}
+
+// add registers key under its id, the start of its SHA-256 hash.
+func (c *FieldCipher) add(key []byte) (string, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(key)
+	id := hex.EncodeToString(sum[:4])
+	c.aeads[id] = aead
+	return id, nil
+}
+
+// KeyID names the key new values are sealed with.
+func (c *FieldCipher) KeyID() string {
+	return c.keyID
+}
+
+// Encrypt seals plaintext. context is authenticated but not stored, so a
+// value copied to a row with a different context does not decrypt.
+func (c *FieldCipher) Encrypt(plaintext, context string) (string, error) {
+	aead := c.aeads[c.keyID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(context))
+	return c.keyID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value sealed by Encrypt with the same context, using the
+// key named in the value.
+func (c *FieldCipher) Decrypt(value, context string) (string, error) {
+	id, encoded, ok := strings.Cut(value, ":")
+	if !ok {
+		return "", ErrDecrypt
+	}
+	aead, ok := c.aeads[id]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown key %s", ErrDecrypt, id)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrDecrypt
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(context))
+	if err != nil {
+		return "", ErrDecrypt
+	}
+	return string(plaintext), nil
+}
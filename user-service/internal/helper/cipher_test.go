@@ -0,0 +1,134 @@
+package helper
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(rune(b)), 32)))
+}
+
+func TestFieldCipherRoundTrip(t *testing.T) {
+	c, err := NewFieldCipher(testKey('a'))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		plaintext string
+	}{
+		{"id number", "AB 123456-7"},
+		{"empty", ""},
+		{"unicode", "Łódź №42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sealed, err := c.Encrypt(tt.plaintext, "traveller:1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(sealed, c.KeyID()+":") {
+				t.Errorf("sealed value %q does not name key %s", sealed, c.KeyID())
+			}
+			got, err := c.Decrypt(sealed, "traveller:1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.plaintext {
+				t.Errorf("Decrypt = %q, want %q", got, tt.plaintext)
+			}
+		})
+	}
+}
+
+func TestFieldCipherDecryptFails(t *testing.T) {
+	c, err := NewFieldCipher(testKey('a'))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewFieldCipher(testKey('b'))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := c.Encrypt("AB123456", "traveller:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, body, _ := strings.Cut(sealed, ":")
+	raw, _ := base64.StdEncoding.DecodeString(body)
+	raw[len(raw)-1] ^= 1
+	tampered := id + ":" + base64.StdEncoding.EncodeToString(raw)
+
+	tests := []struct {
+		name    string
+		cipher  *FieldCipher
+		value   string
+		context string
+	}{
+		{"other context", c, sealed, "traveller:2"},
+		{"other key", other, sealed, "traveller:1"},
+		{"no key id", c, body, "traveller:1"},
+		{"not base64", c, id + ":not base64!", "traveller:1"},
+		{"too short", c, id + ":" + base64.StdEncoding.EncodeToString([]byte("short")), "traveller:1"},
+		{"tampered", c, tampered, "traveller:1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.cipher.Decrypt(tt.value, tt.context); !errors.Is(err, ErrDecrypt) {
+				t.Errorf("Decrypt error = %v, want ErrDecrypt", err)
+			}
+		})
+	}
+}
+
+func TestFieldCipherRotation(t *testing.T) {
+	old, err := NewFieldCipher(testKey('a'))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := old.Encrypt("AB123456", "traveller:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotated, err := NewFieldCipher(testKey('b'), testKey('a'))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated.KeyID() == old.KeyID() {
+		t.Fatal("rotated cipher still seals with the old key")
+	}
+	got, err := rotated.Decrypt(sealed, "traveller:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "AB123456" {
+		t.Errorf("Decrypt = %q, want %q", got, "AB123456")
+	}
+}
+
+func TestNewFieldCipherRejectsBadKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		retired []string
+	}{
+		{"not base64", "not base64!", nil},
+		{"short key", base64.StdEncoding.EncodeToString([]byte("short")), nil},
+		{"bad retired key", testKey('a'), []string{"short"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewFieldCipher(tt.key, tt.retired...); err == nil {
+				t.Error("NewFieldCipher accepted a bad key")
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package helper
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ClientIP is the address a request came from. Requests arrive through the
+// gateway's reverse proxy, which appends the address it saw to
+// X-Forwarded-For, so the last entry is the one to trust; anything before
+// it was sent by the client.
+func ClientIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		parts := strings.Split(fwd, ",")
+		if ip := strings.TrimSpace(parts[len(parts)-1]); ip != "" {
+			return ip
+		}
+	}
+	return HostOnly(r.RemoteAddr)
+}
+
+// HostOnly drops the port from addr.
+func HostOnly(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
@@ -1,89 +0,0 @@
-package helper
-
-import (
-	"errors"
-	"time"
-
-	"ticket-booking/user-service/internal/repository"
-
-	"github.com/dgrijalva/jwt-go"
-)
-
-type JWTClaims struct {
-	UserID   int64  `json:"user_id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	jwt.StandardClaims
-}
-
-type JWTHelper struct {
-	secretKey []byte
-}
-
-func NewJWTHelper(secretKey string) *JWTHelper {
-	return &JWTHelper{
-		secretKey: []byte(secretKey),
-	}
-}
-
-func (j *JWTHelper) GenerateToken(user *repository.User) (string, error) {
-	expirationTime := time.Now().Add(24 * time.Hour)
-
-	claims := &JWTClaims{
-		UserID:   user.ID,
-		Username: user.Username,
-		Email:    user.Email,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: expirationTime.Unix(),
-			IssuedAt:  time.Now().Unix(),
-			Subject:   user.Username,
-		},
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(j.secretKey)
-	if err != nil {
-		return "", err
-	}
-
-	return tokenString, nil
-}
-
-func (j *JWTHelper) ValidateToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("invalid token signing method")
-		}
-		return j.secretKey, nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
-		return claims, nil
-	}
-
-	return nil, errors.New("invalid token")
-}
-
-func (j *JWTHelper) GenerateResetToken(userID int64) (string, error) {
-	expirationTime := time.Now().Add(1 * time.Hour) 
-
-	claims := &JWTClaims{
-		UserID: userID,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: expirationTime.Unix(),
-			IssuedAt:  time.Now().Unix(),
-		},
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(j.secretKey)
-	if err != nil {
-		return "", err
-	}
-
-	return tokenString, nil
-}
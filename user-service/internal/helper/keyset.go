@@ -0,0 +1,158 @@
+package helper
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// KeySet holds the RSA keys access tokens are signed with. One key is
+// active and signs new tokens; every key is published in the JWKS so
+// tokens signed before a rotation still verify until they expire.
+//
+// Rotating a key: add the new <kid>.pem next to the old one and restart,
+// so verifiers see it; point JWT_ACTIVE_KID at it; once the old tokens have
+// expired, remove the old file.
+type KeySet struct {
+	activeKid string
+	keys      map[string]*rsa.PrivateKey
+}
+
+// LoadKeySet reads every <kid>.pem private key (PKCS#1 or PKCS#8) in dir.
+// activeKid picks the signing key; when empty the last kid in name order
+// is used, so date-named kids rotate by adding a file.
+func LoadKeySet(dir, activeKid string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no *.pem keys in %s", dir)
+	}
+	sort.Strings(paths)
+
+	ks := &KeySet{keys: make(map[string]*rsa.PrivateKey)}
+	for _, path := range paths {
+		kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+		key, err := readRSAKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", kid, err)
+		}
+		ks.keys[kid] = key
+		ks.activeKid = kid
+	}
+
+	if activeKid != "" {
+		if _, ok := ks.keys[activeKid]; !ok {
+			return nil, fmt.Errorf("active key %s not found in %s", activeKid, dir)
+		}
+		ks.activeKid = activeKid
+	}
+
+	return ks, nil
+}
+
+// GenerateKeySet makes a single throwaway key, for development without a
+// key directory. Tokens stop verifying when the process restarts.
+func GenerateKeySet() (*KeySet, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	return &KeySet{activeKid: "dev", keys: map[string]*rsa.PrivateKey{"dev": key}}, nil
+}
+
+func readRSAKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("not a PEM file")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an RSA private key")
+	}
+	return key, nil
+}
+
+// Sign signs the claims with the active key, naming it in the kid header.
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = ks.activeKid
+	return token.SignedString(ks.keys[ks.activeKid])
+}
+
+// Keyfunc is a jwt.Keyfunc accepting RS256 tokens signed by one of the
+// keys.
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	if token.Method != jwt.SigningMethodRS256 {
+		return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+	}
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+	return &key.PublicKey, nil
+}
+
+// JWK is the public half of a key in JSON Web Key form (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys, active key first.
+func (ks *KeySet) JWKS() JWKS {
+	kids := make([]string, 0, len(ks.keys))
+	for kid := range ks.keys {
+		if kid != ks.activeKid {
+			kids = append(kids, kid)
+		}
+	}
+	sort.Strings(kids)
+	kids = append([]string{ks.activeKid}, kids...)
+
+	set := JWKS{Keys: make([]JWK, len(kids))}
+	for i, kid := range kids {
+		pub := ks.keys[kid].PublicKey
+		set.Keys[i] = JWK{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}
+	}
+	return set
+}
@@ -0,0 +1,177 @@
+package helper
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+func writeTestKey(t *testing.T, dir, kid string, pkcs8 bool) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	if pkcs8 {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	}
+	if err := os.WriteFile(filepath.Join(dir, kid+".pem"), pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestLoadKeySet(t *testing.T) {
+	dir := t.TempDir()
+	writeTestKey(t, dir, "2026-01", false)
+	writeTestKey(t, dir, "2026-07", true)
+
+	emptyDir := t.TempDir()
+	badDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(badDir, "broken.pem"), []byte("not a key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		dir        string
+		activeKid  string
+		wantActive string
+		wantErr    bool
+	}{
+		{"last kid signs by default", dir, "", "2026-07", false},
+		{"explicit active kid", dir, "2026-01", "2026-01", false},
+		{"unknown active kid", dir, "2025-01", "", true},
+		{"no keys", emptyDir, "", "", true},
+		{"not a PEM file", badDir, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks, err := LoadKeySet(tt.dir, tt.activeKid)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("LoadKeySet() succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			set := ks.JWKS()
+			if len(set.Keys) != 2 {
+				t.Fatalf("JWKS has %d keys, want 2", len(set.Keys))
+			}
+			if set.Keys[0].Kid != tt.wantActive {
+				t.Errorf("first JWKS key = %s, want the active key %s", set.Keys[0].Kid, tt.wantActive)
+			}
+
+			token, err := ks.Sign(jwt.StandardClaims{Subject: "1", ExpiresAt: time.Now().Add(time.Minute).Unix()})
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := jwt.Parse(token, ks.Keyfunc)
+			if err != nil {
+				t.Fatalf("token signed by the set does not verify: %v", err)
+			}
+			if kid := parsed.Header["kid"]; kid != tt.wantActive {
+				t.Errorf("token kid = %v, want %s", kid, tt.wantActive)
+			}
+		})
+	}
+}
+
+func TestKeySetKeyfunc(t *testing.T) {
+	dir := t.TempDir()
+	key := writeTestKey(t, dir, "current", false)
+	ks, err := LoadKeySet(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := jwt.StandardClaims{Subject: "1", ExpiresAt: time.Now().Add(time.Minute).Unix()}
+
+	signRS256 := func(kid string, key *rsa.PrivateKey) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = kid
+		s, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// HS256 keyed with the public modulus is the classic algorithm confusion attack.
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	hmacToken.Header["kid"] = "current"
+	hmacSigned, err := hmacToken.SignedString(key.PublicKey.N.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		wantOK bool
+	}{
+		{"signed by the active key", signRS256("current", key), true},
+		{"unknown kid", signRS256("retired", key), false},
+		{"known kid, other key", signRS256("current", otherKey), false},
+		{"HS256", hmacSigned, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jwt.Parse(tt.token, ks.Keyfunc)
+			if ok := err == nil; ok != tt.wantOK {
+				t.Errorf("jwt.Parse() error = %v, want ok %v", err, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestKeySetJWKSPublishesPublicKey(t *testing.T) {
+	dir := t.TempDir()
+	key := writeTestKey(t, dir, "k1", false)
+	ks, err := LoadKeySet(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwk := ks.JWKS().Keys[0]
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if jwk.Kty != "RSA" || jwk.Alg != "RS256" || jwk.Use != "sig" || jwk.Kid != "k1" {
+		t.Errorf("JWK header = %+v", jwk)
+	}
+	if new(big.Int).SetBytes(n).Cmp(key.N) != 0 {
+		t.Error("JWK modulus does not match the key")
+	}
+	if int(new(big.Int).SetBytes(e).Int64()) != key.E {
+		t.Errorf("JWK exponent = %x, want %d", e, key.E)
+	}
+}
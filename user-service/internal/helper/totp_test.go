@@ -0,0 +1,76 @@
+package helper
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 seed of RFC 6238 appendix B, "12345678901234567890",
+// in base32.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	// The RFC lists 8-digit codes; 6-digit codes are their last six digits.
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		got, err := TOTPCode(rfc6238Secret, TOTPStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("TOTPCode at %d: %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("TOTPCode at %d = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := TOTPStep(now)
+	code := func(step int64) string {
+		c, err := TOTPCode(rfc6238Secret, step)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{"current step", rfc6238Secret, code(step), step, true},
+		{"previous step", rfc6238Secret, code(step - 1), step - 1, true},
+		{"next step", rfc6238Secret, code(step + 1), step + 1, true},
+		{"two steps old", rfc6238Secret, code(step - 2), 0, false},
+		{"two steps ahead", rfc6238Secret, code(step + 2), 0, false},
+		{"spaces are ignored", rfc6238Secret, " 005 924 ", step, true},
+		{"lower-case secret", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "005924", step, true},
+		{"wrong code", rfc6238Secret, "123456", 0, false},
+		{"too short", rfc6238Secret, "05924", 0, false},
+		{"eight digits", rfc6238Secret, "89005924", 0, false},
+		{"invalid secret", "not base32!", "005924", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, ok := ValidateTOTP(tt.secret, tt.code, now)
+			if ok != tt.wantOK || gotStep != tt.wantStep {
+				t.Errorf("ValidateTOTP(%q) = %d, %v, want %d, %v", tt.code, gotStep, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
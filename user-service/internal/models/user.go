@@ -17,6 +17,8 @@ type RegisterRequest struct {
 	Email           string `json:"email" validate:"required,email"`
 	Password        string `json:"password" validate:"required,min=6"`
 	ConfirmPassword string `json:"confirm_password" validate:"required"`
+	Locale          string `json:"locale"`
+	FullName        string `json:"full_name"`
 }
 
 type LoginRequest struct {
@@ -25,8 +27,13 @@ type LoginRequest struct {
 }
 
 type AuthResponse struct {
-	User  *User  `json:"user"`
-	Token string `json:"token"`
+	User         *User  `json:"user"`
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int64  `json:"expires_in"`
+	// Set instead of the tokens when the login needs a second factor
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
 }
 
 type ForgotPasswordRequest struct {
@@ -0,0 +1,97 @@
+package notification
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"ticket-booking/user-service/internal/repository"
+)
+
+const (
+	dispatchBatch    = 20
+	dispatchInterval = 5 * time.Second
+	// sendLease is how long a claimed message is left alone before it is
+	// retried, in case the dispatcher dies while sending it
+	sendLease   = 5 * time.Minute
+	maxAttempts = 8
+)
+
+// Dispatcher delivers queued emails. Failed sends are retried with
+// exponential backoff, from 30 seconds up to about an hour, and given up
+// after maxAttempts.
+type Dispatcher struct {
+	outbox    repository.OutboxRepository
+	templates *Templates
+	mailer    Mailer
+}
+
+func NewDispatcher(outbox repository.OutboxRepository, templates *Templates, mailer Mailer) *Dispatcher {
+	return &Dispatcher{outbox: outbox, templates: templates, mailer: mailer}
+}
+
+// Run dispatches until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(dispatchInterval)
+	defer ticker.Stop()
+	for {
+		// Drain the backlog before waiting again
+		for {
+			n, err := d.DispatchOnce(ctx)
+			if err != nil {
+				log.Printf("dispatch notifications: %v", err)
+			}
+			if err != nil || n < dispatchBatch {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce sends one batch and returns how many messages it claimed.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	msgs, err := d.outbox.Claim(ctx, dispatchBatch, sendLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, msg := range msgs {
+		if err := d.send(ctx, msg); err != nil {
+			final := msg.Attempts >= maxAttempts
+			if final {
+				log.Printf("notification %d to %s failed for good: %v", msg.ID, msg.Recipient, err)
+			}
+			if err := d.outbox.MarkFailed(ctx, msg.ID, err.Error(), backoff(msg.Attempts), final); err != nil {
+				return len(msgs), err
+			}
+			continue
+		}
+		if err := d.outbox.MarkSent(ctx, msg.ID); err != nil {
+			return len(msgs), err
+		}
+	}
+
+	return len(msgs), nil
+}
+
+func (d *Dispatcher) send(ctx context.Context, msg *repository.OutboxMessage) error {
+	subject, body, err := d.templates.Render(msg.Template, msg.Locale, msg.Data)
+	if err != nil {
+		return err
+	}
+	return d.mailer.Send(ctx, Message{To: msg.Recipient, Subject: subject, Body: body})
+}
+
+// backoff is the wait after the given number of attempts: 30s, 1m, 2m, ...
+func backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return 30 * time.Second << (attempts - 1)
+}
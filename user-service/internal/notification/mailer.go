@@ -0,0 +1,120 @@
+// Package notification renders and delivers the emails the system sends.
+// Services queue emails in notification_outbox; the Dispatcher here sends
+// them with retries.
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers a message or reports why it could not.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SMTPMailer sends through an SMTP server, authenticating when a username
+// is set.
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	m := &SMTPMailer{addr: fmt.Sprintf("%s:%d", host, port), from: from}
+	if username != "" {
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return m
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	// The envelope sender is the bare address of "Name <address>"
+	from, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(m.addr, m.auth, from.Address, []string{msg.To}, compose(m.from, msg))
+}
+
+// FileMailer writes every message as an .eml file into a directory, for
+// development without a mail server.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), sanitize(msg.To))
+	return os.WriteFile(filepath.Join(m.dir, name), compose(m.from, msg), 0o644)
+}
+
+// MemoryMailer keeps the messages it is given, for tests.
+type MemoryMailer struct {
+	mu   sync.Mutex
+	sent []Message
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (m *MemoryMailer) Send(ctx context.Context, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// Sent returns the messages sent so far.
+func (m *MemoryMailer) Sent() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.sent...)
+}
+
+// headerValue keeps line breaks out of headers, so data rendered into the
+// subject cannot add headers of its own.
+var headerValue = strings.NewReplacer("\r", "", "\n", " ")
+
+func compose(from string, msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", headerValue.Replace(from))
+	fmt.Fprintf(&b, "To: %s\r\n", headerValue.Replace(msg.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", headerValue.Replace(msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '@' || r == '.' || r == '-' || r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, s)
+}
@@ -0,0 +1,98 @@
+package notification
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Templates every service may queue. Each has a file per locale in
+// templates/<locale>/<name>.tmpl defining a "subject" and a "body".
+const (
+	TemplatePasswordReset        = "password_reset"
+	TemplateEmailVerification    = "email_verification"
+	TemplateBookingConfirmation  = "booking_confirmation"
+	TemplateBookingExpiryWarning = "booking_expiry_warning"
+)
+
+const DefaultLocale = "en"
+
+// Locales are the languages emails are written in.
+var Locales = []string{"en", "id"}
+
+// Locale returns the supported locale closest to l ("id-ID" becomes "id"),
+// or DefaultLocale.
+func Locale(l string) string {
+	if lang, ok := match(l); ok {
+		return lang
+	}
+	return DefaultLocale
+}
+
+// Supported reports whether emails can be written in l's language.
+func Supported(l string) bool {
+	_, ok := match(l)
+	return ok
+}
+
+func match(l string) (string, bool) {
+	l = strings.ToLower(strings.TrimSpace(l))
+	if i := strings.IndexAny(l, "-_"); i > 0 {
+		l = l[:i]
+	}
+	for _, supported := range Locales {
+		if l == supported {
+			return l, true
+		}
+	}
+	return "", false
+}
+
+//go:embed templates
+var templateFS embed.FS
+
+// Templates renders emails from the embedded templates.
+type Templates struct {
+	byName map[string]*template.Template
+}
+
+func LoadTemplates() (*Templates, error) {
+	t := &Templates{byName: make(map[string]*template.Template)}
+	for _, locale := range Locales {
+		paths, err := templateFS.ReadDir("templates/" + locale)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range paths {
+			name := strings.TrimSuffix(p.Name(), ".tmpl")
+			tmpl, err := template.New(name).ParseFS(templateFS, "templates/"+locale+"/"+p.Name())
+			if err != nil {
+				return nil, err
+			}
+			t.byName[locale+"/"+name] = tmpl
+		}
+	}
+	return t, nil
+}
+
+// Render fills the template in the given locale, falling back to
+// DefaultLocale when it has no translation.
+func (t *Templates) Render(name, locale string, data map[string]interface{}) (subject, body string, err error) {
+	tmpl, ok := t.byName[Locale(locale)+"/"+name]
+	if !ok {
+		tmpl, ok = t.byName[DefaultLocale+"/"+name]
+	}
+	if !ok {
+		return "", "", fmt.Errorf("unknown email template %s", name)
+	}
+
+	var s, b strings.Builder
+	if err := tmpl.ExecuteTemplate(&s, "subject", data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.ExecuteTemplate(&b, "body", data); err != nil {
+		return "", "", err
+	}
+	return strings.TrimSpace(s.String()), strings.TrimLeft(b.String(), "\n"), nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AuditEntry records a security-relevant event. UserID is the account it
+// happened to and ActorID whoever caused it, when known.
+type AuditEntry struct {
+	Action  string                 `json:"action" db:"action"`
+	UserID  *int64                 `json:"user_id" db:"user_id"`
+	ActorID *int64                 `json:"actor_id" db:"actor_id"`
+	IP      string                 `json:"ip" db:"ip"`
+	Detail  map[string]interface{} `json:"detail" db:"detail"`
+}
+
+// AuditRepository appends to the audit log. Entries are never changed.
+type AuditRepository interface {
+	Record(ctx context.Context, entry *AuditEntry) error
+}
+
+type auditRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditRepository(db *pgxpool.Pool) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) Record(ctx context.Context, entry *AuditEntry) error {
+	detail := entry.Detail
+	if detail == nil {
+		detail = map[string]interface{}{}
+	}
+	data, err := json.Marshal(detail)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO audit_log (action, user_id, actor_id, ip, detail, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, NOW())`,
+		entry.Action, entry.UserID, entry.ActorID, entry.IP, data)
+	return err
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Throttle scopes: failed logins are counted per account and per client
+// address.
+const (
+	ThrottleUser = "user"
+	ThrottleIP   = "ip"
+)
+
+// LockoutPolicy says when failed logins lock a key out. The first Allowed
+// failures are free; every failure after that locks the key for BaseLock,
+// doubled for each further failure up to MaxLock. Counts start over once
+// no failure has happened for Window.
+type LockoutPolicy struct {
+	Allowed  int
+	BaseLock time.Duration
+	MaxLock  time.Duration
+	Window   time.Duration
+}
+
+// LockFor returns how long the given number of failures locks a key out.
+func (p LockoutPolicy) LockFor(failures int) time.Duration {
+	if failures <= p.Allowed {
+		return 0
+	}
+	lock := p.BaseLock
+	for i := p.Allowed + 1; i < failures && lock < p.MaxLock; i++ {
+		lock *= 2
+	}
+	if lock > p.MaxLock {
+		lock = p.MaxLock
+	}
+	return lock
+}
+
+// LoginThrottleRepository keeps failed login counts in Postgres, so every
+// user-service replica enforces the same lockouts.
+type LoginThrottleRepository interface {
+	Reserve(ctx context.Context, scope, key string, policy LockoutPolicy) (bool, *time.Time, error)
+	Release(ctx context.Context, scope, key string, policy LockoutPolicy) error
+	Reset(ctx context.Context, scope, key string) (bool, error)
+}
+
+type loginThrottleRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewLoginThrottleRepository(db *pgxpool.Pool) LoginThrottleRepository {
+	return &loginThrottleRepository{db: db}
+}
+
+// Reserve counts an attempt as failed before its outcome is known, so
+// parallel attempts cannot all get in under the limit; Release takes it
+// back if it succeeds. It returns false without counting anything while
+// the key is locked out. Otherwise it returns when the lockout this
+// attempt causes ends, or nil if it causes none.
+func (r *loginThrottleRepository) Reserve(ctx context.Context, scope, key string, policy LockoutPolicy) (bool, *time.Time, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO login_throttles (scope, key, failures, last_failure_at)
+		VALUES ($1, $2, 0, NOW())
+		ON CONFLICT (scope, key) DO NOTHING`, scope, key)
+	if err != nil {
+		return false, nil, err
+	}
+
+	var failures int
+	var stale, locked bool
+	err = tx.QueryRow(ctx, `
+		SELECT failures, last_failure_at < NOW() - make_interval(secs => $3), COALESCE(locked_until > NOW(), FALSE)
+		FROM login_throttles WHERE scope = $1 AND key = $2
+		FOR UPDATE`, scope, key, policy.Window.Seconds()).Scan(&failures, &stale, &locked)
+	if err != nil {
+		return false, nil, err
+	}
+	if locked {
+		return false, nil, nil
+	}
+	if stale {
+		failures = 0
+	}
+	failures++
+
+	var until *time.Time
+	err = tx.QueryRow(ctx, `
+		UPDATE login_throttles
+		SET failures = $3, last_failure_at = NOW(),
+			locked_until = CASE WHEN $4 > 0 THEN NOW() + make_interval(secs => $4) END
+		WHERE scope = $1 AND key = $2
+		RETURNING locked_until`, scope, key, failures, policy.LockFor(failures).Seconds()).Scan(&until)
+	if err != nil {
+		return false, nil, err
+	}
+
+	return true, until, tx.Commit(ctx)
+}
+
+// Release takes back an attempt Reserve counted, along with the lockout it
+// caused.
+func (r *loginThrottleRepository) Release(ctx context.Context, scope, key string, policy LockoutPolicy) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE login_throttles
+		SET failures = GREATEST(failures - 1, 0),
+			locked_until = CASE WHEN failures - 1 <= $3 THEN NULL ELSE locked_until END
+		WHERE scope = $1 AND key = $2`, scope, key, policy.Allowed)
+	return err
+}
+
+// Reset forgets the key's failures and lifts its lockout. It reports
+// whether the key was locked out.
+func (r *loginThrottleRepository) Reset(ctx context.Context, scope, key string) (bool, error) {
+	var locked bool
+	err := r.db.QueryRow(ctx, `
+		DELETE FROM login_throttles WHERE scope = $1 AND key = $2
+		RETURNING COALESCE(locked_until > NOW(), FALSE)`, scope, key).Scan(&locked)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	return locked, err
+}
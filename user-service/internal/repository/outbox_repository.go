@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OutboxMessage is an email waiting in notification_outbox. Data holds the
+// template's fields.
+type OutboxMessage struct {
+	ID        int64                  `json:"id" db:"id"`
+	Template  string                 `json:"template" db:"template"`
+	Locale    string                 `json:"locale" db:"locale"`
+	Recipient string                 `json:"recipient" db:"recipient"`
+	Data      map[string]interface{} `json:"data" db:"data"`
+	Attempts  int                    `json:"attempts" db:"attempts"`
+}
+
+// OutboxRepository hands out pending emails to the dispatcher. Claiming a
+// message leases it for a while, so a dispatcher that dies mid-send only
+// delays it, and several dispatchers never send the same message at once.
+type OutboxRepository interface {
+	Enqueue(ctx context.Context, msg *OutboxMessage) error
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*OutboxMessage, error)
+	MarkSent(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, lastError string, retryIn time.Duration, final bool) error
+}
+
+type outboxRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxRepository(db *pgxpool.Pool) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+}
+
+// enqueue writes msg with db, which may be a transaction so the email is
+// only sent if the change it is about commits.
+func enqueue(ctx context.Context, db execer, msg *OutboxMessage) error {
+	data, err := json.Marshal(msg.Data)
+	if err != nil {
+		return err
+	}
+	locale := msg.Locale
+	if locale == "" {
+		locale = "en"
+	}
+
+	_, err = db.Exec(ctx, `
+		INSERT INTO notification_outbox (template, locale, recipient, data, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, NOW(), NOW())`,
+		msg.Template, locale, msg.Recipient, data)
+	return err
+}
+
+func (r *outboxRepository) Enqueue(ctx context.Context, msg *OutboxMessage) error {
+	return enqueue(ctx, r.db, msg)
+}
+
+func (r *outboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*OutboxMessage, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE notification_outbox
+		SET attempts = attempts + 1, next_attempt_at = NOW() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM notification_outbox
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, template, locale, recipient, data, attempts`,
+		limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var msgs []*OutboxMessage
+	for rows.Next() {
+		msg := &OutboxMessage{}
+		var data []byte
+		if err := rows.Scan(&msg.ID, &msg.Template, &msg.Locale, &msg.Recipient, &data, &msg.Attempts); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &msg.Data); err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, rows.Err()
+}
+
+// MarkSent records the delivery and clears the template data, which holds
+// the links and tokens the email carried.
+func (r *outboxRepository) MarkSent(ctx context.Context, id int64) error {
+	_, err := r.db.Exec(ctx, `UPDATE notification_outbox SET status = 'sent', sent_at = NOW(), last_error = NULL, data = '{}' WHERE id = $1`, id)
+	return err
+}
+
+// MarkFailed records a failed attempt and schedules the next one, or gives
+// up on the message when final is set, clearing its data as MarkSent does.
+func (r *outboxRepository) MarkFailed(ctx context.Context, id int64, lastError string, retryIn time.Duration, final bool) error {
+	status := "pending"
+	if final {
+		status = "failed"
+	}
+
+	_, err := r.db.Exec(ctx, `
+		UPDATE notification_outbox
+		SET status = $2, last_error = $3, next_attempt_at = NOW() + make_interval(secs => $4),
+			data = CASE WHEN $5 THEN '{}' ELSE data END
+		WHERE id = $1`,
+		id, status, lastError, retryIn.Seconds(), final)
+	return err
+}
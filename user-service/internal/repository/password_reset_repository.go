@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrResetTokenInvalid = errors.New("invalid or expired token")
+
+// PasswordResetRepository stores password reset tokens by hash.
+type PasswordResetRepository interface {
+	Create(ctx context.Context, userId int64, clientIP, tokenHash string, expiresAt time.Time, email *OutboxMessage, limit SendLimit) error
+	Consume(ctx context.Context, tokenHash, newPassword string) (int64, error)
+}
+
+type passwordResetRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPasswordResetRepository(db *pgxpool.Pool) PasswordResetRepository {
+	return &passwordResetRepository{db: db}
+}
+
+// Create stores the token and queues the email carrying it in one
+// transaction, unless that exceeds limit. clientIP is the address the reset
+// was requested from, or empty.
+func (r *passwordResetRepository) Create(ctx context.Context, userId int64, clientIP, tokenHash string, expiresAt time.Time, email *OutboxMessage, limit SendLimit) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := checkSendLimit(ctx, tx, "password_reset_tokens", userId, clientIP, limit); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO password_reset_tokens (user_id, token_hash, requested_ip, expires_at, created_at) VALUES ($1, $2, NULLIF($3, ''), $4, NOW())`
+
+	if _, err := tx.Exec(ctx, query, userId, tokenHash, clientIP, expiresAt); err != nil {
+		return err
+	}
+	if err := enqueue(ctx, tx, email); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Consume sets the new password hash and ends every session of the user.
+// The password change uses up all of the user's reset tokens. It returns
+// the user ID.
+func (r *passwordResetRepository) Consume(ctx context.Context, tokenHash, newPassword string) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var userId int64
+	err = tx.QueryRow(ctx, `
+		SELECT user_id FROM password_reset_tokens
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		FOR UPDATE`, tokenHash).Scan(&userId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrResetTokenInvalid
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := updatePassword(ctx, tx, userId, newPassword); err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	// A challenge proves the old password; it must not outlive it
+	_, err = tx.Exec(ctx, `UPDATE login_challenges SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL`, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	return userId, tx.Commit(ctx)
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrRefreshTokenInvalid = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used; the session has been revoked")
+)
+
+type RefreshToken struct {
+	ID        int64      `json:"id" db:"id"`
+	UserID    int64      `json:"user_id" db:"user_id"`
+	FamilyID  string     `json:"family_id" db:"family_id"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// RefreshTokenRepository stores refresh tokens by hash. Tokens descended
+// from the same login form a family, which is revoked as a whole.
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *RefreshToken) error
+	Rotate(ctx context.Context, tokenHash string, next *RefreshToken) (*RefreshToken, error)
+	RevokeFamily(ctx context.Context, familyId string) error
+	RevokeByHash(ctx context.Context, tokenHash string) error
+	RevokeByUser(ctx context.Context, userId int64) error
+	IsFamilyRevoked(ctx context.Context, familyId string) (bool, error)
+}
+
+type refreshTokenRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRefreshTokenRepository(db *pgxpool.Pool) RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, token *RefreshToken) error {
+	query := `INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at, created_at) VALUES ($1, $2, $3, $4, NOW()) RETURNING id`
+
+	return r.db.QueryRow(ctx, query, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt).Scan(&token.ID)
+}
+
+// Rotate marks the token as used and stores next in its family, returning
+// the old token. Presenting a token that was already used means it leaked,
+// so its whole family is revoked and ErrRefreshTokenReused returned.
+func (r *refreshTokenRepository) Rotate(ctx context.Context, tokenHash string, next *RefreshToken) (*RefreshToken, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	current := &RefreshToken{}
+	err = tx.QueryRow(ctx, `
+		SELECT id, user_id, family_id, expires_at, used_at, revoked_at
+		FROM refresh_tokens WHERE token_hash = $1
+		FOR UPDATE`, tokenHash).
+		Scan(&current.ID, &current.UserID, &current.FamilyID, &current.ExpiresAt, &current.UsedAt, &current.RevokedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrRefreshTokenInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if current.RevokedAt != nil {
+		return nil, ErrRefreshTokenInvalid
+	}
+	if current.UsedAt != nil {
+		if _, err := tx.Exec(ctx, revokeFamilyQuery, current.FamilyID); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+	if time.Now().After(current.ExpiresAt) {
+		return nil, ErrRefreshTokenInvalid
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE refresh_tokens SET used_at = NOW() WHERE id = $1`, current.ID); err != nil {
+		return nil, err
+	}
+
+	next.UserID = current.UserID
+	next.FamilyID = current.FamilyID
+	err = tx.QueryRow(ctx, `
+		INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, NOW()) RETURNING id`,
+		next.UserID, next.FamilyID, next.TokenHash, next.ExpiresAt).Scan(&next.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return current, tx.Commit(ctx)
+}
+
+const revokeFamilyQuery = `UPDATE refresh_tokens SET revoked_at = NOW() WHERE family_id = $1 AND revoked_at IS NULL`
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyId string) error {
+	_, err := r.db.Exec(ctx, revokeFamilyQuery, familyId)
+	return err
+}
+
+// RevokeByHash revokes the family of the given token. Unknown tokens are
+// ignored, so logging out twice is not an error.
+func (r *refreshTokenRepository) RevokeByHash(ctx context.Context, tokenHash string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE refresh_tokens SET revoked_at = NOW()
+		WHERE revoked_at IS NULL
+		  AND family_id = (SELECT family_id FROM refresh_tokens WHERE token_hash = $1)`, tokenHash)
+	return err
+}
+
+// RevokeByUser ends every session of the user.
+func (r *refreshTokenRepository) RevokeByUser(ctx context.Context, userId int64) error {
+	_, err := r.db.Exec(ctx, `UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, userId)
+	return err
+}
+
+// IsFamilyRevoked reports whether the session was revoked. A family that
+// was never stored counts as revoked.
+func (r *refreshTokenRepository) IsFamilyRevoked(ctx context.Context, familyId string) (bool, error) {
+	var revoked bool
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(bool_or(revoked_at IS NOT NULL), TRUE)
+		FROM refresh_tokens WHERE family_id = $1`, familyId).Scan(&revoked)
+	return revoked, err
+}
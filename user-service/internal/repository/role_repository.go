@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrLastAdmin = errors.New("cannot revoke the last admin")
+
+// RoleRepository stores the roles granted on top of customer, which every
+// user has implicitly.
+type RoleRepository interface {
+	ListByUser(ctx context.Context, userId int64) ([]string, error)
+	Grant(ctx context.Context, userId int64, role string, grantedBy int64) error
+	Revoke(ctx context.Context, userId int64, role string) error
+}
+
+type roleRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRoleRepository(db *pgxpool.Pool) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+func (r *roleRepository) ListByUser(ctx context.Context, userId int64) ([]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []string
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, rows.Err()
+}
+
+// Grant is idempotent; granting a role the user already has keeps the
+// original grant.
+func (r *roleRepository) Grant(ctx context.Context, userId int64, role string, grantedBy int64) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO user_roles (user_id, role, granted_by, created_at)
+		VALUES ($1, $2, NULLIF($3, 0), NOW())
+		ON CONFLICT (user_id, role) DO NOTHING`,
+		userId, role, grantedBy)
+	return err
+}
+
+// Revoke refuses to remove the last admin so the system cannot lock itself
+// out. Admin rows are locked so two admins cannot revoke each other at once.
+func (r *roleRepository) Revoke(ctx context.Context, userId int64, role string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := revokeRole(ctx, tx, userId, role); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// revokeRole removes the role within tx, refusing to remove the last admin.
+func revokeRole(ctx context.Context, tx pgx.Tx, userId int64, role string) error {
+	if role == "admin" {
+		rows, err := tx.Query(ctx, `SELECT user_id FROM user_roles WHERE role = 'admin' FOR UPDATE`)
+		if err != nil {
+			return err
+		}
+		admins := 0
+		isAdmin := false
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			admins++
+			isAdmin = isAdmin || id == userId
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if isAdmin && admins == 1 {
+			return ErrLastAdmin
+		}
+	}
+
+	_, err := tx.Exec(ctx, `DELETE FROM user_roles WHERE user_id = $1 AND role = $2`, userId, role)
+	return err
+}
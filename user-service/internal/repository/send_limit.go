@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrSendLimited is returned instead of storing a token when its email
+// would exceed the SendLimit.
+var ErrSendLimited = errors.New("too many emails requested, try again later")
+
+// SendLimit caps how many token emails of one kind go out: PerDay to a
+// user in 24 hours, at least Wait apart, and PerIPDay requested from one
+// client address in 24 hours when it is set.
+type SendLimit struct {
+	PerDay   int
+	Wait     time.Duration
+	PerIPDay int
+}
+
+// checkSendLimit fails with ErrSendLimited when another token in table for
+// the user would exceed limit. It locks the user's row, and the client
+// address, until tx ends, so concurrent requests are counted one at a time.
+func checkSendLimit(ctx context.Context, tx pgx.Tx, table string, userId int64, clientIP string, limit SendLimit) error {
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM users WHERE id = $1 FOR UPDATE`, userId); err != nil {
+		return err
+	}
+
+	var sent int
+	var waited bool
+	err := tx.QueryRow(ctx, `
+		SELECT COUNT(*), COALESCE(MAX(created_at) < NOW() - make_interval(secs => $2), TRUE)
+		FROM `+table+` WHERE user_id = $1 AND created_at >= NOW() - INTERVAL '24 hours'`,
+		userId, limit.Wait.Seconds()).Scan(&sent, &waited)
+	if err != nil {
+		return err
+	}
+	if sent >= limit.PerDay || !waited {
+		return ErrSendLimited
+	}
+
+	if limit.PerIPDay == 0 || clientIP == "" {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, table+":"+clientIP); err != nil {
+		return err
+	}
+	err = tx.QueryRow(ctx, `
+		SELECT COUNT(*) FROM `+table+`
+		WHERE requested_ip = $1 AND created_at >= NOW() - INTERVAL '24 hours'`, clientIP).Scan(&sent)
+	if err != nil {
+		return err
+	}
+	if sent >= limit.PerIPDay {
+		return ErrSendLimited
+	}
+	return nil
+}
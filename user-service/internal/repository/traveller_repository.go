@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrTravellerNotFound = errors.New("traveller not found")
+	ErrTooManyTravellers = errors.New("too many saved travellers")
+)
+
+// Traveller is a co-traveller a user saved for checkout. IDNumber holds the
+// encrypted ID number as stored; the service decrypts it.
+type Traveller struct {
+	ID            int64     `json:"id" db:"id"`
+	UserID        int64     `json:"user_id" db:"user_id"`
+	FullName      string    `json:"full_name" db:"full_name"`
+	IDNumber      string    `json:"-" db:"id_number"`
+	PassengerType string    `json:"passenger_type" db:"passenger_type"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	// IDNumberHint is the masked ID number shown back to the user
+	IDNumberHint string `json:"id_number_hint" db:"-"`
+}
+
+type TravellerRepository interface {
+	ListByUser(ctx context.Context, userId int64) ([]*Traveller, error)
+	GetByID(ctx context.Context, userId, travellerId int64) (*Traveller, error)
+	Create(ctx context.Context, traveller *Traveller, limit int) (*Traveller, error)
+	Update(ctx context.Context, traveller *Traveller) error
+	Delete(ctx context.Context, userId, travellerId int64) error
+	HasIDNumbers(ctx context.Context) (bool, error)
+}
+
+type travellerRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTravellerRepository(db *pgxpool.Pool) TravellerRepository {
+	return &travellerRepository{db: db}
+}
+
+func (r *travellerRepository) ListByUser(ctx context.Context, userId int64) ([]*Traveller, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, full_name, COALESCE(id_number, ''), passenger_type, created_at
+		FROM travellers WHERE user_id = $1 ORDER BY id`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var travellers []*Traveller
+	for rows.Next() {
+		t := &Traveller{}
+		if err := rows.Scan(&t.ID, &t.UserID, &t.FullName, &t.IDNumber, &t.PassengerType, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		travellers = append(travellers, t)
+	}
+	return travellers, rows.Err()
+}
+
+// GetByID returns the traveller only if userId saved it.
+func (r *travellerRepository) GetByID(ctx context.Context, userId, travellerId int64) (*Traveller, error) {
+	t := &Traveller{}
+	err := r.db.QueryRow(ctx, `
+		SELECT id, user_id, full_name, COALESCE(id_number, ''), passenger_type, created_at
+		FROM travellers WHERE id = $1 AND user_id = $2`, travellerId, userId).
+		Scan(&t.ID, &t.UserID, &t.FullName, &t.IDNumber, &t.PassengerType, &t.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTravellerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Create saves the traveller unless the user already has limit of them.
+func (r *travellerRepository) Create(ctx context.Context, traveller *Traveller, limit int) (*Traveller, error) {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO travellers (user_id, full_name, id_number, passenger_type, created_at, updated_at)
+		SELECT $1, $2, NULLIF($3, ''), $4, NOW(), NOW()
+		WHERE (SELECT COUNT(*) FROM travellers WHERE user_id = $1) < $5
+		RETURNING id, created_at`,
+		traveller.UserID, traveller.FullName, traveller.IDNumber, traveller.PassengerType, limit).
+		Scan(&traveller.ID, &traveller.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTooManyTravellers
+	}
+	if err != nil {
+		return nil, err
+	}
+	return traveller, nil
+}
+
+func (r *travellerRepository) Update(ctx context.Context, traveller *Traveller) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE travellers
+		SET full_name = $3, id_number = NULLIF($4, ''), passenger_type = $5, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2`,
+		traveller.ID, traveller.UserID, traveller.FullName, traveller.IDNumber, traveller.PassengerType)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTravellerNotFound
+	}
+	return nil
+}
+
+// Delete removes the traveller. Bookings made for them keep their own copy
+// of the name.
+func (r *travellerRepository) Delete(ctx context.Context, userId, travellerId int64) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM travellers WHERE id = $1 AND user_id = $2`, travellerId, userId)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTravellerNotFound
+	}
+	return nil
+}
+
+// HasIDNumbers reports whether any traveller has an encrypted ID number.
+func (r *travellerRepository) HasIDNumbers(ctx context.Context) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM travellers WHERE id_number IS NOT NULL)`).Scan(&exists)
+	return exists, err
+}
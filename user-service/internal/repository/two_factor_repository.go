@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrTwoFactorEnabled      = errors.New("two-factor authentication is already enabled")
+	ErrLoginChallengeInvalid = errors.New("invalid or expired login challenge")
+)
+
+// maxChallengeAttempts is how many codes can be tried against one login
+// challenge; after that the user has to log in with their password again.
+const maxChallengeAttempts = 5
+
+// TOTP is a user's authenticator secret. EnabledAt is nil until the user
+// confirms the enrollment with a code.
+type TOTP struct {
+	UserID    int64      `json:"user_id" db:"user_id"`
+	Secret    string     `json:"-" db:"secret"`
+	EnabledAt *time.Time `json:"enabled_at" db:"enabled_at"`
+	LastStep  int64      `json:"-" db:"last_step"`
+}
+
+// TwoFactorRepository stores TOTP secrets, hashed recovery codes and the
+// challenges that stand between a correct password and a session.
+type TwoFactorRepository interface {
+	Get(ctx context.Context, userId int64) (*TOTP, error)
+	SaveSecret(ctx context.Context, userId int64, secret string) error
+	Enable(ctx context.Context, userId int64, step int64, recoveryHashes []string) error
+	Disable(ctx context.Context, userId int64) error
+	UseStep(ctx context.Context, userId int64, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userId int64, codeHash string) (bool, error)
+	SetRequired(ctx context.Context, userId int64, required bool) error
+
+	CreateChallenge(ctx context.Context, userId int64, tokenHash string, expiresAt time.Time) error
+	AttemptChallenge(ctx context.Context, tokenHash string) (int64, error)
+	UseChallenge(ctx context.Context, tokenHash string) error
+	RevokeChallenges(ctx context.Context, userId int64) error
+}
+
+type twoFactorRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTwoFactorRepository(db *pgxpool.Pool) TwoFactorRepository {
+	return &twoFactorRepository{db: db}
+}
+
+// Get returns the user's TOTP secret, or nil if they never started an
+// enrollment.
+func (r *twoFactorRepository) Get(ctx context.Context, userId int64) (*TOTP, error) {
+	t := &TOTP{UserID: userId}
+	err := r.db.QueryRow(ctx, `SELECT secret, enabled_at, last_step FROM user_totp WHERE user_id = $1`, userId).
+		Scan(&t.Secret, &t.EnabledAt, &t.LastStep)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// SaveSecret starts an enrollment, replacing one that was never confirmed.
+func (r *twoFactorRepository) SaveSecret(ctx context.Context, userId int64, secret string) error {
+	tag, err := r.db.Exec(ctx, `
+		INSERT INTO user_totp (user_id, secret, created_at) VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_step = 0, created_at = NOW()
+		WHERE user_totp.enabled_at IS NULL`, userId, secret)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTwoFactorEnabled
+	}
+	return nil
+}
+
+// Enable confirms the enrollment with the step of the code that confirmed
+// it, replacing any recovery codes the user had.
+func (r *twoFactorRepository) Enable(ctx context.Context, userId int64, step int64, recoveryHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE user_totp SET enabled_at = NOW(), last_step = $2
+		WHERE user_id = $1 AND enabled_at IS NULL`, userId, step)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTwoFactorEnabled
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM totp_recovery_codes WHERE user_id = $1`, userId); err != nil {
+		return err
+	}
+	for _, hash := range recoveryHashes {
+		_, err := tx.Exec(ctx, `INSERT INTO totp_recovery_codes (user_id, code_hash, created_at) VALUES ($1, $2, NOW())`, userId, hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *twoFactorRepository) Disable(ctx context.Context, userId int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM totp_recovery_codes WHERE user_id = $1`, userId); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM user_totp WHERE user_id = $1`, userId); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// UseStep records that the code for step was used. It reports false if that
+// step or a later one was used already, so a code cannot be replayed.
+func (r *twoFactorRepository) UseStep(ctx context.Context, userId int64, step int64) (bool, error) {
+	tag, err := r.db.Exec(ctx, `UPDATE user_totp SET last_step = $2 WHERE user_id = $1 AND last_step < $2`, userId, step)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// UseRecoveryCode uses up a recovery code. It reports false if the user
+// has no unused code with that hash.
+func (r *twoFactorRepository) UseRecoveryCode(ctx context.Context, userId int64, codeHash string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE totp_recovery_codes SET used_at = NOW()
+		WHERE id = (
+			SELECT id FROM totp_recovery_codes
+			WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+			LIMIT 1
+		)`, userId, codeHash)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *twoFactorRepository) SetRequired(ctx context.Context, userId int64, required bool) error {
+	tag, err := r.db.Exec(ctx, `UPDATE users SET two_factor_required = $2, updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, userId, required)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *twoFactorRepository) CreateChallenge(ctx context.Context, userId int64, tokenHash string, expiresAt time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO login_challenges (user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, NOW())`, userId, tokenHash, expiresAt)
+	return err
+}
+
+// AttemptChallenge counts an attempt at the challenge and returns its user.
+// Used, expired and exhausted challenges are invalid.
+func (r *twoFactorRepository) AttemptChallenge(ctx context.Context, tokenHash string) (int64, error) {
+	var userId int64
+	err := r.db.QueryRow(ctx, `
+		UPDATE login_challenges SET attempts = attempts + 1
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW() AND attempts < $2
+		RETURNING user_id`, tokenHash, maxChallengeAttempts).Scan(&userId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrLoginChallengeInvalid
+	}
+	return userId, err
+}
+
+func (r *twoFactorRepository) UseChallenge(ctx context.Context, tokenHash string) error {
+	tag, err := r.db.Exec(ctx, `UPDATE login_challenges SET used_at = NOW() WHERE token_hash = $1 AND used_at IS NULL`, tokenHash)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrLoginChallengeInvalid
+	}
+	return nil
+}
+
+// RevokeChallenges ends the user's open login challenges, for when the
+// password they were issued for changes.
+func (r *twoFactorRepository) RevokeChallenges(ctx context.Context, userId int64) error {
+	_, err := r.db.Exec(ctx, `UPDATE login_challenges SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL`, userId)
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -15,6 +16,29 @@ type User struct {
 	CreatedAt time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	Roles     []string   `json:"roles" db:"-"`
+
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty" db:"email_verified_at"`
+	Locale          string     `json:"locale" db:"locale"`
+
+	// TwoFactorRequired is set by an admin; TwoFactorEnabled once the user
+	// has confirmed a TOTP enrollment
+	TwoFactorRequired bool `json:"two_factor_required" db:"two_factor_required"`
+	TwoFactorEnabled  bool `json:"two_factor_enabled" db:"-"`
+
+	FullName    string     `json:"full_name" db:"full_name"`
+	Phone       string     `json:"phone" db:"phone"`
+	DateOfBirth *time.Time `json:"date_of_birth,omitempty" db:"date_of_birth"`
+}
+
+// Profile is what users can change about themselves besides their
+// credentials. Empty fields are cleared; Locale is the language emails are
+// sent in.
+type Profile struct {
+	FullName    string
+	Phone       string
+	DateOfBirth *time.Time
+	Locale      string
 }
 
 type UserRepository interface {
@@ -23,6 +47,8 @@ type UserRepository interface {
 	GetByUsername(ctx context.Context, username string) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	UpdatePassword(ctx context.Context, userId int64, newPassword string) error
+	UpdateProfile(ctx context.Context, userId int64, profile *Profile) error
+	Anonymize(ctx context.Context, userId int64) error
 }
 
 type userRepository struct {
@@ -36,9 +62,9 @@ func NewUserRepository(db *pgxpool.Pool) UserRepository {
 }
 
 func (r *userRepository) Create(ctx context.Context, user *User) (*User, error) {
-	query := `INSERT INTO users (username, email, password, created_at) VALUES ($1, $2, $3, NOW()) RETURNING id`
+	query := `INSERT INTO users (username, email, password, locale, full_name, created_at) VALUES ($1, $2, $3, $4, NULLIF($5, ''), NOW()) RETURNING id`
 
-	err := r.db.QueryRow(ctx, query, user.Username, user.Email, user.Password).Scan(&user.ID)
+	err := r.db.QueryRow(ctx, query, user.Username, user.Email, user.Password, user.Locale, user.FullName).Scan(&user.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -46,11 +72,15 @@ func (r *userRepository) Create(ctx context.Context, user *User) (*User, error)
 	return user, nil
 }
 
-func (r *userRepository) GetByID(ctx context.Context, id int64) (*User, error) {
-	query := `SELECT id, username, email, password FROM users WHERE id = $1 AND deleted_at IS NULL`
+// userColumns are the columns scanUser reads.
+const userColumns = `id, username, email, password, email_verified_at, locale, two_factor_required,
+	EXISTS (SELECT 1 FROM user_totp t WHERE t.user_id = users.id AND t.enabled_at IS NOT NULL),
+	COALESCE(full_name, ''), COALESCE(phone, ''), date_of_birth`
 
+func scanUser(row pgx.Row) (*User, error) {
 	user := &User{}
-	err := r.db.QueryRow(ctx, query, id).Scan(&user.ID, &user.Username, &user.Email, &user.Password)
+	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.EmailVerifiedAt, &user.Locale,
+		&user.TwoFactorRequired, &user.TwoFactorEnabled, &user.FullName, &user.Phone, &user.DateOfBirth)
 	if err != nil {
 		return nil, err
 	}
@@ -58,33 +88,111 @@ func (r *userRepository) GetByID(ctx context.Context, id int64) (*User, error) {
 	return user, nil
 }
 
-func (r *userRepository) GetByUsername(ctx context.Context, username string) (*User, error) {
-	query := `SELECT id, username, email, password FROM users WHERE username = $1 AND deleted_at IS NULL`
+func (r *userRepository) GetByID(ctx context.Context, id int64) (*User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1 AND deleted_at IS NULL`
 
-	user := &User{}
-	err := r.db.QueryRow(ctx, query, username).Scan(&user.ID, &user.Username, &user.Email, &user.Password)
-	if err != nil {
-		return nil, err
-	}
+	return scanUser(r.db.QueryRow(ctx, query, id))
+}
 
-	return user, nil
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE username = $1 AND deleted_at IS NULL`
+
+	return scanUser(r.db.QueryRow(ctx, query, username))
 }
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*User, error) {
-	query := `SELECT id, username, email, password FROM users WHERE email = $1 AND deleted_at IS NULL`
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = $1 AND deleted_at IS NULL`
 
-	user := &User{}
-	err := r.db.QueryRow(ctx, query, email).Scan(&user.ID, &user.Username, &user.Email, &user.Password)
-	if err != nil {
-		return nil, err
-	}
-
-	return user, nil
+	return scanUser(r.db.QueryRow(ctx, query, email))
 }
 
 func (r *userRepository) UpdatePassword(ctx context.Context, userId int64, newPassword string) error {
+	return updatePassword(ctx, r.db, userId, newPassword)
+}
+
+// updatePassword sets the password hash and uses up any reset tokens still
+// outstanding, so a link mailed before the change cannot undo it.
+func updatePassword(ctx context.Context, db execer, userId int64, newPassword string) error {
 	query := `UPDATE users SET password = $1, updated_at = NOW() WHERE id = $2`
 
-	_, err := r.db.Exec(ctx, query, newPassword, userId)
+	if _, err := db.Exec(ctx, query, newPassword, userId); err != nil {
+		return err
+	}
+
+	_, err := db.Exec(ctx, `UPDATE password_reset_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL`, userId)
 	return err
 }
+
+func (r *userRepository) UpdateProfile(ctx context.Context, userId int64, profile *Profile) error {
+	query := `
+		UPDATE users
+		SET full_name = NULLIF($2, ''), phone = NULLIF($3, ''), date_of_birth = $4, locale = $5, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	_, err := r.db.Exec(ctx, query, userId, profile.FullName, profile.Phone, profile.DateOfBirth, profile.Locale)
+	return err
+}
+
+// Anonymize deletes the account: personal data is overwritten, and every
+// credential, session and email of the user is removed. The row stays, so
+// bookings keep pointing at a user. It fails with ErrLastAdmin rather than
+// leave the system without an admin.
+func (r *userRepository) Anonymize(ctx context.Context, userId int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var deleted bool
+	err = tx.QueryRow(ctx, `SELECT deleted_at IS NOT NULL FROM users WHERE id = $1 FOR UPDATE`, userId).Scan(&deleted)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		return pgx.ErrNoRows
+	}
+
+	if err := revokeRole(ctx, tx, userId, "admin"); err != nil {
+		return err
+	}
+
+	// Everything keyed by the old username or email goes before those are
+	// overwritten. Sent emails still hold the address and the links in
+	// them, and audit entries the username and the addresses the user
+	// logged in from. The calendar feed and the passengers belong to
+	// booking-service, but the feed's secret URL would keep publishing the
+	// user's trips and the passengers name the people they travelled with.
+	statements := []string{
+		`DELETE FROM notification_outbox WHERE recipient IN (
+			SELECT email FROM users WHERE id = $1
+			UNION SELECT email FROM email_verification_tokens WHERE user_id = $1 AND email IS NOT NULL)`,
+		`UPDATE audit_log SET ip = NULL, detail = detail - 'username'
+		WHERE user_id = $1 OR actor_id = $1
+			OR LOWER(detail->>'username') = (SELECT LOWER(username) FROM users WHERE id = $1)`,
+		`DELETE FROM login_throttles WHERE scope = 'user' AND key = (SELECT LOWER(username) FROM users WHERE id = $1)`,
+		`DELETE FROM login_challenges WHERE user_id = $1`,
+		`DELETE FROM totp_recovery_codes WHERE user_id = $1`,
+		`DELETE FROM user_totp WHERE user_id = $1`,
+		`DELETE FROM email_verification_tokens WHERE user_id = $1`,
+		`DELETE FROM password_reset_tokens WHERE user_id = $1`,
+		`DELETE FROM user_roles WHERE user_id = $1`,
+		`DELETE FROM calendar_feeds WHERE user_id = $1`,
+		`DELETE FROM travellers WHERE user_id = $1`,
+		`UPDATE booking_passengers SET full_name = '', traveller_id = NULL
+		WHERE booking_id IN (SELECT id FROM bookings WHERE user_id = $1)`,
+		`UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`,
+		`UPDATE users
+		SET username = 'deleted_' || id, email = 'deleted_' || id || '@invalid', password = '',
+			full_name = NULL, phone = NULL, date_of_birth = NULL, email_verified_at = NULL,
+			two_factor_required = FALSE, updated_at = NOW(), deleted_at = NOW()
+		WHERE id = $1`,
+	}
+	for _, query := range statements {
+		if _, err := tx.Exec(ctx, query, userId); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrVerificationTokenInvalid = errors.New("invalid or expired verification token")
+	ErrEmailTaken               = errors.New("email already exists")
+)
+
+// VerificationRepository stores email verification tokens by hash. A token
+// either verifies the user's current address or, when created with a new
+// one, switches the account to it.
+type VerificationRepository interface {
+	Create(ctx context.Context, userId int64, newEmail, tokenHash string, expiresAt time.Time, email *OutboxMessage, limit SendLimit) error
+	Consume(ctx context.Context, tokenHash string) (int64, error)
+}
+
+type verificationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewVerificationRepository(db *pgxpool.Pool) VerificationRepository {
+	return &verificationRepository{db: db}
+}
+
+// Create stores the token and queues the email carrying it in one
+// transaction, unless that exceeds limit.
+func (r *verificationRepository) Create(ctx context.Context, userId int64, newEmail, tokenHash string, expiresAt time.Time, email *OutboxMessage, limit SendLimit) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := checkSendLimit(ctx, tx, "email_verification_tokens", userId, "", limit); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO email_verification_tokens (user_id, email, token_hash, expires_at, created_at) VALUES ($1, NULLIF($2, ''), $3, $4, NOW())`
+
+	if _, err := tx.Exec(ctx, query, userId, newEmail, tokenHash, expiresAt); err != nil {
+		return err
+	}
+	if err := enqueue(ctx, tx, email); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Consume marks the user's email as verified, switching to the new address
+// first if the token is for one, and uses up every token sent to them, so
+// older links stop working too. It returns the user ID.
+func (r *verificationRepository) Consume(ctx context.Context, tokenHash string) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var userId int64
+	var newEmail *string
+	err = tx.QueryRow(ctx, `
+		SELECT user_id, email FROM email_verification_tokens
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		FOR UPDATE`, tokenHash).Scan(&userId, &newEmail)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrVerificationTokenInvalid
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE email_verification_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL`, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	if newEmail != nil {
+		_, err = tx.Exec(ctx, `UPDATE users SET email = $2, email_verified_at = NOW(), updated_at = NOW() WHERE id = $1`, userId, *newEmail)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return 0, ErrEmailTaken
+		}
+	} else {
+		_, err = tx.Exec(ctx, `
+			UPDATE users SET email_verified_at = COALESCE(email_verified_at, NOW()), updated_at = NOW()
+			WHERE id = $1`, userId)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return userId, tx.Commit(ctx)
+}
@@ -9,6 +9,14 @@ import (
 func SetupAuthRoutes(mux *http.ServeMux, httpHandler *handler.HTTPHandler) {
 	mux.HandleFunc("/auth/register", httpHandler.Register)
 	mux.HandleFunc("/auth/login", httpHandler.Login)
+	mux.HandleFunc("/auth/login/2fa", httpHandler.LoginTwoFactor)
+	mux.HandleFunc("/auth/refresh", httpHandler.Refresh)
+	mux.HandleFunc("/auth/logout", httpHandler.Logout)
+	mux.HandleFunc("/auth/verify-email", httpHandler.VerifyEmail)
+	mux.HandleFunc("/auth/resend-verification", httpHandler.ResendVerification)
 	mux.HandleFunc("/auth/forgot-password", httpHandler.ForgotPassword)
 	mux.HandleFunc("/auth/reset-password", httpHandler.ResetPassword)
+	mux.HandleFunc("/auth/2fa/setup", httpHandler.SetupTwoFactor)
+	mux.HandleFunc("/auth/2fa/enable", httpHandler.EnableTwoFactor)
+	mux.HandleFunc("/auth/2fa/disable", httpHandler.DisableTwoFactor)
 }
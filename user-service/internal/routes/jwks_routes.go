@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"ticket-booking/user-service/internal/helper"
+)
+
+// SetupJWKSRoutes publishes the public keys access tokens are verified with.
+func SetupJWKSRoutes(mux *http.ServeMux, keys *helper.KeySet) {
+	mux.HandleFunc("/.well-known/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		json.NewEncoder(w).Encode(keys.JWKS())
+	})
+}
@@ -4,14 +4,17 @@ import (
 	"net/http"
 
 	"ticket-booking/user-service/internal/handler"
+	"ticket-booking/user-service/internal/helper"
 )
 
-func SetupRoutes(httpHandler *handler.HTTPHandler) *http.ServeMux {
+func SetupRoutes(httpHandler *handler.HTTPHandler, keys *helper.KeySet) *http.ServeMux {
 	mux := http.NewServeMux()
 
 	SetupHealthRoutes(mux)
 
 	SetupAuthRoutes(mux, httpHandler)
 
+	SetupJWKSRoutes(mux, keys)
+
 	return mux
 }
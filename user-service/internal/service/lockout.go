@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"ticket-booking/user-service/internal/repository"
+)
+
+// An account is locked out after 5 wrong passwords in a row, and a client
+// address after 20 failed logins across any accounts. Each further failure
+// doubles the lockout, from a minute up to an hour.
+var (
+	accountLockout = repository.LockoutPolicy{
+		Allowed:  5,
+		BaseLock: time.Minute,
+		MaxLock:  time.Hour,
+		Window:   24 * time.Hour,
+	}
+	ipLockout = repository.LockoutPolicy{
+		Allowed:  20,
+		BaseLock: time.Minute,
+		MaxLock:  time.Hour,
+		Window:   24 * time.Hour,
+	}
+)
+
+// Audit log actions
+const (
+	auditLoginLocked   = "login_locked"
+	auditLoginUnlocked = "login_unlocked"
+)
+
+var ErrLoginLocked = errors.New("too many failed login attempts, try again later")
+
+// throttleKey is the per-account key; it does not depend on the account
+// existing, so lockouts do not reveal which usernames are taken.
+func throttleKey(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// loginAttempt is a login attempt counted against the account and the
+// client address before the credentials are checked, so parallel guesses
+// cannot slip past the lockout. It ends with loginFailed, loginSucceeded
+// or releaseLogin.
+type loginAttempt struct {
+	username string
+	clientIP string
+	// userLock and ipLock are when the lockouts this attempt causes end,
+	// if it fails
+	userLock *time.Time
+	ipLock   *time.Time
+}
+
+// beginLogin counts an attempt for username and clientIP, failing with
+// ErrLoginLocked while either is locked out. clientIP may be empty.
+func (s *userService) beginLogin(ctx context.Context, username, clientIP string) (*loginAttempt, error) {
+	ok, userLock, err := s.throttleRepo.Reserve(ctx, repository.ThrottleUser, throttleKey(username), accountLockout)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrLoginLocked
+	}
+	a := &loginAttempt{username: username, userLock: userLock}
+	if clientIP == "" {
+		return a, nil
+	}
+
+	ok, ipLock, err := s.throttleRepo.Reserve(ctx, repository.ThrottleIP, clientIP, ipLockout)
+	if err == nil && !ok {
+		err = ErrLoginLocked
+	}
+	if err != nil {
+		s.releaseLogin(ctx, a)
+		return nil, err
+	}
+	a.clientIP, a.ipLock = clientIP, ipLock
+	return a, nil
+}
+
+// loginFailed keeps the attempt counted and audits any lockout it caused.
+// It reports whether the account or address is now locked out. userId is
+// nil for unknown usernames.
+func (s *userService) loginFailed(ctx context.Context, a *loginAttempt, userId *int64) bool {
+	if a.userLock != nil {
+		s.audit(ctx, &repository.AuditEntry{
+			Action: auditLoginLocked,
+			UserID: userId,
+			IP:     a.clientIP,
+			Detail: map[string]interface{}{"scope": repository.ThrottleUser, "username": a.username, "locked_until": a.userLock},
+		})
+	}
+	if a.ipLock != nil {
+		s.audit(ctx, &repository.AuditEntry{
+			Action: auditLoginLocked,
+			IP:     a.clientIP,
+			Detail: map[string]interface{}{"scope": repository.ThrottleIP, "locked_until": a.ipLock},
+		})
+	}
+	return a.userLock != nil || a.ipLock != nil
+}
+
+// loginSucceeded clears the account's failed logins once every factor was
+// accepted. The address keeps its count, less this attempt.
+func (s *userService) loginSucceeded(ctx context.Context, a *loginAttempt) {
+	if _, err := s.throttleRepo.Reset(ctx, repository.ThrottleUser, throttleKey(a.username)); err != nil {
+		log.Printf("reset failed logins for %q: %v", a.username, err)
+	}
+	s.release(ctx, repository.ThrottleIP, a.clientIP, ipLockout)
+}
+
+// releaseLogin takes the attempt back without a verdict, such as when the
+// password was right but a second factor is still to come.
+func (s *userService) releaseLogin(ctx context.Context, a *loginAttempt) {
+	s.release(ctx, repository.ThrottleUser, throttleKey(a.username), accountLockout)
+	s.release(ctx, repository.ThrottleIP, a.clientIP, ipLockout)
+}
+
+func (s *userService) release(ctx context.Context, scope, key string, policy repository.LockoutPolicy) {
+	if key == "" {
+		return
+	}
+	if err := s.throttleRepo.Release(ctx, scope, key, policy); err != nil {
+		log.Printf("release %s login attempt for %q: %v", scope, key, err)
+	}
+}
+
+// UnlockUser lifts the user's account lockout and clears their failed
+// logins. It reports whether the account was locked out.
+func (s *userService) UnlockUser(ctx context.Context, userId, unlockedBy int64) (bool, error) {
+	user, err := s.userRepo.GetByID(ctx, userId)
+	if err != nil {
+		return false, errors.New("user not found")
+	}
+
+	wasLocked, err := s.throttleRepo.Reset(ctx, repository.ThrottleUser, throttleKey(user.Username))
+	if err != nil {
+		return false, err
+	}
+
+	entry := &repository.AuditEntry{
+		Action: auditLoginUnlocked,
+		UserID: &user.ID,
+		Detail: map[string]interface{}{"was_locked": wasLocked},
+	}
+	if unlockedBy != 0 {
+		entry.ActorID = &unlockedBy
+	}
+	s.audit(ctx, entry)
+
+	return wasLocked, nil
+}
+
+// audit records entry, logging instead of failing the caller when the
+// audit log cannot be written.
+func (s *userService) audit(ctx context.Context, entry *repository.AuditEntry) {
+	if err := s.auditRepo.Record(ctx, entry); err != nil {
+		log.Printf("audit %s: %v", entry.Action, err)
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ticket-booking/user-service/internal/repository"
+)
+
+func TestLockoutPolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   repository.LockoutPolicy
+		failures int
+		want     time.Duration
+	}{
+		{"account within allowance", accountLockout, 5, 0},
+		{"account first lockout", accountLockout, 6, time.Minute},
+		{"account doubles", accountLockout, 7, 2 * time.Minute},
+		{"account doubles again", accountLockout, 9, 8 * time.Minute},
+		{"account capped", accountLockout, 50, time.Hour},
+		{"address within allowance", ipLockout, 20, 0},
+		{"address first lockout", ipLockout, 21, time.Minute},
+		{"address capped", ipLockout, 100, time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.LockFor(tt.failures); got != tt.want {
+				t.Errorf("LockFor(%d) = %v, want %v", tt.failures, got, tt.want)
+			}
+		})
+	}
+}
+
+// memThrottle keeps failure counts in memory, locking a key as soon as its
+// count calls for a lockout.
+type memThrottle struct {
+	failures map[string]int
+	locked   map[string]bool
+}
+
+func newMemThrottle() *memThrottle {
+	return &memThrottle{failures: map[string]int{}, locked: map[string]bool{}}
+}
+
+func (m *memThrottle) Reserve(ctx context.Context, scope, key string, policy repository.LockoutPolicy) (bool, *time.Time, error) {
+	k := scope + ":" + key
+	if m.locked[k] {
+		return false, nil, nil
+	}
+	m.failures[k]++
+	if lock := policy.LockFor(m.failures[k]); lock > 0 {
+		m.locked[k] = true
+		until := time.Now().Add(lock)
+		return true, &until, nil
+	}
+	return true, nil, nil
+}
+
+func (m *memThrottle) Release(ctx context.Context, scope, key string, policy repository.LockoutPolicy) error {
+	k := scope + ":" + key
+	if m.failures[k] > 0 {
+		m.failures[k]--
+	}
+	if m.failures[k] <= policy.Allowed {
+		m.locked[k] = false
+	}
+	return nil
+}
+
+func (m *memThrottle) Reset(ctx context.Context, scope, key string) (bool, error) {
+	k := scope + ":" + key
+	locked := m.locked[k]
+	delete(m.failures, k)
+	delete(m.locked, k)
+	return locked, nil
+}
+
+type discardAudit struct{}
+
+func (discardAudit) Record(ctx context.Context, entry *repository.AuditEntry) error { return nil }
+
+func TestLoginAttempts(t *testing.T) {
+	type step struct {
+		outcome string // "fail", "succeed" or "release"
+		locked  bool   // whether beginLogin must refuse the attempt
+	}
+	fails := func(n int) []step {
+		steps := make([]step, n)
+		for i := range steps {
+			steps[i] = step{outcome: "fail"}
+		}
+		return steps
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"locked after allowance", append(fails(6), step{locked: true})},
+		{"success clears failures", append(append(fails(5), step{outcome: "succeed"}), fails(6)...)},
+		{"right password before second factor keeps count", append(append(fails(5), step{outcome: "release"}), step{outcome: "fail"}, step{locked: true})},
+		{"right password on the last allowed attempt", append(fails(5), step{outcome: "release"}, step{outcome: "release"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := &userService{throttleRepo: newMemThrottle(), auditRepo: discardAudit{}}
+
+			for i, st := range tt.steps {
+				a, err := s.beginLogin(ctx, "Alice", "192.0.2.1")
+				if st.locked {
+					if !errors.Is(err, ErrLoginLocked) {
+						t.Fatalf("attempt %d: err = %v, want ErrLoginLocked", i+1, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("attempt %d: %v", i+1, err)
+				}
+				switch st.outcome {
+				case "fail":
+					s.loginFailed(ctx, a, nil)
+				case "succeed":
+					s.loginSucceeded(ctx, a)
+				case "release":
+					s.releaseLogin(ctx, a)
+				}
+			}
+		})
+	}
+}
+
+// Attempts reserved at the same time count before any of them is checked,
+// so no more guesses get through than one at a time would allow.
+func TestLoginAttemptsInFlight(t *testing.T) {
+	ctx := context.Background()
+	s := &userService{throttleRepo: newMemThrottle(), auditRepo: discardAudit{}}
+
+	admitted := 0
+	for i := 0; i < 20; i++ {
+		if _, err := s.beginLogin(ctx, "alice", ""); err == nil {
+			admitted++
+		}
+	}
+	if want := accountLockout.Allowed + 1; admitted != want {
+		t.Errorf("%d attempts admitted, want %d", admitted, want)
+	}
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"ticket-booking/user-service/internal/helper"
+	"ticket-booking/user-service/internal/notification"
+	"ticket-booking/user-service/internal/repository"
+)
+
+// Audit log actions
+const (
+	auditPasswordChanged = "password_changed"
+	auditEmailChange     = "email_change_requested"
+	auditAccountDeleted  = "account_deleted"
+)
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9][0-9 -]{5,18}$`)
+
+// UpdateProfile replaces the user's profile and returns the updated user.
+func (s *userService) UpdateProfile(ctx context.Context, userId int64, profile *repository.Profile) (*repository.User, error) {
+	profile.FullName = strings.TrimSpace(profile.FullName)
+	if utf8.RuneCountInString(profile.FullName) > 100 {
+		return nil, errors.New("full name must be at most 100 characters")
+	}
+	profile.Phone = strings.TrimSpace(profile.Phone)
+	if profile.Phone != "" && !phonePattern.MatchString(profile.Phone) {
+		return nil, errors.New("invalid phone number")
+	}
+	if dob := profile.DateOfBirth; dob != nil {
+		if dob.After(time.Now()) || dob.Year() < 1900 {
+			return nil, errors.New("invalid date of birth")
+		}
+	}
+	if profile.Locale != "" && !notification.Supported(profile.Locale) {
+		return nil, errors.New("unsupported language, expected one of: " + strings.Join(notification.Locales, ", "))
+	}
+	profile.Locale = notification.Locale(profile.Locale)
+
+	if err := s.userRepo.UpdateProfile(ctx, userId, profile); err != nil {
+		return nil, err
+	}
+	return s.GetUser(ctx, userId)
+}
+
+// ChangeEmail sends a verification link to the new address. The account
+// keeps its current address until the link is followed.
+func (s *userService) ChangeEmail(ctx context.Context, userId int64, password, newEmail string) error {
+	newEmail = strings.TrimSpace(newEmail)
+	if err := helper.ValidateEmail(newEmail); err != nil {
+		return err
+	}
+
+	user, err := s.checkPassword(ctx, userId, password)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(newEmail, user.Email) {
+		return errors.New("that is already your email address")
+	}
+	if _, err := s.userRepo.GetByEmail(ctx, newEmail); err == nil {
+		return repository.ErrEmailTaken
+	}
+	if err := s.sendVerification(ctx, user, newEmail); err != nil {
+		return err
+	}
+	s.audit(ctx, &repository.AuditEntry{Action: auditEmailChange, UserID: &userId, ActorID: &userId})
+	return nil
+}
+
+// ChangePassword sets a new password. Every session of the user ends, so
+// it returns tokens for a fresh one.
+func (s *userService) ChangePassword(ctx context.Context, userId int64, currentPassword, newPassword string) (*Tokens, error) {
+	if err := helper.ValidatePassword(newPassword); err != nil {
+		return nil, err
+	}
+
+	user, err := s.checkPassword(ctx, userId, currentPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.UpdatePassword(ctx, userId, string(hashedPassword)); err != nil {
+		return nil, err
+	}
+	if err := s.refreshRepo.RevokeByUser(ctx, userId); err != nil {
+		return nil, err
+	}
+	if err := s.twoFactorRepo.RevokeChallenges(ctx, userId); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, &repository.AuditEntry{Action: auditPasswordChanged, UserID: &userId, ActorID: &userId})
+
+	if user.Roles, err = s.roles(ctx, userId); err != nil {
+		return nil, err
+	}
+	return s.startSession(ctx, user)
+}
+
+// DeleteAccount anonymises the user. Their bookings are kept for the
+// operator's records but no longer lead back to a person. Accounts with
+// 2FA need a code as well as the password.
+func (s *userService) DeleteAccount(ctx context.Context, userId int64, password, code string) error {
+	user, err := s.checkPassword(ctx, userId, password)
+	if err != nil {
+		return err
+	}
+	if user.TwoFactorEnabled {
+		if err := s.checkSecondFactor(ctx, userId, code); err != nil {
+			return err
+		}
+	}
+
+	if err := s.userRepo.Anonymize(ctx, userId); err != nil {
+		return err
+	}
+	s.audit(ctx, &repository.AuditEntry{Action: auditAccountDeleted, UserID: &userId, ActorID: &userId})
+	return nil
+}
+
+// checkPassword loads the user and checks their current password. Wrong
+// passwords count towards the account lockout like failed logins, so a
+// stolen session cannot be used to guess the password.
+func (s *userService) checkPassword(ctx context.Context, userId int64, password string) (*repository.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userId)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	attempt, err := s.beginLogin(ctx, user.Username, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		if s.loginFailed(ctx, attempt, &user.ID) {
+			return nil, ErrLoginLocked
+		}
+		return nil, errors.New("invalid credentials")
+	}
+	if user.TwoFactorEnabled {
+		s.releaseLogin(ctx, attempt)
+	} else {
+		s.loginSucceeded(ctx, attempt)
+	}
+	return user, nil
+}
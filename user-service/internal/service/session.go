@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+
+	"ticket-booking/user-service/internal/repository"
+)
+
+const (
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 30 * 24 * time.Hour
+)
+
+var ErrUnauthenticated = errors.New("invalid or expired token")
+
+// Tokens is what a client gets on login, registration and refresh. The
+// access token is a short-lived JWT; the refresh token is opaque and can be
+// used once to get the next pair.
+//
+// When a login needs a second factor, only ChallengeToken is set, and
+// ExpiresIn says how long it can be redeemed.
+type Tokens struct {
+	AccessToken    string
+	RefreshToken   string
+	ExpiresIn      int64
+	ChallengeToken string
+}
+
+// startSession opens a new token family for the user.
+func (s *userService) startSession(ctx context.Context, user *repository.User) (*Tokens, error) {
+	familyId, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh, hash, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.refreshRepo.Create(ctx, &repository.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyId,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issue(user, familyId, refresh)
+}
+
+// Refresh trades a refresh token for a new pair. The user and their roles
+// are reloaded, so role changes take effect here.
+func (s *userService) Refresh(ctx context.Context, refreshToken string) (*repository.User, *Tokens, error) {
+	if refreshToken == "" {
+		return nil, nil, errors.New("refresh_token is required")
+	}
+
+	refresh, hash, err := newOpaqueToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	next := &repository.RefreshToken{
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+	}
+	if _, err := s.refreshRepo.Rotate(ctx, hashToken(refreshToken), next); err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.GetUser(ctx, next.UserID)
+	if err != nil {
+		// A deleted user must not keep a session
+		s.refreshRepo.RevokeFamily(ctx, next.FamilyID)
+		return nil, nil, repository.ErrRefreshTokenInvalid
+	}
+
+	tokens, err := s.issue(user, next.FamilyID, refresh)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, tokens, nil
+}
+
+// Logout revokes the session the refresh token belongs to. Access tokens
+// of that session are refused as soon as the gateway checks them.
+func (s *userService) Logout(ctx context.Context, refreshToken string) error {
+	if refreshToken == "" {
+		return errors.New("refresh_token is required")
+	}
+	return s.refreshRepo.RevokeByHash(ctx, hashToken(refreshToken))
+}
+
+// Authenticate checks an access token this service issued and returns the
+// user it belongs to. It lets the user-service HTTP endpoints that act on
+// the caller's own account work without the gateway in front.
+func (s *userService) Authenticate(ctx context.Context, accessToken string) (int64, error) {
+	token, err := jwt.Parse(accessToken, s.keys.Keyfunc)
+	if err != nil || !token.Valid {
+		return 0, ErrUnauthenticated
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, ErrUnauthenticated
+	}
+	userId, ok := claims["user_id"].(float64)
+	if !ok {
+		return 0, ErrUnauthenticated
+	}
+
+	sid, _ := claims["sid"].(string)
+	revoked, err := s.IsSessionRevoked(ctx, sid)
+	if err != nil {
+		return 0, err
+	}
+	if revoked {
+		return 0, ErrUnauthenticated
+	}
+	return int64(userId), nil
+}
+
+func (s *userService) IsSessionRevoked(ctx context.Context, sessionId string) (bool, error) {
+	if sessionId == "" {
+		return true, nil
+	}
+	return s.refreshRepo.IsFamilyRevoked(ctx, sessionId)
+}
+
+// issue signs an access token for the session with the active key; sid
+// lets the gateway check whether the session was revoked.
+func (s *userService) issue(user *repository.User, familyId, refreshToken string) (*Tokens, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"user_id":        user.ID,
+		"username":       user.Username,
+		"roles":          user.Roles,
+		"email_verified": user.EmailVerifiedAt != nil,
+		// Only good for enrolling until the user sets up the 2FA an admin
+		// made mandatory
+		"two_factor_setup": user.TwoFactorRequired && !user.TwoFactorEnabled,
+		"sid":              familyId,
+		"iat":              now.Unix(),
+		"exp":              now.Add(accessTokenTTL).Unix(),
+	}
+
+	tokenString, err := s.keys.Sign(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tokens{
+		AccessToken:  tokenString,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(accessTokenTTL.Seconds()),
+	}, nil
+}
+
+// newOpaqueToken returns a random token and the hash it is stored under.
+func newOpaqueToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
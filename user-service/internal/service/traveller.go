@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	bookingpb "ticket-booking/proto/booking"
+	"ticket-booking/user-service/internal/repository"
+)
+
+// maxTravellers is how many co-travellers one user can save
+const maxTravellers = 20
+
+var idNumberPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 -]{3,29}$`)
+
+func (s *userService) ListTravellers(ctx context.Context, userId int64) ([]*repository.Traveller, error) {
+	travellers, err := s.travellerRepo.ListByUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range travellers {
+		if err := s.maskIDNumber(t); err != nil {
+			return nil, err
+		}
+	}
+	return travellers, nil
+}
+
+func (s *userService) CreateTraveller(ctx context.Context, userId int64, fullName, idNumber, passengerType string) (*repository.Traveller, error) {
+	traveller := &repository.Traveller{UserID: userId}
+	if err := s.fillTraveller(traveller, fullName, idNumber, passengerType); err != nil {
+		return nil, err
+	}
+
+	traveller, err := s.travellerRepo.Create(ctx, traveller, maxTravellers)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.maskIDNumber(traveller); err != nil {
+		return nil, err
+	}
+	return traveller, nil
+}
+
+// UpdateTraveller replaces the traveller's details. The full ID number is
+// never sent back to clients, so an empty idNumber keeps the stored one.
+func (s *userService) UpdateTraveller(ctx context.Context, userId, travellerId int64, fullName, idNumber, passengerType string) (*repository.Traveller, error) {
+	traveller, err := s.travellerRepo.GetByID(ctx, userId, travellerId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fillTraveller(traveller, fullName, idNumber, passengerType); err != nil {
+		return nil, err
+	}
+
+	if err := s.travellerRepo.Update(ctx, traveller); err != nil {
+		return nil, err
+	}
+	if err := s.maskIDNumber(traveller); err != nil {
+		return nil, err
+	}
+	return traveller, nil
+}
+
+func (s *userService) DeleteTraveller(ctx context.Context, userId, travellerId int64) error {
+	return s.travellerRepo.Delete(ctx, userId, travellerId)
+}
+
+// fillTraveller validates the details and sets them on t, encrypting the
+// ID number.
+func (s *userService) fillTraveller(t *repository.Traveller, fullName, idNumber, passengerType string) error {
+	fullName, passengerType, err := bookingpb.CleanPassenger(fullName, passengerType)
+	if err != nil {
+		return err
+	}
+
+	if idNumber = strings.TrimSpace(idNumber); idNumber != "" {
+		if !idNumberPattern.MatchString(idNumber) {
+			return errors.New("ID number must be 4 to 30 letters, digits, spaces or dashes")
+		}
+		encrypted, err := s.fieldCipher.Encrypt(idNumber, travellerContext(t.UserID))
+		if err != nil {
+			return err
+		}
+		t.IDNumber = encrypted
+	}
+
+	t.FullName = fullName
+	t.PassengerType = passengerType
+	return nil
+}
+
+// maskIDNumber sets the hint the user recognises the ID number by, its
+// last four characters. Numbers that short are hidden entirely. It fails
+// when the number cannot be decrypted, which means the key is wrong.
+func (s *userService) maskIDNumber(t *repository.Traveller) error {
+	if t.IDNumber == "" {
+		return nil
+	}
+	idNumber, err := s.fieldCipher.Decrypt(t.IDNumber, travellerContext(t.UserID))
+	if err != nil {
+		return fmt.Errorf("traveller %d: %w", t.ID, err)
+	}
+	hint := "****"
+	if n := utf8.RuneCountInString(idNumber); n > 4 {
+		hint = strings.Repeat("*", n-4) + string([]rune(idNumber)[n-4:])
+	}
+	t.IDNumberHint = hint
+	return nil
+}
+
+// travellerContext binds an encrypted ID number to the user who saved it.
+func travellerContext(userId int64) string {
+	return "traveller:" + strconv.FormatInt(userId, 10)
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"ticket-booking/user-service/internal/helper"
+	"ticket-booking/user-service/internal/repository"
+)
+
+const (
+	// challengeTTL is how long a user has to enter their code after the
+	// password was accepted
+	challengeTTL      = 5 * time.Minute
+	recoveryCodeCount = 10
+	totpIssuer        = "Ticket Booking"
+)
+
+// Audit log actions
+const (
+	auditTwoFactorEnabled     = "two_factor_enabled"
+	auditTwoFactorDisabled    = "two_factor_disabled"
+	auditTwoFactorRequired    = "two_factor_required"
+	auditTwoFactorNotRequired = "two_factor_not_required"
+	auditRecoveryCodeUsed     = "recovery_code_used"
+)
+
+var (
+	ErrTwoFactorCodeInvalid = errors.New("invalid two-factor code")
+	ErrTwoFactorNotEnabled  = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorMandatory   = errors.New("two-factor authentication is required for this account")
+	ErrTwoFactorNoSetup     = errors.New("start two-factor setup first")
+)
+
+// challenge stands in for the tokens when the password was right but the
+// account needs a second factor. Its token is redeemed by LoginTwoFactor.
+func (s *userService) challenge(ctx context.Context, user *repository.User) (*Tokens, error) {
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.twoFactorRepo.CreateChallenge(ctx, user.ID, hash, time.Now().Add(challengeTTL)); err != nil {
+		return nil, err
+	}
+	return &Tokens{
+		ChallengeToken: token,
+		ExpiresIn:      int64(challengeTTL.Seconds()),
+	}, nil
+}
+
+// LoginTwoFactor finishes a login that returned a challenge. code is the
+// current TOTP code or one of the user's recovery codes. Wrong codes count
+// as failed logins, the same as wrong passwords.
+func (s *userService) LoginTwoFactor(ctx context.Context, challengeToken, code, clientIP string) (*repository.User, *Tokens, error) {
+	if challengeToken == "" {
+		return nil, nil, repository.ErrLoginChallengeInvalid
+	}
+	hash := hashToken(challengeToken)
+
+	userId, err := s.twoFactorRepo.AttemptChallenge(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := s.userRepo.GetByID(ctx, userId)
+	if err != nil {
+		return nil, nil, repository.ErrLoginChallengeInvalid
+	}
+
+	attempt, err := s.beginLogin(ctx, user.Username, clientIP)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.checkSecondFactor(ctx, user.ID, code); err != nil {
+		if !errors.Is(err, ErrTwoFactorCodeInvalid) {
+			s.releaseLogin(ctx, attempt)
+		} else if s.loginFailed(ctx, attempt, &user.ID) {
+			return nil, nil, ErrLoginLocked
+		}
+		return nil, nil, err
+	}
+	if err := s.twoFactorRepo.UseChallenge(ctx, hash); err != nil {
+		s.releaseLogin(ctx, attempt)
+		return nil, nil, err
+	}
+	s.loginSucceeded(ctx, attempt)
+
+	if user.Roles, err = s.roles(ctx, user.ID); err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.startSession(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, tokens, nil
+}
+
+// checkSecondFactor accepts a TOTP code not used before, or uses up a
+// recovery code.
+func (s *userService) checkSecondFactor(ctx context.Context, userId int64, code string) error {
+	totp, err := s.twoFactorRepo.Get(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if totp == nil || totp.EnabledAt == nil {
+		return ErrTwoFactorNotEnabled
+	}
+
+	if step, ok := helper.ValidateTOTP(totp.Secret, code, time.Now()); ok {
+		fresh, err := s.twoFactorRepo.UseStep(ctx, userId, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return ErrTwoFactorCodeInvalid
+		}
+		return nil
+	}
+
+	used, err := s.twoFactorRepo.UseRecoveryCode(ctx, userId, hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrTwoFactorCodeInvalid
+	}
+	s.audit(ctx, &repository.AuditEntry{Action: auditRecoveryCodeUsed, UserID: &userId})
+	return nil
+}
+
+// SetupTwoFactor starts an enrollment and returns the secret with its
+// provisioning URI. It has no effect until EnableTwoFactor confirms it.
+func (s *userService) SetupTwoFactor(ctx context.Context, userId int64) (string, string, error) {
+	user, err := s.userRepo.GetByID(ctx, userId)
+	if err != nil {
+		return "", "", errors.New("user not found")
+	}
+
+	secret, err := helper.GenerateTOTPSecret()
+	if err != nil {
+		return "", "", err
+	}
+	if err := s.twoFactorRepo.SaveSecret(ctx, userId, secret); err != nil {
+		return "", "", err
+	}
+
+	return secret, helper.TOTPProvisioningURI(totpIssuer, user.Username, secret), nil
+}
+
+// EnableTwoFactor confirms the enrollment with a code from the
+// authenticator and returns the recovery codes. They are only stored
+// hashed, so this is the one time they can be shown.
+func (s *userService) EnableTwoFactor(ctx context.Context, userId int64, code string) ([]string, error) {
+	totp, err := s.twoFactorRepo.Get(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if totp == nil {
+		return nil, ErrTwoFactorNoSetup
+	}
+	if totp.EnabledAt != nil {
+		return nil, repository.ErrTwoFactorEnabled
+	}
+
+	step, ok := helper.ValidateTOTP(totp.Secret, code, time.Now())
+	if !ok {
+		return nil, ErrTwoFactorCodeInvalid
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		raw, err := randomHex(5)
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = raw[:5] + "-" + raw[5:]
+		hashes[i] = hashToken(raw)
+	}
+
+	if err := s.twoFactorRepo.Enable(ctx, userId, step, hashes); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, &repository.AuditEntry{Action: auditTwoFactorEnabled, UserID: &userId, ActorID: &userId})
+
+	return codes, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off. It takes the
+// password and a code, so a stolen session alone cannot do it.
+func (s *userService) DisableTwoFactor(ctx context.Context, userId int64, password, code string) error {
+	user, err := s.checkPassword(ctx, userId, password)
+	if err != nil {
+		return err
+	}
+	if user.TwoFactorRequired {
+		return ErrTwoFactorMandatory
+	}
+	if err := s.checkSecondFactor(ctx, userId, code); err != nil {
+		return err
+	}
+
+	if err := s.twoFactorRepo.Disable(ctx, userId); err != nil {
+		return err
+	}
+	s.audit(ctx, &repository.AuditEntry{Action: auditTwoFactorDisabled, UserID: &userId, ActorID: &userId})
+	return nil
+}
+
+// RequireTwoFactor makes two-factor authentication mandatory for the user,
+// or optional again. Until they enroll, their access tokens only work for
+// setting it up.
+func (s *userService) RequireTwoFactor(ctx context.Context, userId int64, required bool, changedBy int64) error {
+	if err := s.twoFactorRepo.SetRequired(ctx, userId, required); err != nil {
+		return errors.New("user not found")
+	}
+
+	entry := &repository.AuditEntry{Action: auditTwoFactorNotRequired, UserID: &userId}
+	if required {
+		entry.Action = auditTwoFactorRequired
+	}
+	if changedBy != 0 {
+		entry.ActorID = &changedBy
+	}
+	s.audit(ctx, entry)
+	return nil
+}
+
+// normalizeRecoveryCode accepts codes typed with or without the dash and
+// in either case.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
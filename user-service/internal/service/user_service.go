@@ -3,153 +3,299 @@ package service
 import (
 	"context"
 	"errors"
+	"log"
+	"strings"
 	"time"
 
-	"github.com/dgrijalva/jwt-go"
 	"golang.org/x/crypto/bcrypt"
 
+	"ticket-booking/proto/auth"
+	"ticket-booking/user-service/internal/helper"
+	"ticket-booking/user-service/internal/notification"
 	"ticket-booking/user-service/internal/repository"
 )
 
+// resetTokenTTL is how long a password reset token can be used
+const resetTokenTTL = time.Hour
+
+// resetLimit caps reset emails like verification emails, and also per
+// client address so one client cannot flood many inboxes.
+var resetLimit = repository.SendLimit{
+	PerDay:   verificationDailyLimit,
+	Wait:     verificationResendWait,
+	PerIPDay: 20,
+}
+
 type UserService interface {
-	Register(ctx context.Context, username, email, password, confirmPassword string) (*repository.User, string, error)
-	Login(ctx context.Context, username, password string) (*repository.User, string, error)
+	Register(ctx context.Context, username, email, password, confirmPassword, locale, fullName string) (*repository.User, *Tokens, error)
+	Login(ctx context.Context, username, password, clientIP string) (*repository.User, *Tokens, error)
+	Refresh(ctx context.Context, refreshToken string) (*repository.User, *Tokens, error)
+	Logout(ctx context.Context, refreshToken string) error
+	IsSessionRevoked(ctx context.Context, sessionId string) (bool, error)
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, email string) error
 	GetUser(ctx context.Context, id int64) (*repository.User, error)
-	ForgotPassword(ctx context.Context, email string) (string, error)
+	ForgotPassword(ctx context.Context, email, clientIP string) error
 	ResetPassword(ctx context.Context, token, newPassword string) error
+	GrantRole(ctx context.Context, userId int64, role string, grantedBy int64) ([]string, error)
+	RevokeRole(ctx context.Context, userId int64, role string) ([]string, error)
+	UnlockUser(ctx context.Context, userId, unlockedBy int64) (bool, error)
+	Authenticate(ctx context.Context, accessToken string) (int64, error)
+	LoginTwoFactor(ctx context.Context, challengeToken, code, clientIP string) (*repository.User, *Tokens, error)
+	SetupTwoFactor(ctx context.Context, userId int64) (string, string, error)
+	EnableTwoFactor(ctx context.Context, userId int64, code string) ([]string, error)
+	DisableTwoFactor(ctx context.Context, userId int64, password, code string) error
+	RequireTwoFactor(ctx context.Context, userId int64, required bool, changedBy int64) error
+	UpdateProfile(ctx context.Context, userId int64, profile *repository.Profile) (*repository.User, error)
+	ChangeEmail(ctx context.Context, userId int64, password, newEmail string) error
+	ChangePassword(ctx context.Context, userId int64, currentPassword, newPassword string) (*Tokens, error)
+	DeleteAccount(ctx context.Context, userId int64, password, code string) error
+	ListTravellers(ctx context.Context, userId int64) ([]*repository.Traveller, error)
+	CreateTraveller(ctx context.Context, userId int64, fullName, idNumber, passengerType string) (*repository.Traveller, error)
+	UpdateTraveller(ctx context.Context, userId, travellerId int64, fullName, idNumber, passengerType string) (*repository.Traveller, error)
+	DeleteTraveller(ctx context.Context, userId, travellerId int64) error
 }
 
 type userService struct {
-	userRepo repository.UserRepository
-	jwtKey   []byte
+	userRepo      repository.UserRepository
+	roleRepo      repository.RoleRepository
+	refreshRepo   repository.RefreshTokenRepository
+	verifyRepo    repository.VerificationRepository
+	resetRepo     repository.PasswordResetRepository
+	outboxRepo    repository.OutboxRepository
+	throttleRepo  repository.LoginThrottleRepository
+	auditRepo     repository.AuditRepository
+	twoFactorRepo repository.TwoFactorRepository
+	travellerRepo repository.TravellerRepository
+	keys          *helper.KeySet
+	fieldCipher   *helper.FieldCipher
+	publicURL     string
 }
 
-func NewUserService(userRepo repository.UserRepository, jwtKey string) UserService {
+// NewUserService signs access tokens with keys and encrypts personal data
+// at rest with fieldCipher. Emails are queued in the outbox; their links
+// point at publicURL, the gateway.
+func NewUserService(userRepo repository.UserRepository, roleRepo repository.RoleRepository, refreshRepo repository.RefreshTokenRepository, verifyRepo repository.VerificationRepository, resetRepo repository.PasswordResetRepository, outboxRepo repository.OutboxRepository, throttleRepo repository.LoginThrottleRepository, auditRepo repository.AuditRepository, twoFactorRepo repository.TwoFactorRepository, travellerRepo repository.TravellerRepository, keys *helper.KeySet, fieldCipher *helper.FieldCipher, publicURL string) UserService {
 	return &userService{
-		userRepo: userRepo,
-		jwtKey:   []byte(jwtKey),
+		userRepo:      userRepo,
+		roleRepo:      roleRepo,
+		refreshRepo:   refreshRepo,
+		verifyRepo:    verifyRepo,
+		resetRepo:     resetRepo,
+		outboxRepo:    outboxRepo,
+		throttleRepo:  throttleRepo,
+		auditRepo:     auditRepo,
+		twoFactorRepo: twoFactorRepo,
+		travellerRepo: travellerRepo,
+		keys:          keys,
+		fieldCipher:   fieldCipher,
+		publicURL:     publicURL,
 	}
 }
 
-func (s *userService) Register(ctx context.Context, username, email, password, confirmPassword string) (*repository.User, string, error) {
+func (s *userService) Register(ctx context.Context, username, email, password, confirmPassword, locale, fullName string) (*repository.User, *Tokens, error) {
 	if password != confirmPassword {
-		return nil, "", errors.New("password and confirm password do not match")
+		return nil, nil, errors.New("password and confirm password do not match")
 	}
 
 	_, err := s.userRepo.GetByUsername(ctx, username)
 	if err == nil {
-		return nil, "", errors.New("username already exists")
+		return nil, nil, errors.New("username already exists")
 	}
 
 	_, err = s.userRepo.GetByEmail(ctx, email)
 	if err == nil {
-		return nil, "", errors.New("email already exists")
+		return nil, nil, errors.New("email already exists")
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	user := &repository.User{
 		Username: username,
 		Email:    email,
 		Password: string(hashedPassword),
+		Locale:   notification.Locale(locale),
+		FullName: strings.TrimSpace(fullName),
 	}
 
 	user, err = s.userRepo.Create(ctx, user)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
+	user.Roles = []string{auth.RoleCustomer}
 
-	token, err := s.generateToken(user)
+	// The account works without a verified email, except for booking, and
+	// the user can ask for another link
+	if err := s.sendVerification(ctx, user, ""); err != nil {
+		log.Printf("send verification email to user %d: %v", user.ID, err)
+	}
+
+	tokens, err := s.startSession(ctx, user)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
-	return user, token, nil
+	return user, tokens, nil
 }
 
-func (s *userService) Login(ctx context.Context, username, password string) (*repository.User, string, error) {
-	user, err := s.userRepo.GetByUsername(ctx, username)
+// Login checks the password unless the account or clientIP is locked out
+// by earlier failures. clientIP may be empty when it is not known.
+func (s *userService) Login(ctx context.Context, username, password, clientIP string) (*repository.User, *Tokens, error) {
+	attempt, err := s.beginLogin(ctx, username, clientIP)
 	if err != nil {
-		return nil, "", errors.New("invalid credentials")
+		return nil, nil, err
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+	user, err := s.userRepo.GetByUsername(ctx, username)
 	if err != nil {
-		return nil, "", errors.New("invalid credentials")
+		if s.loginFailed(ctx, attempt, nil) {
+			return nil, nil, ErrLoginLocked
+		}
+		return nil, nil, errors.New("invalid credentials")
 	}
 
-	token, err := s.generateToken(user)
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
-		return nil, "", err
+		if s.loginFailed(ctx, attempt, &user.ID) {
+			return nil, nil, ErrLoginLocked
+		}
+		return nil, nil, errors.New("invalid credentials")
 	}
 
-	return user, token, nil
-}
+	// With two-factor authentication the failures are only cleared once the
+	// code is right too, so guessing codes counts towards the lockout.
+	if user.TwoFactorEnabled {
+		s.releaseLogin(ctx, attempt)
+		tokens, err := s.challenge(ctx, user)
+		if err != nil {
+			return nil, nil, err
+		}
+		return user, tokens, nil
+	}
+	s.loginSucceeded(ctx, attempt)
 
-func (s *userService) GetUser(ctx context.Context, id int64) (*repository.User, error) {
-	return s.userRepo.GetByID(ctx, id)
-}
+	if user.Roles, err = s.roles(ctx, user.ID); err != nil {
+		return nil, nil, err
+	}
 
-func (s *userService) ForgotPassword(ctx context.Context, email string) (string, error) {
-	user, err := s.userRepo.GetByEmail(ctx, email)
+	tokens, err := s.startSession(ctx, user)
 	if err != nil {
-		return "", errors.New("email not found")
+		return nil, nil, err
 	}
 
-	resetToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": user.ID,
-		"exp":     time.Now().Add(1 * time.Hour).Unix(),
-		"type":    "reset",
-	})
+	return user, tokens, nil
+}
 
-	tokenString, err := resetToken.SignedString(s.jwtKey)
+func (s *userService) GetUser(ctx context.Context, id int64) (*repository.User, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	if user.Roles, err = s.roles(ctx, user.ID); err != nil {
+		return nil, err
 	}
+	return user, nil
+}
 
-	return tokenString, nil
+// GrantRole gives a user an extra role. It shows up in their access token
+// from the next refresh on.
+func (s *userService) GrantRole(ctx context.Context, userId int64, role string, grantedBy int64) ([]string, error) {
+	if err := s.checkRoleChange(ctx, userId, role); err != nil {
+		return nil, err
+	}
+	if err := s.roleRepo.Grant(ctx, userId, role, grantedBy); err != nil {
+		return nil, err
+	}
+	return s.roles(ctx, userId)
 }
 
-func (s *userService) ResetPassword(ctx context.Context, token, newPassword string) error {
-	parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
-		return s.jwtKey, nil
-	})
+// RevokeRole takes an extra role away and ends the user's sessions, so no
+// access token carrying the role outlives the revocation check.
+func (s *userService) RevokeRole(ctx context.Context, userId int64, role string) ([]string, error) {
+	if err := s.checkRoleChange(ctx, userId, role); err != nil {
+		return nil, err
+	}
+	if err := s.roleRepo.Revoke(ctx, userId, role); err != nil {
+		return nil, err
+	}
+	if err := s.refreshRepo.RevokeByUser(ctx, userId); err != nil {
+		return nil, err
+	}
+	return s.roles(ctx, userId)
+}
 
-	if err != nil || !parsedToken.Valid {
-		return errors.New("invalid or expired token")
+func (s *userService) checkRoleChange(ctx context.Context, userId int64, role string) error {
+	if !auth.IsRole(role) {
+		return errors.New("invalid role, expected operator, admin or support")
+	}
+	if role == auth.RoleCustomer {
+		return errors.New("every user is a customer")
 	}
+	if _, err := s.userRepo.GetByID(ctx, userId); err != nil {
+		return errors.New("user not found")
+	}
+	return nil
+}
 
-	claims, ok := parsedToken.Claims.(jwt.MapClaims)
-	if !ok || claims["type"] != "reset" {
-		return errors.New("invalid token type")
+// roles returns every role of the user, customer included.
+func (s *userService) roles(ctx context.Context, userId int64) ([]string, error) {
+	extra, err := s.roleRepo.ListByUser(ctx, userId)
+	if err != nil {
+		return nil, err
 	}
+	return append([]string{auth.RoleCustomer}, extra...), nil
+}
 
-	userID := int64(claims["user_id"].(float64))
+// ForgotPassword emails a single-use reset token. Unknown addresses, and
+// requests over resetLimit, are accepted silently so the endpoint does not
+// reveal which emails have accounts.
+func (s *userService) ForgotPassword(ctx context.Context, email, clientIP string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil
+	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	token, hash, err := newOpaqueToken()
 	if err != nil {
 		return err
 	}
 
-	return s.userRepo.UpdatePassword(ctx, userID, string(hashedPassword))
+	msg := &repository.OutboxMessage{
+		Template:  notification.TemplatePasswordReset,
+		Locale:    user.Locale,
+		Recipient: user.Email,
+		Data: map[string]interface{}{
+			"username": user.Username,
+			"token":    token,
+			"hours":    int(resetTokenTTL.Hours()),
+		},
+	}
+	err = s.resetRepo.Create(ctx, user.ID, clientIP, hash, time.Now().Add(resetTokenTTL), msg, resetLimit)
+	if errors.Is(err, repository.ErrSendLimited) {
+		return nil
+	}
+	return err
 }
 
-func (s *userService) generateToken(user *repository.User) (string, error) {
-	expirationTime := time.Now().Add(24 * time.Hour)
-	claims := jwt.MapClaims{
-		"user_id":  user.ID,
-		"username": user.Username,
-		"exp":      expirationTime.Unix(),
+// ResetPassword uses up the token and ends every session of the user, since
+// whoever knew the old password may still hold one.
+func (s *userService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if token == "" {
+		return repository.ErrResetTokenInvalid
+	}
+	// Checked before the token is used up, so a rejected password can be retried
+	if err := helper.ValidatePassword(newPassword); err != nil {
+		return err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(s.jwtKey)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	return tokenString, nil
+	_, err = s.resetRepo.Consume(ctx, hashToken(token), string(hashedPassword))
+	return err
 }
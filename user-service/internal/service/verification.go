@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"ticket-booking/user-service/internal/notification"
+	"ticket-booking/user-service/internal/repository"
+)
+
+const (
+	verificationTokenTTL = 24 * time.Hour
+	// A user can ask for a new verification email once a minute and five
+	// times a day
+	verificationResendWait = time.Minute
+	verificationDailyLimit = 5
+)
+
+var verificationLimit = repository.SendLimit{
+	PerDay: verificationDailyLimit,
+	Wait:   verificationResendWait,
+}
+
+var ErrVerificationThrottled = errors.New("too many verification emails requested, try again later")
+
+// sendVerification queues an email with a single-use link that verifies the
+// user's address, or newEmail when it is set, which the account then
+// switches to. It fails with ErrVerificationThrottled once the user was
+// sent too many lately.
+func (s *userService) sendVerification(ctx context.Context, user *repository.User, newEmail string) error {
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	recipient := user.Email
+	if newEmail != "" {
+		recipient = newEmail
+	}
+
+	email := &repository.OutboxMessage{
+		Template:  notification.TemplateEmailVerification,
+		Locale:    user.Locale,
+		Recipient: recipient,
+		Data: map[string]interface{}{
+			"username": user.Username,
+			"link":     s.publicURL + "/api/auth/verify-email?token=" + url.QueryEscape(token),
+			"hours":    int(verificationTokenTTL.Hours()),
+		},
+	}
+	err = s.verifyRepo.Create(ctx, user.ID, newEmail, hash, time.Now().Add(verificationTokenTTL), email, verificationLimit)
+	if errors.Is(err, repository.ErrSendLimited) {
+		return ErrVerificationThrottled
+	}
+	return err
+}
+
+// VerifyEmail uses up a verification token. The email_verified claim is
+// set in access tokens issued from then on, so clients should refresh.
+func (s *userService) VerifyEmail(ctx context.Context, token string) error {
+	if token == "" {
+		return errors.New("token is required")
+	}
+	_, err := s.verifyRepo.Consume(ctx, hashToken(token))
+	return err
+}
+
+// ResendVerification sends a fresh link. Unknown and already verified
+// addresses, and requests over the limit, are accepted silently so the
+// endpoint does not reveal which emails have accounts.
+func (s *userService) ResendVerification(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil || user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	err = s.sendVerification(ctx, user, "")
+	if errors.Is(err, ErrVerificationThrottled) {
+		return nil
+	}
+	return err
+}
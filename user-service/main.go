@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -15,6 +16,8 @@ import (
 	pb "ticket-booking/proto/user"
 	"ticket-booking/user-service/config"
 	"ticket-booking/user-service/internal/handler"
+	"ticket-booking/user-service/internal/helper"
+	"ticket-booking/user-service/internal/notification"
 	"ticket-booking/user-service/internal/repository"
 	"ticket-booking/user-service/internal/routes"
 	"ticket-booking/user-service/internal/service"
@@ -34,12 +37,42 @@ func main() {
 
 	log.Println("db connected")
 
+	keys, err := loadKeys(cfg)
+	if err != nil {
+		log.Fatalf("signing keys: %v", err)
+	}
+
+	travellerRepo := repository.NewTravellerRepository(pool)
+	fieldCipher, err := loadFieldCipher(context.Background(), cfg, travellerRepo)
+	if err != nil {
+		log.Fatalf("field encryption key: %v", err)
+	}
+
 	userRepo := repository.NewUserRepository(pool)
-	userService := service.NewUserService(userRepo, cfg.JWTSecret)
+	roleRepo := repository.NewRoleRepository(pool)
+	refreshRepo := repository.NewRefreshTokenRepository(pool)
+	verifyRepo := repository.NewVerificationRepository(pool)
+	resetRepo := repository.NewPasswordResetRepository(pool)
+	outboxRepo := repository.NewOutboxRepository(pool)
+	throttleRepo := repository.NewLoginThrottleRepository(pool)
+	auditRepo := repository.NewAuditRepository(pool)
+	twoFactorRepo := repository.NewTwoFactorRepository(pool)
+	userService := service.NewUserService(userRepo, roleRepo, refreshRepo, verifyRepo, resetRepo, outboxRepo, throttleRepo, auditRepo, twoFactorRepo, travellerRepo, keys, fieldCipher, cfg.PublicURL)
 	httpHandler := handler.NewHTTPHandler(userService)
 	grpcHandler := handler.NewGrpcServer(userService)
 
-	httpMux := routes.SetupRoutes(httpHandler)
+	httpMux := routes.SetupRoutes(httpHandler, keys)
+
+	// Deliver the emails every service queues in the outbox
+	mailer, err := newMailer(cfg)
+	if err != nil {
+		log.Fatalf("mailer: %v", err)
+	}
+	templates, err := notification.LoadTemplates()
+	if err != nil {
+		log.Fatalf("email templates: %v", err)
+	}
+	go notification.NewDispatcher(outboxRepo, templates, mailer).Run(context.Background())
 
 	go func() {
 		log.Printf("HTTP server listening on %s", cfg.Addr())
@@ -51,7 +84,9 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(handler.Permissions.UnaryServerInterceptor()),
+	)
 	pb.RegisterUserServiceServer(grpcServer, grpcHandler)
 
 	reflection.Register(grpcServer)
@@ -62,6 +97,42 @@ func main() {
 	}
 }
 
+// loadKeys reads the signing keys, or makes a throwaway one when no key
+// directory is configured.
+func loadKeys(cfg *config.Config) (*helper.KeySet, error) {
+	if cfg.JWTKeysDir != "" {
+		return helper.LoadKeySet(cfg.JWTKeysDir, cfg.JWTActiveKid)
+	}
+	log.Println("JWT_KEYS_DIR not set, signing with a generated key; tokens will not survive a restart")
+	return helper.GenerateKeySet()
+}
+
+// loadFieldCipher reads the key personal data is encrypted with. Without
+// one it makes a throwaway key, but only while nothing is encrypted yet:
+// data sealed with a lost key could never be read again.
+func loadFieldCipher(ctx context.Context, cfg *config.Config, travellerRepo repository.TravellerRepository) (*helper.FieldCipher, error) {
+	if cfg.FieldKey != "" {
+		return helper.NewFieldCipher(cfg.FieldKey, cfg.FieldRetiredKeys...)
+	}
+	encrypted, err := travellerRepo.HasIDNumbers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if encrypted {
+		return nil, errors.New("FIELD_ENCRYPTION_KEY is required, saved travellers hold encrypted ID numbers")
+	}
+	log.Println("FIELD_ENCRYPTION_KEY not set, using a generated key; encrypted data will not be readable after a restart")
+	return helper.GenerateFieldCipher()
+}
+
+func newMailer(cfg *config.Config) (notification.Mailer, error) {
+	if cfg.SMTPHost != "" {
+		return notification.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom), nil
+	}
+	log.Printf("SMTP_HOST not set, writing emails to %s", cfg.MailDir)
+	return notification.NewFileMailer(cfg.MailDir, cfg.MailFrom)
+}
+
 func newDBPool(cfg *config.Config) (*pgxpool.Pool, error) {
 	url := "postgres://" + cfg.DBUser + ":" + cfg.DBPassword + "@" + cfg.DBHost + ":" + cfg.DBPort + "/" + cfg.DBName + "?sslmode=" + cfg.DBSSLMode
 	pcfg, err := pgxpool.ParseConfig(url)